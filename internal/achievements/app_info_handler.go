@@ -2,6 +2,7 @@ package achievements
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"slices"
 	"time"
@@ -31,6 +32,33 @@ type AppInfoParams struct {
 	Version   AppVersion `json:"app_version"`
 }
 
+func validateAppInfoParams(raw json.RawMessage) error {
+	var params AppInfoParams
+	if err := decodeParams(raw, &params); err != nil {
+		return err
+	}
+
+	if len(params.Platforms) == 0 {
+		return errors.New("app platforms are required")
+	}
+
+	from, err := versions.NewVersion(params.Version.From)
+	if err != nil {
+		return fmt.Errorf("app version from: %w", err)
+	}
+
+	to, err := versions.NewVersion(params.Version.To)
+	if err != nil {
+		return fmt.Errorf("app version to: %w", err)
+	}
+
+	if from.GreaterThan(to) {
+		return errors.New("app version from is greater than to")
+	}
+
+	return nil
+}
+
 type AppInfoHandler struct {
 	sg SessionGetter
 }
@@ -41,30 +69,37 @@ func NewAppInfoHandler(sg SessionGetter) *AppInfoHandler {
 	}
 }
 
-func (h *AppInfoHandler) Allow(atype AchievementType) bool {
-	return atype == AchievementTypeAppInfo
+func (h *AppInfoHandler) Type() AchievementType {
+	return AchievementTypeAppInfo
 }
 
-func (h *AppInfoHandler) Process(ua *UserAchievement) error {
-	if ua.Type != AchievementTypeAppInfo {
-		return nil
+func (h *AppInfoHandler) Prepare(userID uuid.UUID) (Calculator, error) {
+	list, err := h.sg.GetLastSessions(userID, 3)
+	if err != nil {
+		return nil, fmt.Errorf("getting last sessions: %w", err)
 	}
 
+	return func(ua *UserAchievement) error {
+		return h.process(ua, list)
+	}, nil
+}
+
+func (h *AppInfoHandler) process(ua *UserAchievement, list []user.Session) error {
 	var details AppInfoParams
 	if err := json.Unmarshal(ua.Params, &details); err != nil {
 		return fmt.Errorf("unmarshalling app info: %w", err)
 	}
 
-	list, err := h.sg.GetLastSessions(ua.UserID, 3)
-	if err != nil {
-		return fmt.Errorf("getting last sessions: %w", err)
-	}
-
 	from, _ := versions.NewVersion(details.Version.From)
 	to, _ := versions.NewVersion(details.Version.To)
 
 	actualFrom := ua.CreatedAt.Add(-authWindow)
+	window := ua.Window()
 	for _, info := range list {
+		if !window.Contains(info.CreatedAt) {
+			continue
+		}
+
 		if !slices.Contains(details.Platforms, info.AppPlatform) {
 			continue
 		}
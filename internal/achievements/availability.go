@@ -0,0 +1,82 @@
+package achievements
+
+import (
+	"time"
+)
+
+type ExpiryPolicy string
+
+const (
+	// ExpiryPolicyKeep displays not achieved items as expired after the end of the window
+	ExpiryPolicyKeep ExpiryPolicy = "keep"
+	// ExpiryPolicyHide hides not achieved items after the end of the window
+	ExpiryPolicyHide ExpiryPolicy = "hide"
+)
+
+type Availability string
+
+const (
+	AvailabilityUpcoming Availability = "upcoming"
+	AvailabilityActive   Availability = "active"
+	AvailabilityEnded    Availability = "ended"
+)
+
+// Window describes the period when actions are counted for the achievement, nil bounds are not limited
+type Window struct {
+	From *time.Time
+	To   *time.Time
+}
+
+func (w Window) Contains(t time.Time) bool {
+	if w.From != nil && t.Before(*w.From) {
+		return false
+	}
+
+	if w.To != nil && !t.Before(*w.To) {
+		return false
+	}
+
+	return true
+}
+
+func (w Window) State(now time.Time) Availability {
+	switch {
+	case w.From != nil && now.Before(*w.From):
+		return AvailabilityUpcoming
+	case w.To != nil && !now.Before(*w.To):
+		return AvailabilityEnded
+	default:
+		return AvailabilityActive
+	}
+}
+
+// Countdown returns the time left till the start of upcoming or the end of active window,
+// zero is returned for ended and not limited windows
+func (w Window) Countdown(now time.Time) time.Duration {
+	switch w.State(now) {
+	case AvailabilityUpcoming:
+		return w.From.Sub(now)
+	case AvailabilityActive:
+		if w.To == nil {
+			return 0
+		}
+
+		return w.To.Sub(now)
+	default:
+		return 0
+	}
+}
+
+// key identifies windows with the same bounds
+func (w Window) key() [2]int64 {
+	var key [2]int64
+	if w.From != nil {
+		key[0] = w.From.UnixNano()
+	}
+
+	if w.To != nil {
+		key[1] = w.To.UnixNano()
+	}
+
+	return key
+}
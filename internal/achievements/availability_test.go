@@ -0,0 +1,56 @@
+package achievements
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWindow_State(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-24 * time.Hour)
+	future := now.Add(24 * time.Hour)
+
+	for name, tc := range map[string]struct {
+		window    Window
+		state     Availability
+		countdown time.Duration
+	}{
+		"not limited": {
+			window: Window{},
+			state:  AvailabilityActive,
+		},
+		"upcoming": {
+			window:    Window{From: &future},
+			state:     AvailabilityUpcoming,
+			countdown: 24 * time.Hour,
+		},
+		"active till the end": {
+			window:    Window{From: &past, To: &future},
+			state:     AvailabilityActive,
+			countdown: 24 * time.Hour,
+		},
+		"ended": {
+			window: Window{From: &past, To: &now},
+			state:  AvailabilityEnded,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.state, tc.window.State(now))
+			require.Equal(t, tc.countdown, tc.window.Countdown(now))
+		})
+	}
+}
+
+func TestWindow_Contains(t *testing.T) {
+	from := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	window := Window{From: &from, To: &to}
+
+	require.True(t, window.Contains(from))
+	require.True(t, window.Contains(to.Add(-time.Second)))
+	require.False(t, window.Contains(to))
+	require.False(t, window.Contains(from.Add(-time.Second)))
+	require.True(t, Window{}.Contains(from))
+}
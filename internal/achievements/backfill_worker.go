@@ -0,0 +1,37 @@
+package achievements
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// BackfillWorker links newly published achievements to existing users
+type BackfillWorker struct {
+	service   *Service
+	interval  time.Duration
+	batchSize int
+}
+
+func NewBackfillWorker(service *Service, interval time.Duration, batchSize int) *BackfillWorker {
+	return &BackfillWorker{
+		service:   service,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+func (w *BackfillWorker) Start(ctx context.Context) error {
+	for {
+		if err := w.service.Backfill(ctx, w.batchSize); err != nil {
+			log.Error().Err(err).Msg("backfill achievements")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(w.interval):
+		}
+	}
+}
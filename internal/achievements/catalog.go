@@ -0,0 +1,162 @@
+package achievements
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+var (
+	ErrInvalidAchievement = errors.New("invalid achievement")
+	ErrInvalidOrder       = errors.New("order must contain all active achievements once")
+
+	achievementIDPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+)
+
+// paramsValidators describes the params schema for each achievement type which could be processed by handlers
+var paramsValidators = map[AchievementType]func(json.RawMessage) error{
+	AchievementTypeAppInfo: validateAppInfoParams,
+	AchievementTypeVote:    validateVotesParams,
+
+	AchievementTypeSubscriptions:  validateCountParams,
+	AchievementTypeActivityStreak: validateStreakParams,
+	AchievementTypeAISummary:      validateCountParams,
+	AchievementTypeDelegation:     validateCountParams,
+	AchievementTypePushEnabled:    validateCountParams,
+}
+
+// Achievement describes the catalog item which is linked to each user
+type Achievement struct {
+	ID                 string
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	DeletedAt          *time.Time
+	BackfilledAt       *time.Time
+	Title              string
+	Subtitle           string
+	Description        string
+	AchievementMessage string
+	SortOrder          string
+	Exclusive          bool
+	// Points are summed for ranking users in leaderboards
+	Points int
+	// Prerequisites should be achieved to unlock the achievement
+	Prerequisites []string `gorm:"serializer:json"`
+	// Series groups tiers of the same achievement, each tier requires the previous one
+	Series string
+	Tier   int
+	// StartsAt and EndsAt limit the period when actions are counted, ExpiryPolicy describes displaying after the end
+	StartsAt     *time.Time
+	EndsAt       *time.Time
+	ExpiryPolicy ExpiryPolicy
+	Params       json.RawMessage `gorm:"serializer:json"`
+	Images       []Image         `gorm:"serializer:json"`
+	Type         AchievementType
+	// Translations contain the content for other locales, they are replaced on the update
+	Translations []Translation `gorm:"-"`
+}
+
+func (a *Achievement) TableName() string {
+	return "achievements"
+}
+
+func (a *Achievement) Archived() bool {
+	return a.DeletedAt != nil
+}
+
+func (a *Achievement) graphItem() graphItem {
+	return graphItem{
+		ID:            a.ID,
+		Prerequisites: a.Prerequisites,
+		Series:        a.Series,
+		Tier:          a.Tier,
+	}
+}
+
+// sortOrderKey keeps the text sort order column comparable as numbers
+func sortOrderKey(position int) string {
+	return fmt.Sprintf("%06d", position)
+}
+
+func validateAchievement(a *Achievement) error {
+	if !achievementIDPattern.MatchString(a.ID) {
+		return fmt.Errorf("%w: id must be a lowercase slug", ErrInvalidAchievement)
+	}
+
+	if a.Title == "" {
+		return fmt.Errorf("%w: empty title", ErrInvalidAchievement)
+	}
+
+	seen := make(map[string]struct{}, len(a.Prerequisites))
+	for _, id := range a.Prerequisites {
+		if id == a.ID {
+			return fmt.Errorf("%w: achievement can't require itself", ErrInvalidAchievement)
+		}
+
+		if _, ok := seen[id]; ok {
+			return fmt.Errorf("%w: duplicated prerequisite: %s", ErrInvalidAchievement, id)
+		}
+
+		seen[id] = struct{}{}
+	}
+
+	if a.Series != "" && !achievementIDPattern.MatchString(a.Series) {
+		return fmt.Errorf("%w: series must be a lowercase slug", ErrInvalidAchievement)
+	}
+
+	if a.Series != "" && a.Tier <= 0 {
+		return fmt.Errorf("%w: series tier must be positive", ErrInvalidAchievement)
+	}
+
+	if a.Series == "" && a.Tier != 0 {
+		return fmt.Errorf("%w: tier is set without series", ErrInvalidAchievement)
+	}
+
+	for _, image := range a.Images {
+		if image.Size == "" || image.Path == "" {
+			return fmt.Errorf("%w: image size and path are required", ErrInvalidAchievement)
+		}
+	}
+
+	if a.Points < 0 {
+		return fmt.Errorf("%w: points must not be negative", ErrInvalidAchievement)
+	}
+
+	if a.StartsAt != nil && a.EndsAt != nil && !a.EndsAt.After(*a.StartsAt) {
+		return fmt.Errorf("%w: end of the window must be after the start", ErrInvalidAchievement)
+	}
+
+	switch a.ExpiryPolicy {
+	case "":
+		a.ExpiryPolicy = ExpiryPolicyKeep
+	case ExpiryPolicyKeep, ExpiryPolicyHide:
+	default:
+		return fmt.Errorf("%w: unknown expiry policy: %s", ErrInvalidAchievement, a.ExpiryPolicy)
+	}
+
+	validate, ok := paramsValidators[a.Type]
+	if !ok {
+		return fmt.Errorf("%w: unknown type: %s", ErrInvalidAchievement, a.Type)
+	}
+
+	if len(a.Params) == 0 {
+		a.Params = json.RawMessage("{}")
+	}
+
+	if err := validate(a.Params); err != nil {
+		return fmt.Errorf("%w: params: %w", ErrInvalidAchievement, err)
+	}
+
+	return validateTranslations(a)
+}
+
+// decodeParams strictly decodes params to catch typos in the catalog
+func decodeParams(raw json.RawMessage, dest any) error {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+
+	return dec.Decode(dest)
+}
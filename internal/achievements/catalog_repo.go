@@ -0,0 +1,196 @@
+package achievements
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/goverland-labs/goverland-inbox-storage/internal/user"
+)
+
+func (r *Repo) CreateAchievement(a *Achievement) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if a.SortOrder == "" {
+			var last int
+			err := tx.
+				Raw(`select coalesce(max(sort_order::int), 0) from achievements where sort_order ~ '^[0-9]+$'`).
+				Scan(&last).
+				Error
+			if err != nil {
+				return fmt.Errorf("get last sort order: %w", err)
+			}
+
+			a.SortOrder = sortOrderKey(last + 1)
+		}
+
+		if err := tx.Create(a).Error; err != nil {
+			return err
+		}
+
+		if err := replaceTranslations(tx, a.ID, a.Translations); err != nil {
+			return fmt.Errorf("save translations: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// UpdateAchievement stores catalog fields, translations and the backfilling state, the sort order and archiving
+// are not changed
+func (r *Repo) UpdateAchievement(a *Achievement) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.
+			Model(a).
+			Select("updated_at", "title", "subtitle", "description", "achievement_message", "exclusive", "points", "prerequisites", "series", "tier", "starts_at", "ends_at", "expiry_policy", "params", "images", "backfilled_at").
+			Where("deleted_at is null").
+			Updates(a)
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := replaceTranslations(tx, a.ID, a.Translations); err != nil {
+			return fmt.Errorf("save translations: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *Repo) GetAchievement(id string) (*Achievement, error) {
+	var a Achievement
+	if err := r.db.Where("id = ?", id).First(&a).Error; err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+func (r *Repo) GetAchievements(withArchived bool) ([]Achievement, error) {
+	query := r.db.Order("sort_order")
+	if !withArchived {
+		query = query.Where("deleted_at is null")
+	}
+
+	var list []Achievement
+	if err := query.Find(&list).Error; err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (r *Repo) ArchiveAchievement(id string) error {
+	res := r.db.
+		Model(&Achievement{}).
+		Where("id = ? and deleted_at is null", id).
+		UpdateColumn("deleted_at", time.Now())
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// Reorder sets the sort order by the position in the list which must contain all active achievements
+func (r *Repo) Reorder(ids []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var active []string
+		err := tx.
+			Model(&Achievement{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("deleted_at is null").
+			Pluck("id", &active).
+			Error
+		if err != nil {
+			return fmt.Errorf("get active achievements: %w", err)
+		}
+
+		if len(active) != len(ids) {
+			return ErrInvalidOrder
+		}
+
+		for _, id := range ids {
+			if !slices.Contains(active, id) {
+				return ErrInvalidOrder
+			}
+		}
+
+		for idx, id := range ids {
+			err = tx.
+				Model(&Achievement{}).
+				Where("id = ?", id).
+				UpdateColumn("sort_order", sortOrderKey(idx+1)).
+				Error
+			if err != nil {
+				return fmt.Errorf("update sort order: %s: %w", id, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetNotBackfilled returns published achievements which are not linked to existing users yet
+func (r *Repo) GetNotBackfilled() ([]Achievement, error) {
+	var list []Achievement
+	err := r.db.
+		Where("backfilled_at is null and deleted_at is null").
+		Order("created_at").
+		Find(&list).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// BackfillUsers links the achievement to the next batch of regular not deleted users ordered by id and returns linked users
+func (r *Repo) BackfillUsers(achievementID string, after uuid.UUID, limit int) ([]uuid.UUID, error) {
+	query := `
+select id from users
+where id > ?
+  and role = ?
+  and deleted_at is null
+order by id
+limit ?`
+
+	var users []uuid.UUID
+	if err := r.db.Raw(query, after, user.RegularRole, limit).Scan(&users).Error; err != nil {
+		return nil, fmt.Errorf("get users: %w", err)
+	}
+
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	err := r.db.Exec(`
+insert into user_achievements (user_id, achievement_id)
+select id, ? from users where id in ?
+on conflict (user_id, achievement_id) DO NOTHING;`, achievementID, users).Error
+	if err != nil {
+		return nil, fmt.Errorf("link users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *Repo) MarkBackfilled(achievementID string) error {
+	return r.db.
+		Model(&Achievement{}).
+		Where("id = ?", achievementID).
+		UpdateColumn("backfilled_at", time.Now()).
+		Error
+}
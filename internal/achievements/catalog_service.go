@@ -0,0 +1,231 @@
+package achievements
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/google/uuid"
+	pevents "github.com/goverland-labs/goverland-platform-events/events/inbox"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAchievementExists        = errors.New("achievement already exists")
+	ErrAchievementHasDependents = errors.New("achievement is required by active achievements")
+)
+
+// CreateAchievement publishes the new achievement, it will be linked to existing users by the backfill worker
+func (s *Service) CreateAchievement(a *Achievement) error {
+	if err := validateAchievement(a); err != nil {
+		return err
+	}
+
+	_, err := s.repo.GetAchievement(a.ID)
+	switch {
+	case err == nil:
+		return fmt.Errorf("%w: %s", ErrAchievementExists, a.ID)
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return fmt.Errorf("get achievement: %w", err)
+	}
+
+	if err = s.checkGraph(a); err != nil {
+		return err
+	}
+
+	a.DeletedAt = nil
+	a.BackfilledAt = nil
+	if err = s.repo.CreateAchievement(a); err != nil {
+		return fmt.Errorf("create achievement: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateAchievement changes the catalog content, changed params trigger recalculation for all users
+func (s *Service) UpdateAchievement(a *Achievement) error {
+	if err := validateAchievement(a); err != nil {
+		return err
+	}
+
+	current, err := s.repo.GetAchievement(a.ID)
+	if err != nil {
+		return fmt.Errorf("get achievement: %w", err)
+	}
+
+	if current.Archived() {
+		return fmt.Errorf("%w: archived achievement can't be updated", ErrInvalidAchievement)
+	}
+
+	if current.Type != a.Type {
+		return fmt.Errorf("%w: type can't be changed", ErrInvalidAchievement)
+	}
+
+	if err = s.checkGraph(a); err != nil {
+		return err
+	}
+
+	a.BackfilledAt = current.BackfilledAt
+	if !paramsEqual(current.Params, a.Params) {
+		a.BackfilledAt = nil
+	}
+
+	if err = s.repo.UpdateAchievement(a); err != nil {
+		return fmt.Errorf("update achievement: %w", err)
+	}
+
+	return nil
+}
+
+// GetAchievement returns the catalog achievement with its translations
+func (s *Service) GetAchievement(id string) (*Achievement, error) {
+	a, err := s.repo.GetAchievement(id)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []Achievement{*a}
+	if err = s.attachTranslations(list); err != nil {
+		return nil, err
+	}
+
+	return &list[0], nil
+}
+
+// GetCatalog returns catalog achievements with their translations
+func (s *Service) GetCatalog(withArchived bool) ([]Achievement, error) {
+	list, err := s.repo.GetAchievements(withArchived)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.attachTranslations(list); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (s *Service) attachTranslations(list []Achievement) error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(list))
+	for _, a := range list {
+		ids = append(ids, a.ID)
+	}
+
+	translations, err := s.repo.GetTranslationsByAchievements(ids)
+	if err != nil {
+		return fmt.Errorf("get translations: %w", err)
+	}
+
+	byAchievement := make(map[string][]Translation, len(list))
+	for _, t := range translations {
+		byAchievement[t.AchievementID] = append(byAchievement[t.AchievementID], t)
+	}
+
+	for i := range list {
+		list[i].Translations = byAchievement[list[i].ID]
+	}
+
+	return nil
+}
+
+// ArchiveAchievement stops the achievement processing, already achieved ones are still displayed to users.
+// Achievements required by active ones are not archived, their dependents should be changed or archived first.
+func (s *Service) ArchiveAchievement(id string) error {
+	catalog, err := s.repo.GetAchievements(false)
+	if err != nil {
+		return fmt.Errorf("get catalog: %w", err)
+	}
+
+	if list := dependents(catalog, id); len(list) > 0 {
+		return fmt.Errorf("%w: %s is required by %s", ErrAchievementHasDependents, id, strings.Join(list, ", "))
+	}
+
+	return s.repo.ArchiveAchievement(id)
+}
+
+func (s *Service) ReorderAchievements(ids []string) error {
+	return s.repo.Reorder(ids)
+}
+
+// Backfill links published achievements to existing users in batches and requests the recalculation for them
+func (s *Service) Backfill(ctx context.Context, batchSize int) error {
+	list, err := s.repo.GetNotBackfilled()
+	if err != nil {
+		return fmt.Errorf("get not backfilled: %w", err)
+	}
+
+	for _, a := range list {
+		if err = s.backfill(ctx, a, batchSize); err != nil {
+			return fmt.Errorf("backfill: %s: %w", a.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) backfill(ctx context.Context, a Achievement, batchSize int) error {
+	after := uuid.Nil
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		users, err := s.repo.BackfillUsers(a.ID, after, batchSize)
+		if err != nil {
+			return err
+		}
+
+		if len(users) == 0 {
+			break
+		}
+
+		for _, userID := range users {
+			err = s.publisher.PublishJSON(ctx, pevents.SubjectRecalculateAchievement, pevents.AchievementRecalculateEvent{
+				UserID: userID,
+				Type:   pevents.AchievementType(a.Type),
+			})
+			if err != nil {
+				// the achievement stays not backfilled and the next run starts over, linking users is idempotent
+				return fmt.Errorf("publish recalculate achievement event: %s: %w", userID, err)
+			}
+		}
+
+		after = users[len(users)-1]
+	}
+
+	if err := s.repo.MarkBackfilled(a.ID); err != nil {
+		return fmt.Errorf("mark backfilled: %w", err)
+	}
+
+	log.Info().Msgf("achievement %s is backfilled", a.ID)
+
+	return nil
+}
+
+// checkGraph validates prerequisites and series of the achievement against the active catalog
+func (s *Service) checkGraph(a *Achievement) error {
+	catalog, err := s.repo.GetAchievements(false)
+	if err != nil {
+		return fmt.Errorf("get catalog: %w", err)
+	}
+
+	return validateGraph(catalog, a)
+}
+
+func paramsEqual(a, b []byte) bool {
+	var left, right any
+	if json.Unmarshal(a, &left) != nil || json.Unmarshal(b, &right) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(left, right)
+}
@@ -0,0 +1,307 @@
+package achievements
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/goverland-labs/goverland-inbox-storage/pkg/grpcsrv"
+	storagepb "github.com/goverland-labs/goverland-inbox-storage/protobuf/inboxstorage"
+)
+
+type CatalogServiceProvider interface {
+	CreateAchievement(a *Achievement) error
+	UpdateAchievement(a *Achievement) error
+	GetAchievement(id string) (*Achievement, error)
+	GetCatalog(withArchived bool) ([]Achievement, error)
+	ArchiveAchievement(id string) error
+	ReorderAchievements(ids []string) error
+	GrantAchievement(req ManualChangeRequest) ([]uuid.UUID, error)
+	RevokeAchievement(req ManualChangeRequest) ([]uuid.UUID, error)
+}
+
+// CatalogStorageServer implements catalog admin methods of the storage protocol
+type CatalogStorageServer struct {
+	storagepb.UnimplementedAchievementCatalogStorageServer
+
+	sp CatalogServiceProvider
+}
+
+func NewCatalogStorageServer(sp CatalogServiceProvider) *CatalogStorageServer {
+	return &CatalogStorageServer{
+		sp: sp,
+	}
+}
+
+func (s *CatalogStorageServer) CreateAchievement(_ context.Context, req *storagepb.CatalogAchievement) (*storagepb.CatalogAchievement, error) {
+	a, err := convertCatalogAchievementFromAPI(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.sp.CreateAchievement(a); err != nil {
+		return nil, errorMapper.Error(fmt.Errorf("create achievement: %s: %w", req.GetId(), err))
+	}
+
+	return s.getAchievement(a.ID)
+}
+
+func (s *CatalogStorageServer) UpdateAchievement(_ context.Context, req *storagepb.CatalogAchievement) (*storagepb.CatalogAchievement, error) {
+	a, err := convertCatalogAchievementFromAPI(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.sp.UpdateAchievement(a); err != nil {
+		return nil, errorMapper.Error(fmt.Errorf("update achievement: %s: %w", req.GetId(), err))
+	}
+
+	return s.getAchievement(a.ID)
+}
+
+func (s *CatalogStorageServer) GetAchievement(_ context.Context, req *storagepb.GetCatalogAchievementRequest) (*storagepb.CatalogAchievement, error) {
+	if req.GetId() == "" {
+		return nil, grpcsrv.InvalidArgument("id", "must not be empty")
+	}
+
+	return s.getAchievement(req.GetId())
+}
+
+func (s *CatalogStorageServer) getAchievement(id string) (*storagepb.CatalogAchievement, error) {
+	a, err := s.sp.GetAchievement(id)
+	if err != nil {
+		return nil, errorMapper.Error(fmt.Errorf("get achievement: %s: %w", id, err))
+	}
+
+	return convertCatalogAchievementToAPI(a), nil
+}
+
+func (s *CatalogStorageServer) GetCatalog(_ context.Context, req *storagepb.GetCatalogRequest) (*storagepb.GetCatalogResponse, error) {
+	list, err := s.sp.GetCatalog(req.GetWithArchived())
+	if err != nil {
+		return nil, errorMapper.Error(fmt.Errorf("get catalog: %w", err))
+	}
+
+	res := &storagepb.GetCatalogResponse{
+		Achievements: make([]*storagepb.CatalogAchievement, 0, len(list)),
+	}
+	for i := range list {
+		res.Achievements = append(res.Achievements, convertCatalogAchievementToAPI(&list[i]))
+	}
+
+	return res, nil
+}
+
+func (s *CatalogStorageServer) ArchiveAchievement(_ context.Context, req *storagepb.ArchiveAchievementRequest) (*emptypb.Empty, error) {
+	if req.GetId() == "" {
+		return nil, grpcsrv.InvalidArgument("id", "must not be empty")
+	}
+
+	if err := s.sp.ArchiveAchievement(req.GetId()); err != nil {
+		return nil, errorMapper.Error(fmt.Errorf("archive achievement: %s: %w", req.GetId(), err))
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *CatalogStorageServer) ReorderAchievements(_ context.Context, req *storagepb.ReorderAchievementsRequest) (*emptypb.Empty, error) {
+	if err := s.sp.ReorderAchievements(req.GetIds()); err != nil {
+		return nil, errorMapper.Error(fmt.Errorf("reorder achievements: %w", err))
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *CatalogStorageServer) GrantAchievement(ctx context.Context, req *storagepb.ManualChangeRequest) (*storagepb.ManualChangeResponse, error) {
+	change, err := convertManualChangeFromAPI(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	granted, err := s.sp.GrantAchievement(change)
+	if err != nil {
+		return nil, errorMapper.Error(fmt.Errorf("grant achievement: %s: %w", req.GetAchievementId(), err))
+	}
+
+	return convertManualChangeToAPI(granted), nil
+}
+
+func (s *CatalogStorageServer) RevokeAchievement(ctx context.Context, req *storagepb.ManualChangeRequest) (*storagepb.ManualChangeResponse, error) {
+	change, err := convertManualChangeFromAPI(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := s.sp.RevokeAchievement(change)
+	if err != nil {
+		return nil, errorMapper.Error(fmt.Errorf("revoke achievement: %s: %w", req.GetAchievementId(), err))
+	}
+
+	return convertManualChangeToAPI(revoked), nil
+}
+
+// convertManualChangeFromAPI takes the actor from the request metadata, it's stored as claimed one
+// until callers are authenticated
+func convertManualChangeFromAPI(ctx context.Context, req *storagepb.ManualChangeRequest) (ManualChangeRequest, error) {
+	actor, ok := grpcsrv.ClaimedActorFromContext(ctx)
+	if !ok {
+		return ManualChangeRequest{}, grpcsrv.InvalidArgument(grpcsrv.ActorMetadataKey, "must be provided in metadata")
+	}
+
+	userIDs := make([]uuid.UUID, 0, len(req.GetUserIds()))
+	for _, id := range req.GetUserIds() {
+		userID, err := grpcsrv.ParseUUID("user_ids", id)
+		if err != nil {
+			return ManualChangeRequest{}, err
+		}
+
+		userIDs = append(userIDs, userID)
+	}
+
+	return ManualChangeRequest{
+		AchievementID: req.GetAchievementId(),
+		UserIDs:       userIDs,
+		Actor:         grpcsrv.ClaimedActorPrefix + actor,
+		Reason:        req.GetReason(),
+	}, nil
+}
+
+func convertManualChangeToAPI(list []uuid.UUID) *storagepb.ManualChangeResponse {
+	res := &storagepb.ManualChangeResponse{
+		UserIds: make([]string, 0, len(list)),
+	}
+	for _, id := range list {
+		res.UserIds = append(res.UserIds, id.String())
+	}
+
+	return res
+}
+
+func convertCatalogAchievementFromAPI(req *storagepb.CatalogAchievement) (*Achievement, error) {
+	var params json.RawMessage
+	if req.GetParams() != "" {
+		if !json.Valid([]byte(req.GetParams())) {
+			return nil, grpcsrv.InvalidArgument("params", "must be a json object")
+		}
+
+		params = json.RawMessage(req.GetParams())
+	}
+
+	translations := make([]Translation, 0, len(req.GetTranslations()))
+	for _, t := range req.GetTranslations() {
+		translations = append(translations, Translation{
+			Locale:             t.GetLocale(),
+			Title:              t.GetTitle(),
+			Subtitle:           t.GetSubtitle(),
+			Description:        t.GetDescription(),
+			AchievementMessage: t.GetAchievementMessage(),
+			Images:             convertCatalogImagesFromAPI(t.GetImages()),
+		})
+	}
+
+	return &Achievement{
+		ID:                 req.GetId(),
+		Title:              req.GetTitle(),
+		Subtitle:           req.GetSubtitle(),
+		Description:        req.GetDescription(),
+		AchievementMessage: req.GetAchievementMessage(),
+		Exclusive:          req.GetExclusive(),
+		Points:             int(req.GetPoints()),
+		Prerequisites:      req.GetPrerequisites(),
+		Series:             req.GetSeries(),
+		Tier:               int(req.GetTier()),
+		StartsAt:           convertOptionalTime(req.GetStartsAt()),
+		EndsAt:             convertOptionalTime(req.GetEndsAt()),
+		ExpiryPolicy:       ExpiryPolicy(req.GetExpiryPolicy()),
+		Params:             params,
+		Images:             convertCatalogImagesFromAPI(req.GetImages()),
+		Type:               AchievementType(req.GetType()),
+		Translations:       translations,
+	}, nil
+}
+
+func convertCatalogAchievementToAPI(a *Achievement) *storagepb.CatalogAchievement {
+	translations := make([]*storagepb.CatalogTranslation, 0, len(a.Translations))
+	for _, t := range a.Translations {
+		translations = append(translations, &storagepb.CatalogTranslation{
+			Locale:             t.Locale,
+			Title:              t.Title,
+			Subtitle:           t.Subtitle,
+			Description:        t.Description,
+			AchievementMessage: t.AchievementMessage,
+			Images:             convertCatalogImagesToAPI(t.Images),
+		})
+	}
+
+	return &storagepb.CatalogAchievement{
+		Id:                 a.ID,
+		CreatedAt:          timestamppb.New(a.CreatedAt),
+		UpdatedAt:          timestamppb.New(a.UpdatedAt),
+		ArchivedAt:         convertOptionalTimestamp(a.DeletedAt),
+		BackfilledAt:       convertOptionalTimestamp(a.BackfilledAt),
+		Title:              a.Title,
+		Subtitle:           a.Subtitle,
+		Description:        a.Description,
+		AchievementMessage: a.AchievementMessage,
+		SortOrder:          a.SortOrder,
+		Exclusive:          a.Exclusive,
+		Points:             uint32(a.Points),
+		Prerequisites:      a.Prerequisites,
+		Series:             a.Series,
+		Tier:               uint32(a.Tier),
+		StartsAt:           convertOptionalTimestamp(a.StartsAt),
+		EndsAt:             convertOptionalTimestamp(a.EndsAt),
+		ExpiryPolicy:       string(a.ExpiryPolicy),
+		Params:             string(a.Params),
+		Images:             convertCatalogImagesToAPI(a.Images),
+		Type:               string(a.Type),
+		Translations:       translations,
+	}
+}
+
+func convertCatalogImagesFromAPI(list []*storagepb.CatalogImage) []Image {
+	images := make([]Image, 0, len(list))
+	for _, image := range list {
+		images = append(images, Image{
+			Size: image.GetSize(),
+			Path: image.GetPath(),
+		})
+	}
+
+	return images
+}
+
+func convertCatalogImagesToAPI(list []Image) []*storagepb.CatalogImage {
+	images := make([]*storagepb.CatalogImage, 0, len(list))
+	for _, image := range list {
+		images = append(images, &storagepb.CatalogImage{
+			Size: image.Size,
+			Path: image.Path,
+		})
+	}
+
+	return images
+}
+
+func convertOptionalTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+
+	t := ts.AsTime()
+
+	return &t
+}
+
+func convertOptionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,584 @@
+package achievements
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-inbox-storage/pkg/grpcsrv"
+	storagepb "github.com/goverland-labs/goverland-inbox-storage/protobuf/inboxstorage"
+)
+
+var testCatalogCreatedAt = time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+type fakeCatalog struct {
+	list    map[string]*Achievement
+	ordered []string
+	changes []ManualChangeRequest
+	err     error
+}
+
+func newFakeCatalog(list ...Achievement) *fakeCatalog {
+	f := &fakeCatalog{list: make(map[string]*Achievement)}
+	for i := range list {
+		f.list[list[i].ID] = &list[i]
+	}
+
+	return f
+}
+
+func (f *fakeCatalog) CreateAchievement(a *Achievement) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	if err := validateAchievement(a); err != nil {
+		return err
+	}
+
+	if _, ok := f.list[a.ID]; ok {
+		return ErrAchievementExists
+	}
+
+	a.CreatedAt = testCatalogCreatedAt
+	a.UpdatedAt = testCatalogCreatedAt
+	f.list[a.ID] = a
+
+	return nil
+}
+
+func (f *fakeCatalog) UpdateAchievement(a *Achievement) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	current, ok := f.list[a.ID]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+
+	if err := validateAchievement(a); err != nil {
+		return err
+	}
+
+	a.CreatedAt = current.CreatedAt
+	f.list[a.ID] = a
+
+	return nil
+}
+
+func (f *fakeCatalog) GetAchievement(id string) (*Achievement, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	a, ok := f.list[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return a, nil
+}
+
+func (f *fakeCatalog) GetCatalog(withArchived bool) ([]Achievement, error) {
+	var list []Achievement
+	for _, a := range f.list {
+		if withArchived || !a.Archived() {
+			list = append(list, *a)
+		}
+	}
+
+	slices.SortFunc(list, func(a, b Achievement) int {
+		return slices.Compare([]string{a.SortOrder, a.ID}, []string{b.SortOrder, b.ID})
+	})
+
+	return list, f.err
+}
+
+func (f *fakeCatalog) ArchiveAchievement(id string) error {
+	a, err := f.GetAchievement(id)
+	if err != nil {
+		return err
+	}
+
+	archivedAt := testCatalogCreatedAt
+	a.DeletedAt = &archivedAt
+
+	return nil
+}
+
+func (f *fakeCatalog) ReorderAchievements(ids []string) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	if len(ids) != len(f.list) {
+		return ErrInvalidOrder
+	}
+
+	f.ordered = ids
+
+	return nil
+}
+
+func (f *fakeCatalog) GrantAchievement(req ManualChangeRequest) ([]uuid.UUID, error) {
+	return f.changeAchievement(req)
+}
+
+func (f *fakeCatalog) RevokeAchievement(req ManualChangeRequest) ([]uuid.UUID, error) {
+	return f.changeAchievement(req)
+}
+
+func (f *fakeCatalog) changeAchievement(req ManualChangeRequest) ([]uuid.UUID, error) {
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := f.GetAchievement(req.AchievementID); err != nil {
+		return nil, err
+	}
+
+	f.changes = append(f.changes, req)
+
+	return req.UserIDs, nil
+}
+
+func testActorContext(actor string) context.Context {
+	if actor == "" {
+		return context.Background()
+	}
+
+	ctx, _ := grpcsrv.NewAuthInterceptor().AuthAndIdentifyTickerFunc(
+		metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcsrv.ActorMetadataKey, actor)),
+	)
+
+	return ctx
+}
+
+func testCatalogAchievement(id string) Achievement {
+	return Achievement{
+		ID:        id,
+		CreatedAt: testCatalogCreatedAt,
+		UpdatedAt: testCatalogCreatedAt,
+		Title:     "Title of " + id,
+		SortOrder: sortOrderKey(1),
+		Type:      AchievementTypeSubscriptions,
+		Params:    []byte(`{"goals":3}`),
+	}
+}
+
+func TestCatalogStorageServer_CreateAchievement(t *testing.T) {
+	startsAt := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		req    *storagepb.CatalogAchievement
+		err    error
+		code   codes.Code
+		reason string
+		field  string
+	}{
+		"created": {
+			req: &storagepb.CatalogAchievement{
+				Id:            "followers",
+				Title:         "Follower",
+				Points:        10,
+				Prerequisites: []string{"existing"},
+				StartsAt:      timestamppb.New(startsAt),
+				Params:        `{"goals":5}`,
+				Images:        []*storagepb.CatalogImage{{Size: "sm", Path: "/sm.png"}},
+				Type:          string(AchievementTypeSubscriptions),
+			},
+		},
+		"existing achievement": {
+			req: &storagepb.CatalogAchievement{
+				Id:    "existing",
+				Title: "Existing",
+				Type:  string(AchievementTypeSubscriptions),
+			},
+			code:   codes.AlreadyExists,
+			reason: "ACHIEVEMENT_EXISTS",
+		},
+		"invalid achievement": {
+			req: &storagepb.CatalogAchievement{
+				Id:   "Wrong ID",
+				Type: string(AchievementTypeSubscriptions),
+			},
+			code:   codes.InvalidArgument,
+			reason: "INVALID_ACHIEVEMENT",
+		},
+		"invalid params": {
+			req: &storagepb.CatalogAchievement{
+				Id:     "followers",
+				Title:  "Follower",
+				Params: `{"goal":`,
+				Type:   string(AchievementTypeSubscriptions),
+			},
+			code:  codes.InvalidArgument,
+			field: "params",
+		},
+		"backend error": {
+			req: &storagepb.CatalogAchievement{
+				Id:    "followers",
+				Title: "Follower",
+				Type:  string(AchievementTypeSubscriptions),
+			},
+			err:  errBackend,
+			code: codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sp := newFakeCatalog(testCatalogAchievement("existing"))
+			sp.err = tc.err
+
+			res, err := NewCatalogStorageServer(sp).CreateAchievement(context.Background(), tc.req)
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.reason, grpcsrv.ErrorReason(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.code != codes.OK {
+				return
+			}
+
+			require.Equal(t, "followers", res.GetId())
+			require.Equal(t, "Follower", res.GetTitle())
+			require.Equal(t, uint32(10), res.GetPoints())
+			require.Equal(t, []string{"existing"}, res.GetPrerequisites())
+			require.Equal(t, startsAt, res.GetStartsAt().AsTime())
+			require.Nil(t, res.GetEndsAt())
+			require.Nil(t, res.GetArchivedAt())
+			require.Equal(t, string(ExpiryPolicyKeep), res.GetExpiryPolicy())
+			require.JSONEq(t, `{"goals":5}`, res.GetParams())
+			require.Equal(t, "/sm.png", res.GetImages()[0].GetPath())
+			require.Equal(t, testCatalogCreatedAt, res.GetCreatedAt().AsTime())
+		})
+	}
+}
+
+func TestCatalogStorageServer_UpdateAchievement(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req    *storagepb.CatalogAchievement
+		code   codes.Code
+		reason string
+	}{
+		"updated": {
+			req: &storagepb.CatalogAchievement{
+				Id:     "followers",
+				Title:  "New title",
+				Params: `{"goals":7}`,
+				Type:   string(AchievementTypeSubscriptions),
+				Translations: []*storagepb.CatalogTranslation{{
+					Locale: "de",
+					Title:  "Neuer Titel",
+					Images: []*storagepb.CatalogImage{{Size: "sm", Path: "/de/sm.png"}},
+				}},
+			},
+		},
+		"unknown achievement": {
+			req: &storagepb.CatalogAchievement{
+				Id:    "unknown",
+				Title: "Unknown",
+				Type:  string(AchievementTypeSubscriptions),
+			},
+			code:   codes.NotFound,
+			reason: "NOT_FOUND",
+		},
+		"invalid achievement": {
+			req: &storagepb.CatalogAchievement{
+				Id:   "followers",
+				Type: string(AchievementTypeSubscriptions),
+			},
+			code:   codes.InvalidArgument,
+			reason: "INVALID_ACHIEVEMENT",
+		},
+		"duplicated translation": {
+			req: &storagepb.CatalogAchievement{
+				Id:    "followers",
+				Title: "New title",
+				Type:  string(AchievementTypeSubscriptions),
+				Translations: []*storagepb.CatalogTranslation{
+					{Locale: "de", Title: "Neuer Titel"},
+					{Locale: "de", Title: "Anderer Titel"},
+				},
+			},
+			code:   codes.InvalidArgument,
+			reason: "INVALID_TRANSLATION",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			res, err := NewCatalogStorageServer(newFakeCatalog(testCatalogAchievement("followers"))).UpdateAchievement(context.Background(), tc.req)
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.reason, grpcsrv.ErrorReason(err))
+			if tc.code == codes.OK {
+				require.Equal(t, "New title", res.GetTitle())
+				require.JSONEq(t, `{"goals":7}`, res.GetParams())
+				require.Equal(t, testCatalogCreatedAt, res.GetCreatedAt().AsTime())
+				require.Len(t, res.GetTranslations(), 1)
+				require.Equal(t, "de", res.GetTranslations()[0].GetLocale())
+				require.Equal(t, "Neuer Titel", res.GetTranslations()[0].GetTitle())
+				require.Equal(t, "/de/sm.png", res.GetTranslations()[0].GetImages()[0].GetPath())
+			}
+		})
+	}
+}
+
+func TestCatalogStorageServer_GetAchievement(t *testing.T) {
+	for name, tc := range map[string]struct {
+		id     string
+		code   codes.Code
+		reason string
+		field  string
+	}{
+		"achievement": {
+			id: "followers",
+		},
+		"empty id": {
+			code:  codes.InvalidArgument,
+			field: "id",
+		},
+		"unknown achievement": {
+			id:     "unknown",
+			code:   codes.NotFound,
+			reason: "NOT_FOUND",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			res, err := NewCatalogStorageServer(newFakeCatalog(testCatalogAchievement("followers"))).GetAchievement(
+				context.Background(),
+				&storagepb.GetCatalogAchievementRequest{Id: tc.id},
+			)
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.reason, grpcsrv.ErrorReason(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.code == codes.OK {
+				require.Equal(t, "followers", res.GetId())
+				require.Equal(t, "Title of followers", res.GetTitle())
+				require.Equal(t, sortOrderKey(1), res.GetSortOrder())
+				require.Equal(t, string(AchievementTypeSubscriptions), res.GetType())
+			}
+		})
+	}
+}
+
+func TestCatalogStorageServer_GetCatalog(t *testing.T) {
+	archivedAt := testCatalogCreatedAt
+	archived := testCatalogAchievement("archived")
+	archived.DeletedAt = &archivedAt
+
+	for name, tc := range map[string]struct {
+		withArchived bool
+		err          error
+		expected     []string
+		code         codes.Code
+	}{
+		"active": {
+			expected: []string{"followers"},
+		},
+		"with archived": {
+			withArchived: true,
+			expected:     []string{"archived", "followers"},
+		},
+		"backend error": {
+			err:  errBackend,
+			code: codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sp := newFakeCatalog(testCatalogAchievement("followers"), archived)
+			sp.err = tc.err
+
+			res, err := NewCatalogStorageServer(sp).GetCatalog(context.Background(), &storagepb.GetCatalogRequest{
+				WithArchived: tc.withArchived,
+			})
+
+			require.Equal(t, tc.code, status.Code(err))
+
+			var ids []string
+			for _, a := range res.GetAchievements() {
+				ids = append(ids, a.GetId())
+				require.Equal(t, a.GetId() == "archived", a.GetArchivedAt() != nil)
+			}
+			require.Equal(t, tc.expected, ids)
+		})
+	}
+}
+
+func TestCatalogStorageServer_ArchiveAchievement(t *testing.T) {
+	for name, tc := range map[string]struct {
+		id     string
+		code   codes.Code
+		reason string
+		field  string
+	}{
+		"archived": {
+			id: "followers",
+		},
+		"empty id": {
+			code:  codes.InvalidArgument,
+			field: "id",
+		},
+		"unknown achievement": {
+			id:     "unknown",
+			code:   codes.NotFound,
+			reason: "NOT_FOUND",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sp := newFakeCatalog(testCatalogAchievement("followers"))
+
+			_, err := NewCatalogStorageServer(sp).ArchiveAchievement(context.Background(), &storagepb.ArchiveAchievementRequest{
+				Id: tc.id,
+			})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.reason, grpcsrv.ErrorReason(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			require.Equal(t, tc.code == codes.OK, sp.list["followers"].Archived())
+		})
+	}
+}
+
+func TestCatalogStorageServer_ReorderAchievements(t *testing.T) {
+	for name, tc := range map[string]struct {
+		ids    []string
+		code   codes.Code
+		reason string
+	}{
+		"reordered": {
+			ids: []string{"votes", "followers"},
+		},
+		"missing achievement": {
+			ids:    []string{"votes"},
+			code:   codes.InvalidArgument,
+			reason: "INVALID_ORDER",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sp := newFakeCatalog(testCatalogAchievement("followers"), testCatalogAchievement("votes"))
+
+			_, err := NewCatalogStorageServer(sp).ReorderAchievements(context.Background(), &storagepb.ReorderAchievementsRequest{
+				Ids: tc.ids,
+			})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.reason, grpcsrv.ErrorReason(err))
+			if tc.code == codes.OK {
+				require.Equal(t, tc.ids, sp.ordered)
+			}
+		})
+	}
+}
+
+func TestCatalogStorageServer_GrantAchievement(t *testing.T) {
+	userID := uuid.New()
+
+	for name, tc := range map[string]struct {
+		actor  string
+		req    *storagepb.ManualChangeRequest
+		err    error
+		code   codes.Code
+		reason string
+		field  string
+	}{
+		"granted": {
+			actor: "admin",
+			req:   &storagepb.ManualChangeRequest{AchievementId: "votes", UserIds: []string{userID.String()}, Reason: "lost votes"},
+		},
+		"missing actor": {
+			req:   &storagepb.ManualChangeRequest{AchievementId: "votes", UserIds: []string{userID.String()}, Reason: "lost votes"},
+			code:  codes.InvalidArgument,
+			field: grpcsrv.ActorMetadataKey,
+		},
+		"invalid user id": {
+			actor: "admin",
+			req:   &storagepb.ManualChangeRequest{AchievementId: "votes", UserIds: []string{"wrong"}, Reason: "lost votes"},
+			code:  codes.InvalidArgument,
+			field: "user_ids",
+		},
+		"empty reason": {
+			actor:  "admin",
+			req:    &storagepb.ManualChangeRequest{AchievementId: "votes", UserIds: []string{userID.String()}},
+			code:   codes.InvalidArgument,
+			reason: "INVALID_GRANT",
+		},
+		"unknown achievement": {
+			actor:  "admin",
+			req:    &storagepb.ManualChangeRequest{AchievementId: "unknown", UserIds: []string{userID.String()}, Reason: "lost votes"},
+			code:   codes.NotFound,
+			reason: "NOT_FOUND",
+		},
+		"backend error": {
+			actor: "admin",
+			req:   &storagepb.ManualChangeRequest{AchievementId: "votes", UserIds: []string{userID.String()}, Reason: "lost votes"},
+			err:   errBackend,
+			code:  codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sp := newFakeCatalog(testCatalogAchievement("votes"))
+			sp.err = tc.err
+
+			res, err := NewCatalogStorageServer(sp).GrantAchievement(testActorContext(tc.actor), tc.req)
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.reason, grpcsrv.ErrorReason(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.code != codes.OK {
+				return
+			}
+
+			require.Equal(t, []string{userID.String()}, res.GetUserIds())
+			require.Len(t, sp.changes, 1)
+			require.Equal(t, "claimed:admin", sp.changes[0].Actor)
+			require.Equal(t, "lost votes", sp.changes[0].Reason)
+			require.Equal(t, []uuid.UUID{userID}, sp.changes[0].UserIDs)
+		})
+	}
+}
+
+func TestCatalogStorageServer_RevokeAchievement(t *testing.T) {
+	userID := uuid.New()
+
+	for name, tc := range map[string]struct {
+		actor string
+		code  codes.Code
+		field string
+	}{
+		"revoked": {
+			actor: "admin",
+		},
+		"missing actor": {
+			code:  codes.InvalidArgument,
+			field: grpcsrv.ActorMetadataKey,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sp := newFakeCatalog(testCatalogAchievement("votes"))
+
+			res, err := NewCatalogStorageServer(sp).RevokeAchievement(testActorContext(tc.actor), &storagepb.ManualChangeRequest{
+				AchievementId: "votes",
+				UserIds:       []string{userID.String()},
+				Reason:        "abuse",
+			})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.code != codes.OK {
+				return
+			}
+
+			require.Equal(t, []string{userID.String()}, res.GetUserIds())
+			require.Equal(t, "claimed:admin", sp.changes[0].Actor)
+		})
+	}
+}
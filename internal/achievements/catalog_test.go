@@ -0,0 +1,62 @@
+package achievements
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_validateAchievement(t *testing.T) {
+	for name, tc := range map[string]struct {
+		achievement Achievement
+		valid       bool
+	}{
+		"vote": {
+			achievement: Achievement{ID: "first-vote", Title: "First vote", Type: AchievementTypeVote, Params: json.RawMessage(`{"goals": 1, "verified": true}`)},
+			valid:       true,
+		},
+		"app info": {
+			achievement: Achievement{ID: "beta-2", Title: "Beta", Type: AchievementTypeAppInfo, Params: json.RawMessage(`{"app_platforms": ["iOS"], "app_version": {"from": "1.0.0", "to": "1.2.0"}}`)},
+			valid:       true,
+		},
+		"wrong id": {
+			achievement: Achievement{ID: "First Vote", Title: "First vote", Type: AchievementTypeVote, Params: json.RawMessage(`{"goals": 1}`)},
+		},
+		"empty title": {
+			achievement: Achievement{ID: "first-vote", Type: AchievementTypeVote, Params: json.RawMessage(`{"goals": 1}`)},
+		},
+		"requires itself": {
+			achievement: Achievement{ID: "first-vote", Title: "First vote", Type: AchievementTypeVote, Prerequisites: []string{"first-vote"}, Params: json.RawMessage(`{"goals": 1}`)},
+		},
+		"tier without series": {
+			achievement: Achievement{ID: "first-vote", Title: "First vote", Type: AchievementTypeVote, Tier: 1, Params: json.RawMessage(`{"goals": 1}`)},
+		},
+		"unknown type": {
+			achievement: Achievement{ID: "first-vote", Title: "First vote", Type: "likes", Params: json.RawMessage(`{"goals": 1}`)},
+		},
+		"zero goals": {
+			achievement: Achievement{ID: "first-vote", Title: "First vote", Type: AchievementTypeVote, Params: json.RawMessage(`{"goals": 0}`)},
+		},
+		"unknown params field": {
+			achievement: Achievement{ID: "first-vote", Title: "First vote", Type: AchievementTypeVote, Params: json.RawMessage(`{"goal": 1}`)},
+		},
+		"wrong versions window": {
+			achievement: Achievement{ID: "beta-2", Title: "Beta", Type: AchievementTypeAppInfo, Params: json.RawMessage(`{"app_platforms": ["iOS"], "app_version": {"from": "1.3.0", "to": "1.2.0"}}`)},
+		},
+		"image without path": {
+			achievement: Achievement{ID: "first-vote", Title: "First vote", Type: AchievementTypeVote, Params: json.RawMessage(`{"goals": 1}`), Images: []Image{{Size: "large"}}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := validateAchievement(&tc.achievement)
+			if tc.valid {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, ErrInvalidAchievement)
+		})
+	}
+}
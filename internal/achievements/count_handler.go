@@ -0,0 +1,209 @@
+package achievements
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// maxStreakDays limits the activity history loaded for calculating streaks
+	maxStreakDays = 365
+)
+
+type SubscriptionCounter interface {
+	CountByUser(userID uuid.UUID, from, to *time.Time) (int, error)
+}
+
+type ActivityProvider interface {
+	GetActivityDays(userID uuid.UUID, since time.Time) ([]time.Time, error)
+}
+
+type AIRequestCounter interface {
+	CountAIRequests(userID uuid.UUID, from, to *time.Time) (int, error)
+}
+
+type DelegationCounter interface {
+	CountDelegations(userID uuid.UUID, from, to *time.Time) (int, error)
+}
+
+type PushTokenCounter interface {
+	CountPushTokens(userID uuid.UUID) (int, error)
+}
+
+// CountParams describes achievements reached by the number of user actions, one action is required by default
+type CountParams struct {
+	Goals *int `json:"goals,omitempty"`
+}
+
+func validateCountParams(raw json.RawMessage) error {
+	var params CountParams
+	if err := decodeParams(raw, &params); err != nil {
+		return err
+	}
+
+	if params.Goals != nil && *params.Goals <= 0 {
+		return errors.New("goals must be positive")
+	}
+
+	return nil
+}
+
+func validateStreakParams(raw json.RawMessage) error {
+	if err := validateCountParams(raw); err != nil {
+		return err
+	}
+
+	var params CountParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return err
+	}
+
+	if params.Goals != nil && *params.Goals > maxStreakDays {
+		return fmt.Errorf("goals must not exceed %d days", maxStreakDays)
+	}
+
+	return nil
+}
+
+// CountHandler calculates the progress by the number of actions provided by the counter
+// within the achievement window
+type CountHandler struct {
+	atype   AchievementType
+	counter func(userID uuid.UUID, window Window) (int, error)
+}
+
+// countInWindow adapts counters which support limiting actions by the creation time
+func countInWindow(counter func(userID uuid.UUID, from, to *time.Time) (int, error)) func(uuid.UUID, Window) (int, error) {
+	return func(userID uuid.UUID, window Window) (int, error) {
+		return counter(userID, window.From, window.To)
+	}
+}
+
+// NewSubscriptionsHandler counts daos followed by the user
+func NewSubscriptionsHandler(sc SubscriptionCounter) *CountHandler {
+	return &CountHandler{
+		atype:   AchievementTypeSubscriptions,
+		counter: countInWindow(sc.CountByUser),
+	}
+}
+
+// NewActivityStreakHandler counts days in a row with the user activity
+func NewActivityStreakHandler(ap ActivityProvider) *CountHandler {
+	return &CountHandler{
+		atype: AchievementTypeActivityStreak,
+		counter: func(userID uuid.UUID, window Window) (int, error) {
+			now := time.Now().UTC()
+			if window.To != nil && window.To.Before(now) {
+				now = window.To.UTC()
+			}
+
+			days, err := ap.GetActivityDays(userID, now.AddDate(0, 0, -maxStreakDays))
+			if err != nil {
+				return 0, err
+			}
+
+			inWindow := make([]time.Time, 0, len(days))
+			for _, day := range days {
+				if window.From == nil || !truncateDay(day).Before(truncateDay(*window.From)) {
+					inWindow = append(inWindow, day)
+				}
+			}
+
+			return currentStreak(inWindow, now), nil
+		},
+	}
+}
+
+// NewAISummaryHandler counts AI summaries read by the user
+func NewAISummaryHandler(ac AIRequestCounter) *CountHandler {
+	return &CountHandler{
+		atype:   AchievementTypeAISummary,
+		counter: countInWindow(ac.CountAIRequests),
+	}
+}
+
+// NewDelegationHandler counts delegations made by the user
+func NewDelegationHandler(dc DelegationCounter) *CountHandler {
+	return &CountHandler{
+		atype:   AchievementTypeDelegation,
+		counter: countInWindow(dc.CountDelegations),
+	}
+}
+
+// NewPushEnabledHandler counts devices with enabled push notifications
+func NewPushEnabledHandler(pc PushTokenCounter) *CountHandler {
+	return &CountHandler{
+		atype: AchievementTypePushEnabled,
+		// enabled notifications are the current state of devices, so the window is not applied
+		counter: func(userID uuid.UUID, _ Window) (int, error) {
+			return pc.CountPushTokens(userID)
+		},
+	}
+}
+
+func (h *CountHandler) Type() AchievementType {
+	return h.atype
+}
+
+// Prepare returns the calculator which counts actions once for each distinct window
+func (h *CountHandler) Prepare(userID uuid.UUID) (Calculator, error) {
+	counts := make(map[[2]int64]int)
+
+	return func(ua *UserAchievement) error {
+		window := ua.Window()
+		count, ok := counts[window.key()]
+		if !ok {
+			var err error
+			count, err = h.counter(userID, window)
+			if err != nil {
+				return fmt.Errorf("count %s: %w", h.atype, err)
+			}
+
+			counts[window.key()] = count
+		}
+
+		ua.Progress = min(count, ua.Goal)
+		if ua.Progress >= ua.Goal {
+			now := time.Now()
+			ua.AchievedAt = &now
+		}
+
+		return nil
+	}, nil
+}
+
+// currentStreak returns the number of days in a row till today or yesterday, days are ordered from the latest one
+func currentStreak(days []time.Time, now time.Time) int {
+	expected := truncateDay(now)
+	if len(days) > 0 && truncateDay(days[0]).Before(expected) {
+		// the streak is not broken until the end of the day
+		expected = expected.AddDate(0, 0, -1)
+	}
+
+	streak := 0
+	for _, day := range days {
+		day = truncateDay(day)
+		if day.After(expected) {
+			continue
+		}
+
+		if !day.Equal(expected) {
+			break
+		}
+
+		streak++
+		expected = expected.AddDate(0, 0, -1)
+	}
+
+	return streak
+}
+
+func truncateDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
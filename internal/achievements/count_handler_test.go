@@ -0,0 +1,70 @@
+package achievements
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_currentStreak(t *testing.T) {
+	now := time.Date(2024, 5, 10, 15, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time {
+		return time.Date(2024, 5, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	for name, tc := range map[string]struct {
+		days     []time.Time
+		expected int
+	}{
+		"empty":               {expected: 0},
+		"today only":          {days: []time.Time{day(10)}, expected: 1},
+		"till today":          {days: []time.Time{day(10), day(9), day(8)}, expected: 3},
+		"till yesterday":      {days: []time.Time{day(9), day(8)}, expected: 2},
+		"broken before today": {days: []time.Time{day(8), day(7)}, expected: 0},
+		"gap":                 {days: []time.Time{day(10), day(9), day(7), day(6)}, expected: 2},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expected, currentStreak(tc.days, now))
+		})
+	}
+}
+
+func TestCountHandler_Prepare(t *testing.T) {
+	calls := 0
+	h := &CountHandler{
+		atype: AchievementTypeSubscriptions,
+		counter: func(_ uuid.UUID, window Window) (int, error) {
+			calls++
+			if window.From != nil {
+				return 1, nil
+			}
+
+			return 3, nil
+		},
+	}
+
+	calculate, err := h.Prepare(uuid.New())
+	require.NoError(t, err)
+
+	reached := &UserAchievement{Goal: 3}
+	require.NoError(t, calculate(reached))
+	require.Equal(t, 3, reached.Progress)
+	require.NotNil(t, reached.AchievedAt)
+
+	inProgress := &UserAchievement{Goal: 10}
+	require.NoError(t, calculate(inProgress))
+	require.Equal(t, 3, inProgress.Progress)
+	require.Nil(t, inProgress.AchievedAt)
+
+	require.Equal(t, 1, calls)
+
+	from := time.Now().Add(-time.Hour)
+	seasonal := &UserAchievement{Goal: 3, StartsAt: &from}
+	require.NoError(t, calculate(seasonal))
+	require.Equal(t, 1, seasonal.Progress)
+	require.Nil(t, seasonal.AchievedAt)
+
+	require.Equal(t, 2, calls)
+}
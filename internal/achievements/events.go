@@ -0,0 +1,57 @@
+package achievements
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/goverland-labs/goverland-inbox-storage/internal/settings"
+)
+
+const SubjectAchievementUnlocked = "inbox.achievement.unlocked"
+
+// AchievementUnlockedPayload is published once per unlock. ID is the same for redelivered events,
+// so consumers can skip duplicates.
+type AchievementUnlockedPayload struct {
+	ID                 string    `json:"id"`
+	UserID             uuid.UUID `json:"user_id"`
+	AchievementID      string    `json:"achievement_id"`
+	Title              string    `json:"title"`
+	Subtitle           string    `json:"subtitle"`
+	AchievementMessage string    `json:"achievement_message"`
+	Images             []Image   `json:"images"`
+	Exclusive          bool      `json:"exclusive"`
+	AchievedAt         time.Time `json:"achieved_at"`
+}
+
+func newUnlockedPayload(ua UserAchievement) AchievementUnlockedPayload {
+	return AchievementUnlockedPayload{
+		ID:                 fmt.Sprintf("%s:%s:%d", ua.UserID, ua.AchievementID, ua.AchievedAt.Unix()),
+		UserID:             ua.UserID,
+		AchievementID:      ua.AchievementID,
+		Title:              ua.Title,
+		Subtitle:           ua.Subtitle,
+		AchievementMessage: ua.AchievementMessage,
+		Images:             ua.Images,
+		Exclusive:          ua.Exclusive,
+		AchievedAt:         *ua.AchievedAt,
+	}
+}
+
+// unlockMessages localises unlocked achievements and builds their outbox messages
+func (s *Service) unlockMessages(list []UserAchievement) ([]*settings.OutboxMessage, error) {
+	s.localizeUnlocks(list)
+
+	msgs := make([]*settings.OutboxMessage, 0, len(list))
+	for _, ua := range list {
+		msg, err := settings.NewOutboxMessage(SubjectAchievementUnlocked, newUnlockedPayload(ua))
+		if err != nil {
+			return nil, err
+		}
+
+		msgs = append(msgs, msg)
+	}
+
+	return msgs, nil
+}
@@ -0,0 +1,110 @@
+package achievements
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const maxGrantUsers = 1000
+
+var ErrInvalidGrant = errors.New("invalid grant request")
+
+type ManualAction string
+
+const (
+	ManualActionGrant  ManualAction = "grant"
+	ManualActionRevoke ManualAction = "revoke"
+)
+
+// ManualChange records the achievement granted or revoked by the admin
+type ManualChange struct {
+	ID            uint64 `gorm:"primaryKey"`
+	CreatedAt     time.Time
+	UserID        uuid.UUID
+	AchievementID string
+	Action        ManualAction
+	Actor         string
+	Reason        string
+}
+
+func (ManualChange) TableName() string {
+	return "achievement_manual_changes"
+}
+
+// ManualChangeRequest describes granting or revoking the achievement for the list of users
+type ManualChangeRequest struct {
+	AchievementID string
+	UserIDs       []uuid.UUID
+	Actor         string
+	Reason        string
+}
+
+func (r *ManualChangeRequest) validate() error {
+	r.Actor = strings.TrimSpace(r.Actor)
+	r.Reason = strings.TrimSpace(r.Reason)
+
+	if r.AchievementID == "" {
+		return fmt.Errorf("%w: empty achievement id", ErrInvalidGrant)
+	}
+
+	if r.Actor == "" {
+		return fmt.Errorf("%w: empty actor", ErrInvalidGrant)
+	}
+
+	if r.Reason == "" {
+		return fmt.Errorf("%w: empty reason", ErrInvalidGrant)
+	}
+
+	if len(r.UserIDs) == 0 || len(r.UserIDs) > maxGrantUsers {
+		return fmt.Errorf("%w: users count must be between 1 and %d", ErrInvalidGrant, maxGrantUsers)
+	}
+
+	return nil
+}
+
+// GrantAchievement marks the achievement as achieved for the users, the unlock event is published via the outbox
+// as for earned achievements. Users who already have the achievement are skipped, changed users are returned.
+func (s *Service) GrantAchievement(req ManualChangeRequest) ([]uuid.UUID, error) {
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := s.repo.GetAchievement(req.AchievementID)
+	if err != nil {
+		return nil, fmt.Errorf("get achievement: %w", err)
+	}
+
+	if a.Archived() {
+		return nil, fmt.Errorf("%w: archived achievement can't be granted", ErrInvalidGrant)
+	}
+
+	granted, err := s.repo.Grant(req, s.unlockMessages)
+	if err != nil {
+		return nil, fmt.Errorf("grant achievement: %w", err)
+	}
+
+	return granted, nil
+}
+
+// RevokeAchievement clears the achieved and viewed state and the progress for the users, users without the
+// achievement are skipped. Revoked achievements are not recalculated until they are granted again.
+func (s *Service) RevokeAchievement(req ManualChangeRequest) ([]uuid.UUID, error) {
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.GetAchievement(req.AchievementID); err != nil {
+		return nil, fmt.Errorf("get achievement: %w", err)
+	}
+
+	revoked, err := s.repo.Revoke(req)
+	if err != nil {
+		return nil, fmt.Errorf("revoke achievement: %w", err)
+	}
+
+	return revoked, nil
+}
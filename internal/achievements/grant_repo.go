@@ -0,0 +1,174 @@
+package achievements
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-inbox-storage/internal/settings"
+	"github.com/goverland-labs/goverland-inbox-storage/internal/user"
+)
+
+// manualProgress is the progress change of the user achievement made by the grant or revoke
+type manualProgress struct {
+	UserID      uuid.UUID
+	OldProgress int
+	NewProgress int
+	Goal        int
+	AchievedAt  *time.Time
+}
+
+// Grant links the achievement to active regular users if needed, marks it achieved with the reached goal and
+// clears the revoked state. Unlock events are stored to the outbox in the same transaction as for earned achievements.
+func (r *Repo) Grant(req ManualChangeRequest, messages func(list []UserAchievement) ([]*settings.OutboxMessage, error)) ([]uuid.UUID, error) {
+	var granted []manualProgress
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`
+insert into user_achievements (user_id, achievement_id)
+select id, ? from users
+where id in ?
+  and role = ?
+  and deleted_at is null
+on conflict (user_id, achievement_id) DO NOTHING;`, req.AchievementID, req.UserIDs, user.RegularRole).Error
+		if err != nil {
+			return fmt.Errorf("link users: %w", err)
+		}
+
+		// prev is the row before the update, it's used for the progress history
+		err = tx.Raw(`
+update user_achievements ua
+set achieved_at = now(),
+    revoked_at = null,
+    progress = greatest(ua.progress, coalesce((a.params->>'goals')::int, 1))
+from achievements a, users u, user_achievements prev
+where a.id = ua.achievement_id
+  and u.id = ua.user_id
+  and prev.user_id = ua.user_id
+  and prev.achievement_id = ua.achievement_id
+  and u.role = ?
+  and u.deleted_at is null
+  and ua.achievement_id = ?
+  and ua.user_id in ?
+  and ua.achieved_at is null
+returning ua.user_id, prev.progress old_progress, ua.progress new_progress, coalesce((a.params->>'goals')::int, 1) goal, ua.achieved_at`,
+			user.RegularRole, req.AchievementID, req.UserIDs).Scan(&granted).Error
+		if err != nil {
+			return fmt.Errorf("mark achieved: %w", err)
+		}
+
+		if err = createManualChanges(tx, req, ManualActionGrant, granted); err != nil {
+			return err
+		}
+
+		unlocked := make([]UserAchievement, 0, len(granted))
+		for _, p := range granted {
+			unlocked = append(unlocked, UserAchievement{
+				UserID:        p.UserID,
+				AchievementID: req.AchievementID,
+				AchievedAt:    p.AchievedAt,
+			})
+		}
+
+		return addUnlocks(tx, unlocked, messages)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return manualProgressUsers(granted), nil
+}
+
+// Revoke clears the achieved and viewed state and resets the progress. Revoked achievements are not calculated,
+// so they are not achieved and published again until granted.
+func (r *Repo) Revoke(req ManualChangeRequest) ([]uuid.UUID, error) {
+	var revoked []manualProgress
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// prev is the row before the update, it's used for the progress history
+		err := tx.Raw(`
+update user_achievements ua
+set achieved_at = null,
+    viewed_at = null,
+    progress = 0,
+    revoked_at = now()
+from achievements a, user_achievements prev
+where a.id = ua.achievement_id
+  and prev.user_id = ua.user_id
+  and prev.achievement_id = ua.achievement_id
+  and ua.achievement_id = ?
+  and ua.user_id in ?
+  and ua.achieved_at is not null
+returning ua.user_id, prev.progress old_progress, ua.progress new_progress, coalesce((a.params->>'goals')::int, 1) goal`,
+			req.AchievementID, req.UserIDs).Scan(&revoked).Error
+		if err != nil {
+			return fmt.Errorf("clear achieved: %w", err)
+		}
+
+		return createManualChanges(tx, req, ManualActionRevoke, revoked)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return manualProgressUsers(revoked), nil
+}
+
+func manualProgressUsers(list []manualProgress) []uuid.UUID {
+	users := make([]uuid.UUID, 0, len(list))
+	for _, p := range list {
+		users = append(users, p.UserID)
+	}
+
+	return users
+}
+
+// newManualProgressEvents returns the progress history of the grant or revoke, granted achievements are achieved
+func newManualProgressEvents(achievementID string, action ManualAction, list []manualProgress, now time.Time) []ProgressEvent {
+	events := make([]ProgressEvent, 0, len(list))
+	for _, p := range list {
+		events = append(events, ProgressEvent{
+			CreatedAt:     now,
+			UserID:        p.UserID,
+			AchievementID: achievementID,
+			OldProgress:   p.OldProgress,
+			NewProgress:   p.NewProgress,
+			Goal:          p.Goal,
+			Achieved:      action == ManualActionGrant,
+			Trigger:       manualTrigger(action),
+		})
+	}
+
+	return events
+}
+
+// createManualChanges stores the manual change and the progress history for changed users
+func createManualChanges(tx *gorm.DB, req ManualChangeRequest, action ManualAction, changed []manualProgress) error {
+	if len(changed) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	list := make([]ManualChange, 0, len(changed))
+	for _, p := range changed {
+		list = append(list, ManualChange{
+			CreatedAt:     now,
+			UserID:        p.UserID,
+			AchievementID: req.AchievementID,
+			Action:        action,
+			Actor:         req.Actor,
+			Reason:        req.Reason,
+		})
+	}
+
+	if err := tx.Create(&list).Error; err != nil {
+		return fmt.Errorf("create manual changes: %w", err)
+	}
+
+	events := newManualProgressEvents(req.AchievementID, action, changed, now)
+	if err := tx.Create(&events).Error; err != nil {
+		return fmt.Errorf("create progress events: %w", err)
+	}
+
+	return nil
+}
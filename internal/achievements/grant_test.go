@@ -0,0 +1,45 @@
+package achievements
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManualChangeRequest_validate(t *testing.T) {
+	users := []uuid.UUID{uuid.New()}
+
+	for name, tc := range map[string]struct {
+		req   ManualChangeRequest
+		valid bool
+	}{
+		"valid": {
+			req:   ManualChangeRequest{AchievementID: "early-tester", UserIDs: users, Actor: "admin", Reason: "beta testing"},
+			valid: true,
+		},
+		"empty reason": {
+			req: ManualChangeRequest{AchievementID: "early-tester", UserIDs: users, Actor: "admin", Reason: "  "},
+		},
+		"empty actor": {
+			req: ManualChangeRequest{AchievementID: "early-tester", UserIDs: users, Reason: "beta testing"},
+		},
+		"no users": {
+			req: ManualChangeRequest{AchievementID: "early-tester", Actor: "admin", Reason: "beta testing"},
+		},
+		"too many users": {
+			req: ManualChangeRequest{AchievementID: "early-tester", UserIDs: make([]uuid.UUID, maxGrantUsers+1), Actor: "admin", Reason: "beta testing"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := tc.req.validate()
+			if tc.valid {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, ErrInvalidGrant)
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package achievements
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// graphItem describes the achievement position in the dependency graph
+type graphItem struct {
+	ID            string
+	Prerequisites []string
+	Series        string
+	Tier          int
+}
+
+// dependencies returns the effective prerequisites by achievement id. Each tier of the series
+// additionally requires the previous tier of the same series.
+func dependencies(items []graphItem) map[string][]string {
+	deps := make(map[string][]string, len(items))
+	series := make(map[string][]graphItem)
+	for _, item := range items {
+		deps[item.ID] = slices.Clone(item.Prerequisites)
+
+		if item.Series != "" {
+			series[item.Series] = append(series[item.Series], item)
+		}
+	}
+
+	for _, tiers := range series {
+		sort.Slice(tiers, func(i, j int) bool {
+			return tiers[i].Tier < tiers[j].Tier
+		})
+
+		for i := 1; i < len(tiers); i++ {
+			if !slices.Contains(deps[tiers[i].ID], tiers[i-1].ID) {
+				deps[tiers[i].ID] = append(deps[tiers[i].ID], tiers[i-1].ID)
+			}
+		}
+	}
+
+	return deps
+}
+
+// findCycle returns the path of the first found cycle or nil if the graph is acyclic
+func findCycle(deps map[string][]string) []string {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+
+	ids := make([]string, 0, len(deps))
+	for id := range deps {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	state := make(map[string]int, len(deps))
+	path := make([]string, 0, len(deps))
+
+	var visit func(id string) []string
+	visit = func(id string) []string {
+		switch state[id] {
+		case visited:
+			return nil
+		case visiting:
+			start := slices.Index(path, id)
+
+			return append(slices.Clone(path[start:]), id)
+		}
+
+		state[id] = visiting
+		path = append(path, id)
+		for _, dep := range deps[id] {
+			if cycle := visit(dep); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[id] = visited
+
+		return nil
+	}
+
+	for _, id := range ids {
+		if cycle := visit(id); cycle != nil {
+			return cycle
+		}
+	}
+
+	return nil
+}
+
+// validateGraph checks prerequisites and series of the changed achievement against the active catalog
+func validateGraph(catalog []Achievement, changed *Achievement) error {
+	items := make([]graphItem, 0, len(catalog)+1)
+	known := make(map[string]struct{}, len(catalog))
+	for _, a := range catalog {
+		if a.ID == changed.ID {
+			continue
+		}
+
+		if changed.Series != "" && a.Series == changed.Series && a.Tier == changed.Tier {
+			return fmt.Errorf("%w: tier %d of series %s is taken by %s", ErrInvalidAchievement, changed.Tier, changed.Series, a.ID)
+		}
+
+		known[a.ID] = struct{}{}
+		items = append(items, a.graphItem())
+	}
+
+	for _, id := range changed.Prerequisites {
+		if _, ok := known[id]; !ok {
+			return fmt.Errorf("%w: unknown prerequisite: %s", ErrInvalidAchievement, id)
+		}
+	}
+
+	items = append(items, changed.graphItem())
+	if cycle := findCycle(dependencies(items)); cycle != nil {
+		return fmt.Errorf("%w: prerequisites cycle: %s", ErrInvalidAchievement, strings.Join(cycle, " -> "))
+	}
+
+	return nil
+}
+
+// dependents returns sorted ids of catalog achievements which require the achievement directly or as
+// the previous tier of their series
+func dependents(catalog []Achievement, id string) []string {
+	items := make([]graphItem, 0, len(catalog))
+	for _, a := range catalog {
+		items = append(items, a.graphItem())
+	}
+
+	var list []string
+	for item, deps := range dependencies(items) {
+		if item != id && slices.Contains(deps, id) {
+			list = append(list, item)
+		}
+	}
+	sort.Strings(list)
+
+	return list
+}
+
+// Requirement describes the prerequisite which should be achieved to unlock the achievement
+type Requirement struct {
+	AchievementID string
+	Title         string
+	Achieved      bool
+}
+
+// resolveLocks fills requirements and locked state of user achievements displayed to the user. Prerequisites
+// which are not displayed, like missing, archived or not achieved exclusive ones, can't be achieved, so they
+// lock achievements without being listed in requirements.
+func resolveLocks(list []*UserAchievement) {
+	deps, byID := displayedGraph(list, nil)
+	for _, ua := range list {
+		ua.Requirements = ua.Requirements[:0]
+		ua.Locked = false
+		for _, id := range deps[ua.AchievementID] {
+			required, ok := byID[id]
+			if ok {
+				ua.Requirements = append(ua.Requirements, Requirement{
+					AchievementID: id,
+					Title:         required.Title,
+					Achieved:      required.Achieved(),
+				})
+			}
+
+			if !ua.Achieved() && (!ok || !required.Achieved()) {
+				ua.Locked = true
+			}
+		}
+	}
+}
+
+// lockedAchievements returns ids of not achieved candidates locked by the same rule as displayed achievements
+func lockedAchievements(displayed, candidates []*UserAchievement) map[string]bool {
+	deps, byID := displayedGraph(displayed, candidates)
+
+	locked := make(map[string]bool)
+	for _, ua := range candidates {
+		for _, id := range deps[ua.AchievementID] {
+			if required, ok := byID[id]; !ok || !required.Achieved() {
+				locked[ua.AchievementID] = true
+			}
+		}
+	}
+
+	return locked
+}
+
+// displayedGraph returns dependencies of displayed achievements and extra ones, like not displayed exclusive
+// candidates, and displayed achievements by id
+func displayedGraph(displayed, extra []*UserAchievement) (map[string][]string, map[string]*UserAchievement) {
+	items := make([]graphItem, 0, len(displayed)+len(extra))
+	byID := make(map[string]*UserAchievement, len(displayed))
+	for _, ua := range displayed {
+		items = append(items, ua.graphItem())
+		byID[ua.AchievementID] = ua
+	}
+
+	for _, ua := range extra {
+		if _, ok := byID[ua.AchievementID]; !ok {
+			items = append(items, ua.graphItem())
+		}
+	}
+
+	return dependencies(items), byID
+}
+
+// dependentTypes returns types of not achieved displayed achievements which require one of achieved ids
+func dependentTypes(displayed []*UserAchievement, achieved map[string]bool) []AchievementType {
+	deps, _ := displayedGraph(displayed, nil)
+
+	var types []AchievementType
+	for _, ua := range displayed {
+		if ua.Achieved() || slices.Contains(types, ua.Type) {
+			continue
+		}
+
+		if slices.ContainsFunc(deps[ua.AchievementID], func(id string) bool { return achieved[id] }) {
+			types = append(types, ua.Type)
+		}
+	}
+
+	return types
+}
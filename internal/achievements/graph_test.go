@@ -0,0 +1,151 @@
+package achievements
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_validateGraph(t *testing.T) {
+	catalog := []Achievement{
+		{ID: "vote-3", Series: "votes", Tier: 1},
+		{ID: "vote-10", Series: "votes", Tier: 2},
+		{ID: "follow-5"},
+		{ID: "veteran", Prerequisites: []string{"vote-10", "follow-5"}},
+	}
+
+	for name, tc := range map[string]struct {
+		changed Achievement
+		valid   bool
+	}{
+		"new with several prerequisites": {
+			changed: Achievement{ID: "legend", Prerequisites: []string{"veteran", "vote-3"}},
+			valid:   true,
+		},
+		"next tier": {
+			changed: Achievement{ID: "vote-25", Series: "votes", Tier: 3},
+			valid:   true,
+		},
+		"unknown prerequisite": {
+			changed: Achievement{ID: "legend", Prerequisites: []string{"unknown"}},
+		},
+		"taken tier": {
+			changed: Achievement{ID: "vote-5", Series: "votes", Tier: 2},
+		},
+		"direct cycle": {
+			changed: Achievement{ID: "follow-5", Prerequisites: []string{"veteran"}},
+		},
+		"cycle through series": {
+			changed: Achievement{ID: "vote-3", Series: "votes", Tier: 1, Prerequisites: []string{"veteran"}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := validateGraph(catalog, &tc.changed)
+			if tc.valid {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, ErrInvalidAchievement)
+		})
+	}
+}
+
+func Test_dependents(t *testing.T) {
+	catalog := []Achievement{
+		{ID: "vote-3", Series: "votes", Tier: 1},
+		{ID: "vote-10", Series: "votes", Tier: 2},
+		{ID: "vote-25", Series: "votes", Tier: 3},
+		{ID: "follow-5"},
+		{ID: "veteran", Prerequisites: []string{"vote-10", "follow-5"}},
+		{ID: "legend", Prerequisites: []string{"follow-5"}},
+	}
+
+	for name, tc := range map[string]struct {
+		id       string
+		expected []string
+	}{
+		"prerequisite and previous tier": {
+			id:       "vote-10",
+			expected: []string{"veteran", "vote-25"},
+		},
+		"prerequisite of several achievements": {
+			id:       "follow-5",
+			expected: []string{"legend", "veteran"},
+		},
+		"last tier": {
+			id: "vote-25",
+		},
+		"not required": {
+			id: "legend",
+		},
+		"unknown achievement": {
+			id: "unknown",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expected, dependents(catalog, tc.id))
+		})
+	}
+}
+
+func Test_resolveLocks(t *testing.T) {
+	now := time.Now()
+	list := []*UserAchievement{
+		{AchievementID: "vote-3", Series: "votes", Tier: 1, AchievedAt: &now},
+		{AchievementID: "vote-10", Series: "votes", Tier: 2},
+		{AchievementID: "follow-5", AchievedAt: &now},
+		{AchievementID: "veteran", Prerequisites: []string{"vote-10", "follow-5"}},
+		{AchievementID: "hidden", Prerequisites: []string{"archived"}},
+	}
+
+	resolveLocks(list)
+
+	require.False(t, list[0].Locked)
+	require.False(t, list[1].Locked)
+	require.Equal(t, []Requirement{{AchievementID: "vote-3", Achieved: true}}, list[1].Requirements)
+	require.True(t, list[3].Locked)
+	require.Equal(t, []Requirement{
+		{AchievementID: "vote-10", Achieved: false},
+		{AchievementID: "follow-5", Achieved: true},
+	}, list[3].Requirements)
+	require.True(t, list[4].Locked)
+	require.Empty(t, list[4].Requirements)
+}
+
+func Test_lockedAchievements(t *testing.T) {
+	now := time.Now()
+	displayed := []*UserAchievement{
+		{AchievementID: "vote-3", Series: "votes", Tier: 1, AchievedAt: &now},
+		{AchievementID: "vote-10", Series: "votes", Tier: 2},
+		{AchievementID: "vote-50", Series: "votes", Tier: 3},
+		{AchievementID: "follow-5", AchievedAt: &now},
+	}
+	candidates := []*UserAchievement{
+		displayed[1],
+		displayed[2],
+		{AchievementID: "exclusive", Prerequisites: []string{"follow-5"}},
+		{AchievementID: "by-exclusive", Prerequisites: []string{"exclusive"}},
+		{AchievementID: "by-archived", Prerequisites: []string{"archived"}},
+	}
+
+	require.Equal(t, map[string]bool{
+		"vote-50":      true,
+		"by-exclusive": true,
+		"by-archived":  true,
+	}, lockedAchievements(displayed, candidates))
+}
+
+func Test_dependentTypes(t *testing.T) {
+	now := time.Now()
+	displayed := []*UserAchievement{
+		{AchievementID: "vote-3", Type: AchievementTypeVote, Series: "votes", Tier: 1, AchievedAt: &now},
+		{AchievementID: "vote-10", Type: AchievementTypeVote, Series: "votes", Tier: 2},
+		{AchievementID: "veteran", Type: AchievementTypeActivityStreak, Prerequisites: []string{"vote-3"}},
+		{AchievementID: "follower", Type: AchievementTypeSubscriptions, Prerequisites: []string{"vote-10"}},
+	}
+
+	require.Equal(t, []AchievementType{AchievementTypeVote, AchievementTypeActivityStreak}, dependentTypes(displayed, map[string]bool{"vote-3": true}))
+}
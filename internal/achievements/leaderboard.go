@@ -0,0 +1,112 @@
+package achievements
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultLeaderboardLimit = 50
+	maxLeaderboardLimit     = 100
+)
+
+var ErrInvalidLeaderboardFilter = errors.New("invalid leaderboard filter")
+
+type LeaderboardRank string
+
+const (
+	// LeaderboardRankPoints ranks users by the sum of points of unlocked achievements
+	LeaderboardRankPoints LeaderboardRank = "points"
+	// LeaderboardRankUnlocked ranks users by the number of unlocked achievements
+	LeaderboardRankUnlocked LeaderboardRank = "unlocked"
+)
+
+// LeaderboardFilter describes the leaderboard request. The period is applied to the unlock date,
+// DaoID limits the leaderboard to followers of the dao.
+type LeaderboardFilter struct {
+	Rank   LeaderboardRank
+	From   *time.Time
+	To     *time.Time
+	DaoID  *uuid.UUID
+	Limit  int
+	Offset int
+}
+
+// LeaderboardEntry describes the user position, users with the same score share the position
+type LeaderboardEntry struct {
+	Position int
+	UserID   uuid.UUID
+	Address  *string
+	ENS      *string
+	Unlocked int
+	Points   int
+}
+
+// Badge is the unlocked achievement displayed in the public user profile
+type Badge struct {
+	AchievementID string
+	Title         string
+	Subtitle      string
+	Images        []Image
+	AchievedAt    time.Time
+}
+
+func (f *LeaderboardFilter) validate() error {
+	switch f.Rank {
+	case "":
+		f.Rank = LeaderboardRankPoints
+	case LeaderboardRankPoints, LeaderboardRankUnlocked:
+	default:
+		return fmt.Errorf("%w: unknown rank: %s", ErrInvalidLeaderboardFilter, f.Rank)
+	}
+
+	if f.From != nil && f.To != nil && !f.To.After(*f.From) {
+		return fmt.Errorf("%w: end of the period must be after the start", ErrInvalidLeaderboardFilter)
+	}
+
+	if f.Limit < 0 || f.Limit > maxLeaderboardLimit {
+		return fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidLeaderboardFilter, maxLeaderboardLimit)
+	}
+
+	if f.Limit == 0 {
+		f.Limit = defaultLeaderboardLimit
+	}
+
+	if f.Offset < 0 {
+		return fmt.Errorf("%w: negative offset", ErrInvalidLeaderboardFilter)
+	}
+
+	return nil
+}
+
+// GetLeaderboard returns regular users ranked by unlocked achievements, users who opted out in settings are skipped
+func (s *Service) GetLeaderboard(filter LeaderboardFilter) ([]LeaderboardEntry, error) {
+	if err := filter.validate(); err != nil {
+		return nil, err
+	}
+
+	list, err := s.repo.GetLeaderboard(filter)
+	if err != nil {
+		return nil, fmt.Errorf("get leaderboard: %w", err)
+	}
+
+	return list, nil
+}
+
+// GetPublicBadges returns unlocked non-exclusive achievements of the user found by the address or ens name
+func (s *Service) GetPublicBadges(addressOrENS string) ([]Badge, error) {
+	userInfo, err := s.up.GetRegularByAddressOrENS(addressOrENS)
+	if err != nil {
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+
+	list, err := s.repo.GetBadgesByUserID(userInfo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get badges: %w", err)
+	}
+
+	return list, nil
+}
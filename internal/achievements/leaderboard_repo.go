@@ -0,0 +1,169 @@
+package achievements
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/goverland-labs/goverland-inbox-storage/internal/settings"
+	"github.com/goverland-labs/goverland-inbox-storage/internal/user"
+)
+
+// leaderboardScores contains score expressions by rank, the request value is never added to the query
+var leaderboardScores = map[LeaderboardRank]string{
+	LeaderboardRankPoints:   "coalesce(sum(a.points), 0)",
+	LeaderboardRankUnlocked: "count(*)",
+}
+
+func (r *Repo) GetLeaderboard(filter LeaderboardFilter) ([]LeaderboardEntry, error) {
+	score, ok := leaderboardScores[filter.Rank]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown rank: %s", ErrInvalidLeaderboardFilter, filter.Rank)
+	}
+
+	conditions := []string{
+		"ua.achieved_at is not null",
+		"a.deleted_at is null",
+		"not a.exclusive",
+		"u.role = @role",
+		"u.deleted_at is null",
+		`not exists (
+        select 1 from user_settings s
+        where s.user_id = u.id
+          and s.type = @settings_type
+          and s.deleted_at is null
+          and coalesce((s.value->>@opt_out_flag)::boolean, false)
+    )`,
+	}
+	args := []any{
+		sql.Named("role", user.RegularRole),
+		sql.Named("settings_type", settings.DetailsTypeAchievementsConfig),
+		sql.Named("opt_out_flag", settings.AchievementsFlagHideFromLeaderboard),
+		sql.Named("limit", filter.Limit),
+		sql.Named("offset", filter.Offset),
+	}
+
+	if filter.From != nil {
+		conditions = append(conditions, "ua.achieved_at >= @from")
+		args = append(args, sql.Named("from", *filter.From))
+	}
+
+	if filter.To != nil {
+		conditions = append(conditions, "ua.achieved_at < @to")
+		args = append(args, sql.Named("to", *filter.To))
+	}
+
+	if filter.DaoID != nil {
+		conditions = append(conditions, `exists (
+        select 1 from user_subscriptions us
+        where us.user_id = u.id
+          and us.dao_id = @dao_id
+          and us.deleted_at is null
+    )`)
+		args = append(args, sql.Named("dao_id", *filter.DaoID))
+	}
+
+	query := fmt.Sprintf(`
+select
+    position,
+    user_id,
+    address,
+    ens,
+    unlocked,
+    points
+from (
+    select
+        u.id user_id,
+        u.address,
+        u.ens,
+        count(*) unlocked,
+        coalesce(sum(a.points), 0) points,
+        rank() over (order by %s desc) position
+    from user_achievements ua
+    inner join achievements a on a.id = ua.achievement_id
+    inner join users u on u.id = ua.user_id
+    where %s
+    group by u.id, u.address, u.ens
+) ranked
+order by position, user_id
+limit @limit offset @offset`, score, strings.Join(conditions, "\n    and "))
+
+	rows, err := r.db.Raw(query, args...).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]LeaderboardEntry, 0, filter.Limit)
+	for rows.Next() {
+		var entry LeaderboardEntry
+		err = rows.Scan(
+			&entry.Position,
+			&entry.UserID,
+			&entry.Address,
+			&entry.ENS,
+			&entry.Unlocked,
+			&entry.Points,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, entry)
+	}
+
+	return list, rows.Err()
+}
+
+// GetBadgesByUserID returns unlocked achievements which are not exclusive ordered as in the catalog
+func (r *Repo) GetBadgesByUserID(userID uuid.UUID) ([]Badge, error) {
+	query := `
+select
+    ua.achievement_id,
+    a.title,
+    a.subtitle,
+    a.images,
+    ua.achieved_at
+from user_achievements ua
+inner join achievements a on a.id = ua.achievement_id
+where ua.user_id = ?
+  and ua.achieved_at is not null
+  and not a.exclusive
+order by a.sort_order`
+
+	rows, err := r.db.Raw(query, userID).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []Badge
+	for rows.Next() {
+		var (
+			badge  Badge
+			images string
+		)
+
+		err = rows.Scan(
+			&badge.AchievementID,
+			&badge.Title,
+			&badge.Subtitle,
+			&images,
+			&badge.AchievedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = json.Unmarshal([]byte(images), &badge.Images); err != nil {
+			return nil, fmt.Errorf("unmarshal images: %s: %w", badge.AchievementID, err)
+		}
+
+		list = append(list, badge)
+	}
+
+	return list, rows.Err()
+}
@@ -0,0 +1,52 @@
+package achievements
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeaderboardFilter_validate(t *testing.T) {
+	now := time.Now()
+	weekAgo := now.AddDate(0, 0, -7)
+
+	for name, tc := range map[string]struct {
+		filter LeaderboardFilter
+		valid  bool
+	}{
+		"defaults": {
+			filter: LeaderboardFilter{},
+			valid:  true,
+		},
+		"unlocked for the period": {
+			filter: LeaderboardFilter{Rank: LeaderboardRankUnlocked, From: &weekAgo, To: &now, Limit: 10},
+			valid:  true,
+		},
+		"unknown rank": {
+			filter: LeaderboardFilter{Rank: "likes"},
+		},
+		"wrong period": {
+			filter: LeaderboardFilter{From: &now, To: &weekAgo},
+		},
+		"too big limit": {
+			filter: LeaderboardFilter{Limit: maxLeaderboardLimit + 1},
+		},
+		"negative offset": {
+			filter: LeaderboardFilter{Offset: -1},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := tc.filter.validate()
+			if tc.valid {
+				require.NoError(t, err)
+				require.NotEmpty(t, tc.filter.Rank)
+				require.NotZero(t, tc.filter.Limit)
+
+				return
+			}
+
+			require.ErrorIs(t, err, ErrInvalidLeaderboardFilter)
+		})
+	}
+}
@@ -7,11 +7,13 @@ import (
 
 	"github.com/google/uuid"
 	pevents "github.com/goverland-labs/goverland-platform-events/events/inbox"
+
+	"github.com/goverland-labs/goverland-inbox-storage/internal/achievements/recalc"
 )
 
 type Image struct {
-	Size string
-	Path string
+	Size string `json:"size"`
+	Path string `json:"path"`
 }
 
 type UserAchievement struct {
@@ -29,9 +31,19 @@ type UserAchievement struct {
 	Exclusive          bool
 	Type               AchievementType
 	Params             json.RawMessage
-	BLockedBy          string
+	Prerequisites      []string `gorm:"-"`
+	Series             string   `gorm:"-"`
+	Tier               int      `gorm:"-"`
 	Goal               int
 	Progress           int
+	// PreviousProgress is the stored progress before the calculation
+	PreviousProgress int          `gorm:"-"`
+	StartsAt         *time.Time   `gorm:"-"`
+	EndsAt           *time.Time   `gorm:"-"`
+	ExpiryPolicy     ExpiryPolicy `gorm:"-"`
+	// Locked achievements have not achieved prerequisites, they are not displayed and their progress is not calculated
+	Locked       bool          `gorm:"-"`
+	Requirements []Requirement `gorm:"-"`
 }
 
 func (ua *UserAchievement) TableName() string {
@@ -39,14 +51,49 @@ func (ua *UserAchievement) TableName() string {
 	return "user_achievements"
 }
 
+func (ua *UserAchievement) Achieved() bool {
+	return ua.AchievedAt != nil && !ua.AchievedAt.IsZero()
+}
+
+// Window returns the period when user actions are counted for the achievement
+func (ua *UserAchievement) Window() Window {
+	return Window{
+		From: ua.StartsAt,
+		To:   ua.EndsAt,
+	}
+}
+
+func (ua *UserAchievement) Availability(now time.Time) Availability {
+	return ua.Window().State(now)
+}
+
+// Countdown returns the time left till the start or the end of the achievement window
+func (ua *UserAchievement) Countdown(now time.Time) time.Duration {
+	return ua.Window().Countdown(now)
+}
+
+func (ua *UserAchievement) graphItem() graphItem {
+	return graphItem{
+		ID:            ua.AchievementID,
+		Prerequisites: ua.Prerequisites,
+		Series:        ua.Series,
+		Tier:          ua.Tier,
+	}
+}
+
 type UserAchievements []UserAchievement
 
 type AchievementType string
 
 const (
-	AchievementTypeUnspecified AchievementType = "unspecified"
-	AchievementTypeAppInfo     AchievementType = "app_info"
-	AchievementTypeVote        AchievementType = "vote"
+	AchievementTypeUnspecified    AchievementType = "unspecified"
+	AchievementTypeAppInfo        AchievementType = "app_info"
+	AchievementTypeVote           AchievementType = "vote"
+	AchievementTypeSubscriptions  AchievementType = AchievementType(recalc.TypeSubscriptions)
+	AchievementTypeActivityStreak AchievementType = AchievementType(recalc.TypeActivityStreak)
+	AchievementTypeAISummary      AchievementType = AchievementType(recalc.TypeAISummary)
+	AchievementTypeDelegation     AchievementType = AchievementType(recalc.TypeDelegation)
+	AchievementTypePushEnabled    AchievementType = AchievementType(recalc.TypePushEnabled)
 )
 
 func convertAchievementType(atype pevents.AchievementType) (AchievementType, error) {
@@ -55,6 +102,12 @@ func convertAchievementType(atype pevents.AchievementType) (AchievementType, err
 		return AchievementTypeAppInfo, nil
 	case pevents.AchievementTypeVote:
 		return AchievementTypeVote, nil
+	case recalc.TypeSubscriptions,
+		recalc.TypeActivityStreak,
+		recalc.TypeAISummary,
+		recalc.TypeDelegation,
+		recalc.TypePushEnabled:
+		return AchievementType(atype), nil
 	default:
 		return AchievementTypeUnspecified, fmt.Errorf("unknown achievement type: %v", atype)
 	}
@@ -0,0 +1,83 @@
+package achievements
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultProgressHistoryLimit = 50
+	maxProgressHistoryLimit     = 100
+)
+
+// ProgressEvent records the change of the user achievement progress
+type ProgressEvent struct {
+	ID            uint64 `gorm:"primaryKey"`
+	CreatedAt     time.Time
+	UserID        uuid.UUID
+	AchievementID string
+	OldProgress   int
+	NewProgress   int
+	Goal          int
+	Achieved      bool
+	// Trigger describes the event which caused the change
+	Trigger string
+}
+
+func (ProgressEvent) TableName() string {
+	return "achievement_progress_events"
+}
+
+func newProgressEvent(ua *UserAchievement, trigger string, now time.Time) ProgressEvent {
+	return ProgressEvent{
+		CreatedAt:     now,
+		UserID:        ua.UserID,
+		AchievementID: ua.AchievementID,
+		OldProgress:   ua.PreviousProgress,
+		NewProgress:   ua.Progress,
+		Goal:          ua.Goal,
+		Achieved:      ua.Achieved(),
+		Trigger:       trigger,
+	}
+}
+
+// recalcTrigger describes the recalculation event by the achievement type
+func recalcTrigger(atype AchievementType) string {
+	return fmt.Sprintf("recalculate:%s", atype)
+}
+
+// manualTrigger describes the grant or revoke by the admin
+func manualTrigger(action ManualAction) string {
+	return fmt.Sprintf("manual:%s", action)
+}
+
+// GetAchievementProgressHistory returns progress changes of the user achievement from the newest to the oldest
+func (s *Service) GetAchievementProgressHistory(userID uuid.UUID, achievementID string, limit int) ([]ProgressEvent, error) {
+	if limit <= 0 || limit > maxProgressHistoryLimit {
+		limit = defaultProgressHistoryLimit
+	}
+
+	list, err := s.repo.GetProgressHistory(userID, achievementID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("get progress history: %w", err)
+	}
+
+	return list, nil
+}
+
+func (r *Repo) GetProgressHistory(userID uuid.UUID, achievementID string, limit int) ([]ProgressEvent, error) {
+	var list []ProgressEvent
+	err := r.db.
+		Where("user_id = ? and achievement_id = ?", userID, achievementID).
+		Order("created_at desc, id desc").
+		Limit(limit).
+		Find(&list).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
@@ -0,0 +1,49 @@
+package achievements
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newProgressEvent(t *testing.T) {
+	now := time.Now()
+	ua := &UserAchievement{
+		UserID:           uuid.New(),
+		AchievementID:    "five-votes",
+		Goal:             5,
+		PreviousProgress: 2,
+		Progress:         3,
+	}
+
+	event := newProgressEvent(ua, recalcTrigger(AchievementTypeVote), now)
+	require.Equal(t, 2, event.OldProgress)
+	require.Equal(t, 3, event.NewProgress)
+	require.Equal(t, 5, event.Goal)
+	require.False(t, event.Achieved)
+	require.Equal(t, "recalculate:vote", event.Trigger)
+	require.Equal(t, now, event.CreatedAt)
+}
+
+func Test_newManualProgressEvents(t *testing.T) {
+	now := time.Now()
+	changed := []manualProgress{{UserID: uuid.New(), OldProgress: 2, NewProgress: 5, Goal: 5}}
+
+	granted := newManualProgressEvents("five-votes", ManualActionGrant, changed, now)
+	require.Len(t, granted, 1)
+	require.Equal(t, changed[0].UserID, granted[0].UserID)
+	require.Equal(t, "five-votes", granted[0].AchievementID)
+	require.Equal(t, 2, granted[0].OldProgress)
+	require.Equal(t, 5, granted[0].NewProgress)
+	require.True(t, granted[0].Achieved)
+	require.Equal(t, "manual:grant", granted[0].Trigger)
+	require.Equal(t, now, granted[0].CreatedAt)
+
+	revoked := newManualProgressEvents("five-votes", ManualActionRevoke, []manualProgress{{UserID: uuid.New(), OldProgress: 5, Goal: 5}}, now)
+	require.Len(t, revoked, 1)
+	require.Equal(t, 0, revoked[0].NewProgress)
+	require.False(t, revoked[0].Achieved)
+	require.Equal(t, "manual:revoke", revoked[0].Trigger)
+}
@@ -0,0 +1,34 @@
+// Package recalc describes achievement types calculated by the storage itself and requests their recalculation.
+// It's separated from the achievements package to be used by services which provide data for handlers.
+package recalc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	pevents "github.com/goverland-labs/goverland-platform-events/events/inbox"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	TypeSubscriptions  pevents.AchievementType = "subscriptions"
+	TypeActivityStreak pevents.AchievementType = "activity_streak"
+	TypeAISummary      pevents.AchievementType = "ai_summary"
+	TypeDelegation     pevents.AchievementType = "delegation"
+	TypePushEnabled    pevents.AchievementType = "push_enabled"
+)
+
+type Publisher interface {
+	PublishJSON(ctx context.Context, subject string, obj any) error
+}
+
+// Request publishes the recalculation event, errors are only logged to not break the main flow
+func Request(ctx context.Context, pb Publisher, userID uuid.UUID, atype pevents.AchievementType) {
+	err := pb.PublishJSON(ctx, pevents.SubjectRecalculateAchievement, pevents.AchievementRecalculateEvent{
+		UserID: userID,
+		Type:   atype,
+	})
+	if err != nil {
+		log.Error().Err(err).Msgf("publish recalculate achievement event: %s: %s", atype, userID)
+	}
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-inbox-storage/internal/settings"
 )
 
 type Repo struct {
@@ -22,13 +24,15 @@ func (r *Repo) InitByUser(userID uuid.UUID) error {
 	query := `
 insert into user_achievements (user_id, achievement_id)
 select ? user_id, id from achievements
+where deleted_at is null
 on conflict (user_id, achievement_id) DO NOTHING;`
 
 	return r.db.Exec(query, userID).Error
 }
 
-// GetActiveByUserID returns all active achievements for user including exclusive
-func (r *Repo) GetActiveByUserID(userID uuid.UUID) ([]*UserAchievement, error) {
+// GetActiveByUserIDAndType returns not achieved and not revoked user achievements by type including exclusive and
+// locked ones
+func (r *Repo) GetActiveByUserIDAndType(userID uuid.UUID, atype AchievementType) ([]*UserAchievement, error) {
 	query := `
 select
     ua.user_id,
@@ -37,14 +41,24 @@ select
     a.params,
     a.type,
     coalesce(a.params->'goals', '1') goal,
-    ua.progress
+    ua.progress,
+    a.starts_at,
+    a.ends_at,
+    a.prerequisites,
+    a.series,
+    a.tier
 from user_achievements ua
 inner join achievements a on a.id = ua.achievement_id
 where user_id = ?
+    and a.type = ?
     and ua.achieved_at is null
+    and ua.revoked_at is null
+    and a.deleted_at is null
+    and (a.starts_at is null or a.starts_at <= now())
+    and (a.ends_at is null or a.ends_at > now())
 order by created_at`
 
-	rows, err := r.db.Raw(query, userID.String()).Rows()
+	rows, err := r.db.Raw(query, userID.String(), atype).Rows()
 	if err != nil {
 		return nil, fmt.Errorf("get active by user: %w", err)
 	}
@@ -55,6 +69,8 @@ order by created_at`
 	for rows.Next() {
 		ua := &UserAchievement{}
 
+		var prerequisites string
+
 		err = rows.Scan(
 			&ua.UserID,
 			&ua.AchievementID,
@@ -63,28 +79,71 @@ order by created_at`
 			&ua.Type,
 			&ua.Goal,
 			&ua.Progress,
+			&ua.StartsAt,
+			&ua.EndsAt,
+			&prerequisites,
+			&ua.Series,
+			&ua.Tier,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("convert row: %w", err)
 		}
 
+		if err = json.Unmarshal([]byte(prerequisites), &ua.Prerequisites); err != nil {
+			return nil, fmt.Errorf("unmarshal prerequisites: %w", err)
+		}
+
 		list = append(list, ua)
 	}
 
 	return list, nil
 }
 
-func (r *Repo) SaveAchievement(ua *UserAchievement) error {
-	return r.db.
-		Model(ua).
-		Where("user_id = ? and achievement_id = ?", ua.UserID, ua.AchievementID).
-		UpdateColumns(UserAchievement{
-			UpdatedAt:  time.Now(),
-			AchievedAt: ua.AchievedAt,
-			ViewedAt:   ua.ViewedAt,
-			Progress:   ua.Progress,
-		}).
-		Error
+// SaveAchievements stores the progress, its history and events about unlocked achievements in one transaction.
+// Already achieved rows are not changed, so the concurrent or repeated calculation doesn't move the achieving date
+// and doesn't duplicate the unlock event.
+func (r *Repo) SaveAchievements(list []*UserAchievement, trigger string, messages func(list []UserAchievement) ([]*settings.OutboxMessage, error)) error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		events := make([]ProgressEvent, 0, len(list))
+		var unlocked []UserAchievement
+		for _, ua := range list {
+			res := tx.
+				Model(&UserAchievement{}).
+				Where("user_id = ? and achievement_id = ? and achieved_at is null", ua.UserID, ua.AchievementID).
+				UpdateColumns(map[string]any{
+					"updated_at":  now,
+					"achieved_at": ua.AchievedAt,
+					"progress":    ua.Progress,
+				})
+			if res.Error != nil {
+				return fmt.Errorf("save %s: %w", ua.AchievementID, res.Error)
+			}
+
+			if res.RowsAffected == 0 {
+				continue
+			}
+
+			events = append(events, newProgressEvent(ua, trigger, now))
+			if ua.Achieved() {
+				unlocked = append(unlocked, *ua)
+			}
+		}
+
+		if len(events) == 0 {
+			return nil
+		}
+
+		if err := tx.Create(&events).Error; err != nil {
+			return fmt.Errorf("create progress events: %w", err)
+		}
+
+		return addUnlocks(tx, unlocked, messages)
+	})
 }
 
 // GetActualByUserID returns the result available for display for the user
@@ -98,18 +157,26 @@ select
     a.description,
     a.achievement_message,
     a.images,
+    a.type,
     coalesce(a.params->'goals', '1') goal,
     ua.progress,
     ua.achieved_at,
     ua.viewed_at,
     a.exclusive,
-    coalesce(a.blocked_by, '') blocked_by
+    a.prerequisites,
+    a.series,
+    a.tier,
+    a.starts_at,
+    a.ends_at,
+    a.expiry_policy
 from user_achievements ua
 inner join achievements a on a.id = ua.achievement_id
 where user_id = ?
   and (
     not a.exclusive or (a.exclusive and ua.achieved_at is not null)
     )
+  and (a.deleted_at is null or ua.achieved_at is not null)
+  and (a.expiry_policy <> 'hide' or a.ends_at is null or a.ends_at > now() or ua.achieved_at is not null)
 order by a.sort_order`
 
 	rows, err := r.db.Raw(query, userID.String()).Rows()
@@ -123,7 +190,7 @@ order by a.sort_order`
 	for rows.Next() {
 		ua := &UserAchievement{}
 
-		var images string
+		var images, prerequisites string
 
 		err = rows.Scan(
 			&ua.UserID,
@@ -133,12 +200,18 @@ order by a.sort_order`
 			&ua.Description,
 			&ua.AchievementMessage,
 			&images,
+			&ua.Type,
 			&ua.Goal,
 			&ua.Progress,
 			&ua.AchievedAt,
 			&ua.ViewedAt,
 			&ua.Exclusive,
-			&ua.BLockedBy,
+			&prerequisites,
+			&ua.Series,
+			&ua.Tier,
+			&ua.StartsAt,
+			&ua.EndsAt,
+			&ua.ExpiryPolicy,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("convert row: %w", err)
@@ -148,6 +221,10 @@ order by a.sort_order`
 			return nil, fmt.Errorf("unmarshal images: %w", err)
 		}
 
+		if err = json.Unmarshal([]byte(prerequisites), &ua.Prerequisites); err != nil {
+			return nil, fmt.Errorf("unmarshal prerequisites: %w", err)
+		}
+
 		list = append(list, ua)
 	}
 
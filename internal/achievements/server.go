@@ -2,41 +2,65 @@ package achievements
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	proto "github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/goverland-labs/goverland-inbox-storage/pkg/grpcsrv"
+)
+
+var errorMapper = grpcsrv.NewErrorMapper(
+	grpcsrv.ErrorRule{Err: ErrInvalidAchievement, Code: codes.InvalidArgument, Reason: "INVALID_ACHIEVEMENT"},
+	grpcsrv.ErrorRule{Err: ErrInvalidOrder, Code: codes.InvalidArgument, Reason: "INVALID_ORDER"},
+	grpcsrv.ErrorRule{Err: ErrAchievementExists, Code: codes.AlreadyExists, Reason: "ACHIEVEMENT_EXISTS"},
+	grpcsrv.ErrorRule{Err: ErrAchievementHasDependents, Code: codes.FailedPrecondition, Reason: "ACHIEVEMENT_HAS_DEPENDENTS"},
+	grpcsrv.ErrorRule{Err: ErrInvalidLeaderboardFilter, Code: codes.InvalidArgument, Reason: "INVALID_LEADERBOARD_FILTER"},
+	grpcsrv.ErrorRule{Err: ErrInvalidGrant, Code: codes.InvalidArgument, Reason: "INVALID_GRANT"},
+	grpcsrv.ErrorRule{Err: ErrInvalidTranslation, Code: codes.InvalidArgument, Reason: "INVALID_TRANSLATION"},
 )
 
+type ServiceProvider interface {
+	GetActualByUserID(userID uuid.UUID, locale string) ([]*UserAchievement, error)
+	MarkAsViewed(userID uuid.UUID, achievementID string) error
+}
+
 type Server struct {
 	proto.UnimplementedAchievementServer
 
-	sp *Service
+	sp ServiceProvider
 }
 
-func NewServer(sp *Service) *Server {
+func NewServer(sp ServiceProvider) *Server {
 	return &Server{sp: sp}
 }
 
-func (s *Server) GetUserAchievementList(_ context.Context, req *proto.GetUserAchievementListRequest) (*proto.AchievementList, error) {
-	userID, err := uuid.Parse(req.GetUserId())
+func (s *Server) GetUserAchievementList(ctx context.Context, req *proto.GetUserAchievementListRequest) (*proto.AchievementList, error) {
+	userID, err := grpcsrv.ParseUUID("user_id", req.GetUserId())
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+		return nil, err
 	}
 
-	list, err := s.sp.GetActualByUserID(userID)
+	list, err := s.sp.GetActualByUserID(userID, grpcsrv.LocaleFromContext(ctx))
 	if err != nil {
-		return nil, status.Error(codes.Internal, "fetch user's achievements")
+		return nil, errorMapper.Error(fmt.Errorf("fetch user's achievements: %w", err))
 	}
 
 	resp := &proto.AchievementList{
 		List: make([]*proto.AchievementInfo, 0, len(list)),
 	}
 
+	now := time.Now()
 	for _, achievement := range list {
+		// the app is not able to display locked achievements with requirements and upcoming ones yet
+		if achievement.Locked || achievement.Availability(now) == AvailabilityUpcoming {
+			continue
+		}
+
 		var achievedAt, viewedAt *timestamppb.Timestamp
 
 		if achievement.AchievedAt != nil {
@@ -76,13 +100,13 @@ func (s *Server) GetUserAchievementList(_ context.Context, req *proto.GetUserAch
 }
 
 func (s *Server) MarkAsViewed(_ context.Context, req *proto.MarkAsViewedRequest) (*emptypb.Empty, error) {
-	userID, err := uuid.Parse(req.GetUserId())
+	userID, err := grpcsrv.ParseUUID("user_id", req.GetUserId())
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+		return nil, err
 	}
 
 	if err = s.sp.MarkAsViewed(userID, req.GetAchievementId()); err != nil {
-		return nil, status.Error(codes.Internal, "failed to mark as viewed")
+		return nil, errorMapper.Error(fmt.Errorf("mark as viewed: %w", err))
 	}
 
 	return &emptypb.Empty{}, nil
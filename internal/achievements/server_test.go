@@ -0,0 +1,134 @@
+package achievements
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	proto "github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
+	"github.com/stretchr/testify/require"
+	"go.openly.dev/pointy"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+var (
+	errBackend = errors.New("backend is down")
+
+	testLeaderUserID    = uuid.New()
+	testBadgeAchievedAt = time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+)
+
+type fakeServerService struct {
+	list    []*UserAchievement
+	filter  LeaderboardFilter
+	history []ProgressEvent
+	limit   int
+	err     error
+}
+
+func (f *fakeServerService) GetActualByUserID(uuid.UUID, string) ([]*UserAchievement, error) {
+	return f.list, f.err
+}
+
+func (f *fakeServerService) GetLeaderboard(filter LeaderboardFilter) ([]LeaderboardEntry, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	if err := filter.validate(); err != nil {
+		return nil, err
+	}
+
+	f.filter = filter
+
+	return []LeaderboardEntry{
+		{Position: 1, UserID: testLeaderUserID, Address: pointy.String("0x1"), ENS: pointy.String("one.eth"), Unlocked: 3, Points: 30},
+		{Position: 2, UserID: uuid.New(), Address: pointy.String("0x2"), Unlocked: 2, Points: 20},
+	}, nil
+}
+
+func (f *fakeServerService) GetPublicBadges(addressOrENS string) ([]Badge, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	if addressOrENS != "one.eth" {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return []Badge{
+		{AchievementID: "first-vote", Title: "First vote", Images: []Image{{Size: "sm", Path: "/sm.png"}}, AchievedAt: testBadgeAchievedAt},
+	}, nil
+}
+
+func (f *fakeServerService) GetAchievementProgressHistory(_ uuid.UUID, _ string, limit int) ([]ProgressEvent, error) {
+	f.limit = limit
+
+	return f.history, f.err
+}
+
+func (f *fakeServerService) MarkAsViewed(uuid.UUID, string) error {
+	return f.err
+}
+
+func TestServer_GetUserAchievementList(t *testing.T) {
+	userID := uuid.NewString()
+	startsAt := time.Now().Add(time.Hour)
+	list := []*UserAchievement{
+		{AchievementID: "active", Goal: 2, Progress: 1},
+		{AchievementID: "locked", Locked: true},
+		{AchievementID: "upcoming", StartsAt: &startsAt},
+	}
+
+	for _, tc := range []struct {
+		name   string
+		userID string
+		err    error
+		code   codes.Code
+	}{
+		{name: "ok", userID: userID, code: codes.OK},
+		{name: "invalid user id", userID: "wrong", code: codes.InvalidArgument},
+		{name: "backend error", userID: userID, err: errBackend, code: codes.Internal},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := NewServer(&fakeServerService{list: list, err: tc.err}).GetUserAchievementList(
+				context.Background(),
+				&proto.GetUserAchievementListRequest{UserId: tc.userID},
+			)
+
+			require.Equal(t, tc.code, status.Code(err), err)
+			if tc.code == codes.OK {
+				require.Len(t, resp.GetList(), 1)
+				require.Equal(t, "active", resp.GetList()[0].GetId())
+			}
+		})
+	}
+}
+
+func TestServer_MarkAsViewed(t *testing.T) {
+	userID := uuid.NewString()
+
+	for _, tc := range []struct {
+		name   string
+		userID string
+		err    error
+		code   codes.Code
+	}{
+		{name: "ok", userID: userID, code: codes.OK},
+		{name: "invalid user id", userID: "wrong", code: codes.InvalidArgument},
+		{name: "backend error", userID: userID, err: errBackend, code: codes.Internal},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewServer(&fakeServerService{err: tc.err}).MarkAsViewed(context.Background(), &proto.MarkAsViewedRequest{
+				UserId:        tc.userID,
+				AchievementId: "achievement",
+			})
+
+			require.Equal(t, tc.code, status.Code(err), err)
+		})
+	}
+}
@@ -2,63 +2,164 @@ package achievements
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/google/uuid"
+	pevents "github.com/goverland-labs/goverland-platform-events/events/inbox"
+	"github.com/rs/zerolog/log"
 
+	"github.com/goverland-labs/goverland-inbox-storage/internal/achievements/recalc"
+	"github.com/goverland-labs/goverland-inbox-storage/internal/settings"
 	"github.com/goverland-labs/goverland-inbox-storage/internal/user"
 )
 
 type UserProvider interface {
 	GetByID(id uuid.UUID) (*user.User, error)
+	GetRegularByAddressOrENS(value string) (*user.User, error)
+	GetLastLocale(userID uuid.UUID) (string, error)
+}
+
+// Calculator updates the progress of the particular user achievement
+type Calculator func(*UserAchievement) error
+
+// skipCalculation keeps achievements unchanged when the user data is not available for the handler
+func skipCalculation(*UserAchievement) error {
+	return nil
 }
 
 type AchievementHandler interface {
-	Allow(AchievementType) bool
-	Process(*UserAchievement) error
+	Type() AchievementType
+	// Prepare fetches the user data once for all achievements of the handler type
+	Prepare(userID uuid.UUID) (Calculator, error)
+}
+
+type Publisher interface {
+	PublishJSON(ctx context.Context, subject string, obj any) error
+}
+
+// progressStore loads not achieved user achievements and saves the calculated progress
+type progressStore interface {
+	GetActiveByUserIDAndType(userID uuid.UUID, atype AchievementType) ([]*UserAchievement, error)
+	GetActualByUserID(userID uuid.UUID) ([]*UserAchievement, error)
+	SaveAchievements(list []*UserAchievement, trigger string, messages func(list []UserAchievement) ([]*settings.OutboxMessage, error)) error
+}
+
+// translationStore loads translations of achievements for the locales
+type translationStore interface {
+	GetTranslations(achievementIDs []string, locales []string) ([]Translation, error)
 }
 
 type Service struct {
-	up   UserProvider
-	repo *Repo
+	up           UserProvider
+	repo         *Repo
+	progress     progressStore
+	translations translationStore
+	publisher    Publisher
 
-	handlers []AchievementHandler
+	handlers map[AchievementType]AchievementHandler
 }
 
-func NewService(up UserProvider, repo *Repo, list []AchievementHandler) *Service {
-	return &Service{up, repo, list}
+func NewService(up UserProvider, repo *Repo, list []AchievementHandler, pb Publisher) *Service {
+	handlers := make(map[AchievementType]AchievementHandler, len(list))
+	for _, h := range list {
+		handlers[h.Type()] = h
+	}
+
+	return &Service{
+		up:           up,
+		repo:         repo,
+		progress:     repo,
+		translations: repo,
+		publisher:    pb,
+		handlers:     handlers,
+	}
 }
 
 func (s *Service) init(_ context.Context, userID uuid.UUID) error {
 	return s.repo.InitByUser(userID)
 }
 
-func (s *Service) recalc(_ context.Context, userID uuid.UUID, atype AchievementType) error {
-	list, err := s.repo.GetActiveByUserID(userID)
+// recalc updates not achieved and not locked user achievements by type. The progress is calculated from the actual
+// data and the achieved ones are not updated, so the same event could be processed several times. Failed
+// achievements don't block other ones, their errors are returned after saving, so the event is redelivered.
+// Achievements unlocked by achieved ones are recalculated by their types.
+func (s *Service) recalc(ctx context.Context, userID uuid.UUID, atype AchievementType) error {
+	h, ok := s.handlers[atype]
+	if !ok {
+		log.Warn().Msgf("no handler for achievement type: %s", atype)
+
+		return nil
+	}
+
+	list, err := s.progress.GetActiveByUserIDAndType(userID, atype)
 	if err != nil {
 		return fmt.Errorf("get achievements: %w", err)
 	}
 
+	if len(list) == 0 {
+		return nil
+	}
+
+	displayed, err := s.progress.GetActualByUserID(userID)
+	if err != nil {
+		return fmt.Errorf("get actual achievements: %w", err)
+	}
+
+	locked := lockedAchievements(displayed, list)
+	list = slices.DeleteFunc(list, func(ua *UserAchievement) bool {
+		return locked[ua.AchievementID]
+	})
+
+	if len(list) == 0 {
+		return nil
+	}
+
+	calculate, err := h.Prepare(userID)
+	if err != nil {
+		return fmt.Errorf("prepare %s: %w", atype, err)
+	}
+
+	var errs []error
+	achieved := make(map[string]bool)
+	changed := make([]*UserAchievement, 0, len(list))
 	for _, info := range list {
-		for _, h := range s.handlers {
-			if !h.Allow(atype) {
-				continue
-			}
-
-			if errH := h.Process(info); errH != nil {
-				return fmt.Errorf("process achievement: %w", errH)
-			}
-
-			if errS := s.repo.SaveAchievement(info); errS != nil {
-				return fmt.Errorf("save achievement: %w", errS)
-			}
+		info.PreviousProgress = info.Progress
+		if err = calculate(info); err != nil {
+			// do not block other achievements by the broken one
+			errs = append(errs, fmt.Errorf("calculate %s: %w", info.AchievementID, err))
+
+			continue
+		}
+
+		if info.Progress == info.PreviousProgress && info.AchievedAt == nil {
+			continue
+		}
+
+		if info.Achieved() {
+			achieved[info.AchievementID] = true
 		}
+
+		changed = append(changed, info)
 	}
 
-	return nil
+	if err = s.progress.SaveAchievements(changed, recalcTrigger(atype), s.unlockMessages); err != nil {
+		return errors.Join(append(errs, fmt.Errorf("save achievements: %w", err))...)
+	}
+
+	if len(achieved) > 0 {
+		for _, dt := range dependentTypes(displayed, achieved) {
+			recalc.Request(ctx, s.publisher, userID, pevents.AchievementType(dt))
+		}
+	}
+
+	return errors.Join(errs...)
 }
 
-func (s *Service) GetActualByUserID(userID uuid.UUID) ([]*UserAchievement, error) {
+// GetActualByUserID returns achievements available for display including locked ones with their requirements.
+// Texts are localised by the requested locale or the locale of the latest session, the catalog content is the fallback.
+func (s *Service) GetActualByUserID(userID uuid.UUID, locale string) ([]*UserAchievement, error) {
 	userInfo, err := s.up.GetByID(userID)
 	if err != nil {
 		return nil, fmt.Errorf("get user: %w", err)
@@ -74,25 +175,14 @@ func (s *Service) GetActualByUserID(userID uuid.UUID) ([]*UserAchievement, error
 		return nil, fmt.Errorf("get actual achievements: %w", err)
 	}
 
-	filtered := make([]*UserAchievement, 0, len(actual))
-	for _, a := range actual {
-		if a.BLockedBy == "" {
-			filtered = append(filtered, a)
-			continue
-		}
-
-		for _, as := range actual {
-			if as.AchievementID != a.BLockedBy {
-				continue
-			}
-
-			if as.AchievedAt != nil && !as.AchievedAt.IsZero() {
-				filtered = append(filtered, a)
-			}
-		}
+	// localised titles are used in requirements
+	if err = s.localize(actual, s.resolveLocale(userID, locale)); err != nil {
+		return nil, err
 	}
 
-	return filtered, nil
+	resolveLocks(actual)
+
+	return actual, nil
 }
 
 func (s *Service) MarkAsViewed(userID uuid.UUID, achievementID string) error {
@@ -0,0 +1,239 @@
+package achievements
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	pevents "github.com/goverland-labs/goverland-platform-events/events/inbox"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-inbox-storage/internal/settings"
+)
+
+// fakeProgressStore follows the SaveAchievements contract: achieved rows are not changed and get no history
+// events and unlock messages
+type fakeProgressStore struct {
+	rows    map[string]*UserAchievement
+	events  []ProgressEvent
+	unlocks []*settings.OutboxMessage
+	saveErr error
+}
+
+func (f *fakeProgressStore) GetActiveByUserIDAndType(uuid.UUID, AchievementType) ([]*UserAchievement, error) {
+	var list []*UserAchievement
+	for _, row := range f.rows {
+		if row.AchievedAt != nil {
+			continue
+		}
+
+		ua := *row
+		list = append(list, &ua)
+	}
+
+	return list, nil
+}
+
+// GetActualByUserID returns displayed achievements: not achieved exclusive ones are hidden
+func (f *fakeProgressStore) GetActualByUserID(uuid.UUID) ([]*UserAchievement, error) {
+	var list []*UserAchievement
+	for _, row := range f.rows {
+		if row.Exclusive && row.AchievedAt == nil {
+			continue
+		}
+
+		ua := *row
+		list = append(list, &ua)
+	}
+
+	return list, nil
+}
+
+func (f *fakeProgressStore) SaveAchievements(list []*UserAchievement, trigger string, messages func(list []UserAchievement) ([]*settings.OutboxMessage, error)) error {
+	if f.saveErr != nil {
+		return f.saveErr
+	}
+
+	now := time.Now()
+	var unlocked []UserAchievement
+	for _, ua := range list {
+		row := f.rows[ua.AchievementID]
+		if row.AchievedAt != nil {
+			continue
+		}
+
+		row.Progress = ua.Progress
+		row.AchievedAt = ua.AchievedAt
+		f.events = append(f.events, newProgressEvent(ua, trigger, now))
+		if ua.Achieved() {
+			unlocked = append(unlocked, *ua)
+		}
+	}
+
+	if len(unlocked) == 0 {
+		return nil
+	}
+
+	msgs, err := messages(unlocked)
+	if err != nil {
+		return err
+	}
+
+	f.unlocks = append(f.unlocks, msgs...)
+
+	return nil
+}
+
+type fakeCountHandler struct {
+	counts map[string]int
+	broken map[string]bool
+}
+
+func (h *fakeCountHandler) Type() AchievementType {
+	return AchievementTypeVote
+}
+
+func (h *fakeCountHandler) Prepare(uuid.UUID) (Calculator, error) {
+	return func(ua *UserAchievement) error {
+		if h.broken[ua.AchievementID] {
+			return errors.New("broken params")
+		}
+
+		ua.Progress = min(h.counts[ua.AchievementID], ua.Goal)
+		if ua.Progress >= ua.Goal {
+			now := time.Now()
+			ua.AchievedAt = &now
+		}
+
+		return nil
+	}, nil
+}
+
+type fakeRecalcPublisher struct {
+	types []pevents.AchievementType
+}
+
+func (f *fakeRecalcPublisher) PublishJSON(_ context.Context, _ string, obj any) error {
+	f.types = append(f.types, obj.(pevents.AchievementRecalculateEvent).Type)
+
+	return nil
+}
+
+func newRecalcService(store *fakeProgressStore, h *fakeCountHandler) *Service {
+	return &Service{
+		up:        &fakeLocaleUsers{},
+		progress:  store,
+		publisher: &fakeRecalcPublisher{},
+		handlers:  map[AchievementType]AchievementHandler{h.Type(): h},
+	}
+}
+
+func TestService_recalc(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("repeated event changes nothing", func(t *testing.T) {
+		store := &fakeProgressStore{rows: map[string]*UserAchievement{
+			"first-vote": {UserID: userID, AchievementID: "first-vote", Goal: 1},
+			"ten-votes":  {UserID: userID, AchievementID: "ten-votes", Goal: 10},
+		}}
+		s := newRecalcService(store, &fakeCountHandler{counts: map[string]int{"first-vote": 1, "ten-votes": 3}})
+
+		require.NoError(t, s.recalc(context.Background(), userID, AchievementTypeVote))
+		require.Len(t, store.events, 2)
+
+		achievedAt := store.rows["first-vote"].AchievedAt
+		require.NotNil(t, achievedAt)
+		require.Equal(t, 3, store.rows["ten-votes"].Progress)
+
+		require.NoError(t, s.recalc(context.Background(), userID, AchievementTypeVote))
+		require.Len(t, store.events, 2)
+		require.Equal(t, achievedAt, store.rows["first-vote"].AchievedAt)
+		require.Len(t, store.unlocks, 1)
+	})
+
+	t.Run("unlocked achievement is stored to the outbox", func(t *testing.T) {
+		store := &fakeProgressStore{rows: map[string]*UserAchievement{
+			"first-vote": {UserID: userID, AchievementID: "first-vote", Title: "First vote", Goal: 1},
+			"ten-votes":  {UserID: userID, AchievementID: "ten-votes", Title: "Ten votes", Goal: 10},
+		}}
+		s := newRecalcService(store, &fakeCountHandler{counts: map[string]int{"first-vote": 1, "ten-votes": 3}})
+
+		require.NoError(t, s.recalc(context.Background(), userID, AchievementTypeVote))
+		require.Len(t, store.unlocks, 1)
+		require.Equal(t, SubjectAchievementUnlocked, store.unlocks[0].Subject)
+
+		var payload AchievementUnlockedPayload
+		require.NoError(t, json.Unmarshal(store.unlocks[0].Payload, &payload))
+		require.Equal(t, userID, payload.UserID)
+		require.Equal(t, "first-vote", payload.AchievementID)
+		require.Equal(t, "First vote", payload.Title)
+		require.Equal(t, store.rows["first-vote"].AchievedAt.Unix(), payload.AchievedAt.Unix())
+	})
+
+	t.Run("failed achievement doesn't block others", func(t *testing.T) {
+		store := &fakeProgressStore{rows: map[string]*UserAchievement{
+			"first-vote": {UserID: userID, AchievementID: "first-vote", Goal: 1},
+			"ten-votes":  {UserID: userID, AchievementID: "ten-votes", Goal: 10},
+		}}
+		s := newRecalcService(store, &fakeCountHandler{
+			counts: map[string]int{"first-vote": 1, "ten-votes": 3},
+			broken: map[string]bool{"ten-votes": true},
+		})
+
+		err := s.recalc(context.Background(), userID, AchievementTypeVote)
+		require.ErrorContains(t, err, "calculate ten-votes")
+		require.NotNil(t, store.rows["first-vote"].AchievedAt)
+		require.Zero(t, store.rows["ten-votes"].Progress)
+	})
+
+	t.Run("save error", func(t *testing.T) {
+		saveErr := errors.New("db is down")
+		store := &fakeProgressStore{
+			rows:    map[string]*UserAchievement{"first-vote": {UserID: userID, AchievementID: "first-vote", Goal: 1}},
+			saveErr: saveErr,
+		}
+		s := newRecalcService(store, &fakeCountHandler{counts: map[string]int{"first-vote": 1}})
+
+		require.ErrorIs(t, s.recalc(context.Background(), userID, AchievementTypeVote), saveErr)
+	})
+
+	t.Run("locked achievements are not calculated", func(t *testing.T) {
+		store := &fakeProgressStore{rows: map[string]*UserAchievement{
+			"vote-3":       {UserID: userID, AchievementID: "vote-3", Type: AchievementTypeVote, Series: "votes", Tier: 1, Goal: 3},
+			"vote-10":      {UserID: userID, AchievementID: "vote-10", Type: AchievementTypeVote, Series: "votes", Tier: 2, Goal: 10},
+			"exclusive":    {UserID: userID, AchievementID: "exclusive", Type: AchievementTypeVote, Exclusive: true, Goal: 100},
+			"by-exclusive": {UserID: userID, AchievementID: "by-exclusive", Type: AchievementTypeVote, Prerequisites: []string{"exclusive"}, Goal: 1},
+			"by-archived":  {UserID: userID, AchievementID: "by-archived", Type: AchievementTypeVote, Prerequisites: []string{"archived"}, Goal: 1},
+		}}
+		s := newRecalcService(store, &fakeCountHandler{counts: map[string]int{
+			"vote-3":       5,
+			"vote-10":      5,
+			"exclusive":    5,
+			"by-exclusive": 5,
+			"by-archived":  5,
+		}})
+
+		require.NoError(t, s.recalc(context.Background(), userID, AchievementTypeVote))
+		require.NotNil(t, store.rows["vote-3"].AchievedAt)
+		require.Equal(t, 5, store.rows["exclusive"].Progress)
+		require.Zero(t, store.rows["vote-10"].Progress)
+		require.Zero(t, store.rows["by-exclusive"].Progress)
+		require.Zero(t, store.rows["by-archived"].Progress)
+		require.Equal(t, []pevents.AchievementType{pevents.AchievementType(AchievementTypeVote)}, s.publisher.(*fakeRecalcPublisher).types)
+
+		// the next tier is unlocked by the achieved one
+		require.NoError(t, s.recalc(context.Background(), userID, AchievementTypeVote))
+		require.Equal(t, 5, store.rows["vote-10"].Progress)
+		require.Zero(t, store.rows["by-exclusive"].Progress)
+		require.Zero(t, store.rows["by-archived"].Progress)
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		s := newRecalcService(&fakeProgressStore{}, &fakeCountHandler{})
+
+		require.NoError(t, s.recalc(context.Background(), userID, AchievementTypeAppInfo))
+	})
+}
@@ -0,0 +1,213 @@
+package achievements
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/goverland-labs/goverland-inbox-storage/pkg/grpcsrv"
+	storagepb "github.com/goverland-labs/goverland-inbox-storage/protobuf/inboxstorage"
+)
+
+type StorageServiceProvider interface {
+	GetActualByUserID(userID uuid.UUID, locale string) ([]*UserAchievement, error)
+	GetLeaderboard(filter LeaderboardFilter) ([]LeaderboardEntry, error)
+	GetPublicBadges(addressOrENS string) ([]Badge, error)
+	GetAchievementProgressHistory(userID uuid.UUID, achievementID string, limit int) ([]ProgressEvent, error)
+}
+
+// StorageServer implements user achievements methods of the storage protocol
+type StorageServer struct {
+	storagepb.UnimplementedAchievementStorageServer
+
+	sp StorageServiceProvider
+}
+
+func NewStorageServer(sp StorageServiceProvider) *StorageServer {
+	return &StorageServer{
+		sp: sp,
+	}
+}
+
+func (s *StorageServer) GetUserAchievements(ctx context.Context, req *storagepb.GetUserAchievementsRequest) (*storagepb.GetUserAchievementsResponse, error) {
+	userID, err := grpcsrv.ParseUUID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.sp.GetActualByUserID(userID, grpcsrv.LocaleFromContext(ctx))
+	if err != nil {
+		return nil, errorMapper.Error(fmt.Errorf("get user achievements: %s: %w", req.GetUserId(), err))
+	}
+
+	res := &storagepb.GetUserAchievementsResponse{
+		Achievements: make([]*storagepb.UserAchievement, 0, len(list)),
+	}
+
+	now := time.Now()
+	for _, ua := range list {
+		res.Achievements = append(res.Achievements, convertUserAchievementToAPI(ua, now))
+	}
+
+	return res, nil
+}
+
+func (s *StorageServer) GetLeaderboard(_ context.Context, req *storagepb.GetLeaderboardRequest) (*storagepb.GetLeaderboardResponse, error) {
+	filter := LeaderboardFilter{
+		Rank:   LeaderboardRank(req.GetRank()),
+		From:   convertOptionalTime(req.GetFrom()),
+		To:     convertOptionalTime(req.GetTo()),
+		Limit:  int(req.GetLimit()),
+		Offset: int(req.GetOffset()),
+	}
+
+	if req.GetDaoId() != "" {
+		daoID, err := grpcsrv.ParseUUID("dao_id", req.GetDaoId())
+		if err != nil {
+			return nil, err
+		}
+
+		filter.DaoID = &daoID
+	}
+
+	list, err := s.sp.GetLeaderboard(filter)
+	if err != nil {
+		return nil, errorMapper.Error(fmt.Errorf("get leaderboard: %w", err))
+	}
+
+	res := &storagepb.GetLeaderboardResponse{
+		Entries: make([]*storagepb.LeaderboardEntry, 0, len(list)),
+	}
+	for _, entry := range list {
+		info := &storagepb.LeaderboardEntry{
+			Position: uint32(entry.Position),
+			UserId:   entry.UserID.String(),
+			Unlocked: uint32(entry.Unlocked),
+			Points:   uint32(entry.Points),
+		}
+
+		if entry.Address != nil {
+			info.Address = *entry.Address
+		}
+
+		if entry.ENS != nil {
+			info.Ens = *entry.ENS
+		}
+
+		res.Entries = append(res.Entries, info)
+	}
+
+	return res, nil
+}
+
+func (s *StorageServer) GetPublicBadges(_ context.Context, req *storagepb.GetPublicBadgesRequest) (*storagepb.GetPublicBadgesResponse, error) {
+	if req.GetAddressOrEns() == "" {
+		return nil, grpcsrv.InvalidArgument("address_or_ens", "must not be empty")
+	}
+
+	list, err := s.sp.GetPublicBadges(req.GetAddressOrEns())
+	if err != nil {
+		return nil, errorMapper.Error(fmt.Errorf("get public badges: %s: %w", req.GetAddressOrEns(), err))
+	}
+
+	res := &storagepb.GetPublicBadgesResponse{
+		Badges: make([]*storagepb.Badge, 0, len(list)),
+	}
+	for _, badge := range list {
+		res.Badges = append(res.Badges, &storagepb.Badge{
+			AchievementId: badge.AchievementID,
+			Title:         badge.Title,
+			Subtitle:      badge.Subtitle,
+			Images:        convertImagesToAPI(badge.Images),
+			AchievedAt:    timestamppb.New(badge.AchievedAt),
+		})
+	}
+
+	return res, nil
+}
+
+func (s *StorageServer) GetAchievementProgressHistory(_ context.Context, req *storagepb.GetAchievementProgressHistoryRequest) (*storagepb.GetAchievementProgressHistoryResponse, error) {
+	userID, err := grpcsrv.ParseUUID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetAchievementId() == "" {
+		return nil, grpcsrv.InvalidArgument("achievement_id", "must not be empty")
+	}
+
+	list, err := s.sp.GetAchievementProgressHistory(userID, req.GetAchievementId(), int(req.GetLimit()))
+	if err != nil {
+		return nil, errorMapper.Error(fmt.Errorf("get progress history: %s: %s: %w", req.GetUserId(), req.GetAchievementId(), err))
+	}
+
+	res := &storagepb.GetAchievementProgressHistoryResponse{
+		Events: make([]*storagepb.ProgressEvent, 0, len(list)),
+	}
+	for _, event := range list {
+		res.Events = append(res.Events, &storagepb.ProgressEvent{
+			CreatedAt:   timestamppb.New(event.CreatedAt),
+			OldProgress: uint32(event.OldProgress),
+			NewProgress: uint32(event.NewProgress),
+			Goal:        uint32(event.Goal),
+			Achieved:    event.Achieved,
+			Trigger:     event.Trigger,
+		})
+	}
+
+	return res, nil
+}
+
+func convertUserAchievementToAPI(ua *UserAchievement, now time.Time) *storagepb.UserAchievement {
+	requirements := make([]*storagepb.AchievementRequirement, 0, len(ua.Requirements))
+	for _, r := range ua.Requirements {
+		requirements = append(requirements, &storagepb.AchievementRequirement{
+			AchievementId: r.AchievementID,
+			Title:         r.Title,
+			Achieved:      r.Achieved,
+		})
+	}
+
+	var countdown *durationpb.Duration
+	if left := ua.Countdown(now); left > 0 {
+		countdown = durationpb.New(left)
+	}
+
+	return &storagepb.UserAchievement{
+		Id:                 ua.AchievementID,
+		Title:              ua.Title,
+		Subtitle:           ua.Subtitle,
+		Description:        ua.Description,
+		AchievementMessage: ua.AchievementMessage,
+		Images:             convertImagesToAPI(ua.Images),
+		Goal:               uint32(ua.Goal),
+		Progress:           uint32(ua.Progress),
+		AchievedAt:         convertOptionalTimestamp(ua.AchievedAt),
+		ViewedAt:           convertOptionalTimestamp(ua.ViewedAt),
+		Exclusive:          ua.Exclusive,
+		Locked:             ua.Locked,
+		Requirements:       requirements,
+		Series:             ua.Series,
+		Tier:               uint32(ua.Tier),
+		StartsAt:           convertOptionalTimestamp(ua.StartsAt),
+		EndsAt:             convertOptionalTimestamp(ua.EndsAt),
+		Availability:       string(ua.Availability(now)),
+		Countdown:          countdown,
+	}
+}
+
+func convertImagesToAPI(list []Image) []*storagepb.AchievementImage {
+	images := make([]*storagepb.AchievementImage, 0, len(list))
+	for _, image := range list {
+		images = append(images, &storagepb.AchievementImage{
+			Size: image.Size,
+			Path: image.Path,
+		})
+	}
+
+	return images
+}
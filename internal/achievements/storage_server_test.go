@@ -0,0 +1,281 @@
+package achievements
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/goverland-labs/goverland-inbox-storage/pkg/grpcsrv"
+	storagepb "github.com/goverland-labs/goverland-inbox-storage/protobuf/inboxstorage"
+)
+
+func TestStorageServer_GetUserAchievements(t *testing.T) {
+	userID := uuid.NewString()
+	achievedAt := time.Now().Add(-time.Hour)
+	startsAt := time.Now().Add(time.Hour)
+	list := []*UserAchievement{
+		{AchievementID: "vote-3", Series: "votes", Tier: 1, Goal: 3, Progress: 3, AchievedAt: &achievedAt},
+		{
+			AchievementID: "vote-10",
+			Title:         "Ten votes",
+			Images:        []Image{{Size: "sm", Path: "/sm.png"}},
+			Series:        "votes",
+			Tier:          2,
+			Goal:          10,
+			Progress:      4,
+		},
+		{
+			AchievementID: "veteran",
+			Locked:        true,
+			Requirements: []Requirement{
+				{AchievementID: "vote-3", Title: "Three votes", Achieved: true},
+				{AchievementID: "vote-10", Title: "Ten votes"},
+			},
+		},
+		{AchievementID: "upcoming", StartsAt: &startsAt},
+	}
+
+	for name, tc := range map[string]struct {
+		userID string
+		err    error
+		code   codes.Code
+		field  string
+	}{
+		"achievements": {
+			userID: userID,
+		},
+		"invalid user id": {
+			userID: "wrong",
+			code:   codes.InvalidArgument,
+			field:  "user_id",
+		},
+		"backend error": {
+			userID: userID,
+			err:    errBackend,
+			code:   codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			res, err := NewStorageServer(&fakeServerService{list: list, err: tc.err}).GetUserAchievements(
+				context.Background(),
+				&storagepb.GetUserAchievementsRequest{UserId: tc.userID},
+			)
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.code != codes.OK {
+				return
+			}
+
+			require.Len(t, res.GetAchievements(), 4)
+
+			achieved := res.GetAchievements()[0]
+			require.Equal(t, "vote-3", achieved.GetId())
+			require.Equal(t, achievedAt.Unix(), achieved.GetAchievedAt().AsTime().Unix())
+			require.Nil(t, achieved.GetViewedAt())
+
+			active := res.GetAchievements()[1]
+			require.Equal(t, "Ten votes", active.GetTitle())
+			require.Equal(t, "/sm.png", active.GetImages()[0].GetPath())
+			require.Equal(t, uint32(10), active.GetGoal())
+			require.Equal(t, uint32(4), active.GetProgress())
+			require.Equal(t, "votes", active.GetSeries())
+			require.Equal(t, uint32(2), active.GetTier())
+			require.False(t, active.GetLocked())
+			require.Equal(t, string(AvailabilityActive), active.GetAvailability())
+			require.Nil(t, active.GetStartsAt())
+			require.Nil(t, active.GetCountdown())
+
+			locked := res.GetAchievements()[2]
+			require.True(t, locked.GetLocked())
+			require.Len(t, locked.GetRequirements(), 2)
+			require.Equal(t, "vote-3", locked.GetRequirements()[0].GetAchievementId())
+			require.Equal(t, "Three votes", locked.GetRequirements()[0].GetTitle())
+			require.True(t, locked.GetRequirements()[0].GetAchieved())
+			require.False(t, locked.GetRequirements()[1].GetAchieved())
+
+			upcoming := res.GetAchievements()[3]
+			require.Equal(t, "upcoming", upcoming.GetId())
+			require.Equal(t, string(AvailabilityUpcoming), upcoming.GetAvailability())
+			require.Equal(t, startsAt.Unix(), upcoming.GetStartsAt().AsTime().Unix())
+			require.InDelta(t, time.Hour, upcoming.GetCountdown().AsDuration(), float64(time.Minute))
+		})
+	}
+}
+
+func TestStorageServer_GetLeaderboard(t *testing.T) {
+	daoID := uuid.New()
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		req      *storagepb.GetLeaderboardRequest
+		err      error
+		expected LeaderboardFilter
+		code     codes.Code
+		reason   string
+		field    string
+	}{
+		"default filter": {
+			req:      &storagepb.GetLeaderboardRequest{},
+			expected: LeaderboardFilter{Rank: LeaderboardRankPoints, Limit: defaultLeaderboardLimit},
+		},
+		"followers of the dao for the period": {
+			req: &storagepb.GetLeaderboardRequest{
+				Rank:   string(LeaderboardRankUnlocked),
+				From:   timestamppb.New(from),
+				DaoId:  daoID.String(),
+				Limit:  10,
+				Offset: 20,
+			},
+			expected: LeaderboardFilter{Rank: LeaderboardRankUnlocked, From: &from, DaoID: &daoID, Limit: 10, Offset: 20},
+		},
+		"invalid dao id": {
+			req:   &storagepb.GetLeaderboardRequest{DaoId: "wrong"},
+			code:  codes.InvalidArgument,
+			field: "dao_id",
+		},
+		"unknown rank": {
+			req:    &storagepb.GetLeaderboardRequest{Rank: "votes"},
+			code:   codes.InvalidArgument,
+			reason: "INVALID_LEADERBOARD_FILTER",
+		},
+		"backend error": {
+			req:  &storagepb.GetLeaderboardRequest{},
+			err:  errBackend,
+			code: codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sp := &fakeServerService{err: tc.err}
+
+			res, err := NewStorageServer(sp).GetLeaderboard(context.Background(), tc.req)
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.reason, grpcsrv.ErrorReason(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.code != codes.OK {
+				return
+			}
+
+			require.Equal(t, tc.expected, sp.filter)
+			require.Len(t, res.GetEntries(), 2)
+			require.Equal(t, uint32(1), res.GetEntries()[0].GetPosition())
+			require.Equal(t, testLeaderUserID.String(), res.GetEntries()[0].GetUserId())
+			require.Equal(t, "0x1", res.GetEntries()[0].GetAddress())
+			require.Equal(t, "one.eth", res.GetEntries()[0].GetEns())
+			require.Equal(t, uint32(3), res.GetEntries()[0].GetUnlocked())
+			require.Equal(t, uint32(30), res.GetEntries()[0].GetPoints())
+			require.Empty(t, res.GetEntries()[1].GetEns())
+		})
+	}
+}
+
+func TestStorageServer_GetPublicBadges(t *testing.T) {
+	for name, tc := range map[string]struct {
+		addressOrENS string
+		err          error
+		code         codes.Code
+		reason       string
+		field        string
+	}{
+		"badges": {
+			addressOrENS: "one.eth",
+		},
+		"empty address": {
+			code:  codes.InvalidArgument,
+			field: "address_or_ens",
+		},
+		"unknown user": {
+			addressOrENS: "unknown.eth",
+			code:         codes.NotFound,
+			reason:       "NOT_FOUND",
+		},
+		"backend error": {
+			addressOrENS: "one.eth",
+			err:          errBackend,
+			code:         codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			res, err := NewStorageServer(&fakeServerService{err: tc.err}).GetPublicBadges(context.Background(), &storagepb.GetPublicBadgesRequest{
+				AddressOrEns: tc.addressOrENS,
+			})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.reason, grpcsrv.ErrorReason(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.code != codes.OK {
+				return
+			}
+
+			require.Len(t, res.GetBadges(), 1)
+			require.Equal(t, "first-vote", res.GetBadges()[0].GetAchievementId())
+			require.Equal(t, "First vote", res.GetBadges()[0].GetTitle())
+			require.Equal(t, "/sm.png", res.GetBadges()[0].GetImages()[0].GetPath())
+			require.Equal(t, testBadgeAchievedAt, res.GetBadges()[0].GetAchievedAt().AsTime())
+		})
+	}
+}
+
+func TestStorageServer_GetAchievementProgressHistory(t *testing.T) {
+	userID := uuid.NewString()
+	createdAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	history := []ProgressEvent{
+		{CreatedAt: createdAt, OldProgress: 2, NewProgress: 3, Goal: 3, Achieved: true, Trigger: recalcTrigger(AchievementTypeVote)},
+		{CreatedAt: createdAt.Add(-time.Hour), OldProgress: 0, NewProgress: 2, Goal: 3, Trigger: manualTrigger(ManualActionGrant)},
+	}
+
+	for name, tc := range map[string]struct {
+		req   *storagepb.GetAchievementProgressHistoryRequest
+		err   error
+		code  codes.Code
+		field string
+	}{
+		"history": {
+			req: &storagepb.GetAchievementProgressHistoryRequest{UserId: userID, AchievementId: "votes", Limit: 10},
+		},
+		"invalid user id": {
+			req:   &storagepb.GetAchievementProgressHistoryRequest{UserId: "wrong", AchievementId: "votes"},
+			code:  codes.InvalidArgument,
+			field: "user_id",
+		},
+		"empty achievement id": {
+			req:   &storagepb.GetAchievementProgressHistoryRequest{UserId: userID},
+			code:  codes.InvalidArgument,
+			field: "achievement_id",
+		},
+		"backend error": {
+			req:  &storagepb.GetAchievementProgressHistoryRequest{UserId: userID, AchievementId: "votes"},
+			err:  errBackend,
+			code: codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sp := &fakeServerService{history: history, err: tc.err}
+
+			res, err := NewStorageServer(sp).GetAchievementProgressHistory(context.Background(), tc.req)
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.code != codes.OK {
+				return
+			}
+
+			require.Equal(t, 10, sp.limit)
+			require.Len(t, res.GetEvents(), 2)
+			require.Equal(t, createdAt, res.GetEvents()[0].GetCreatedAt().AsTime())
+			require.Equal(t, uint32(2), res.GetEvents()[0].GetOldProgress())
+			require.Equal(t, uint32(3), res.GetEvents()[0].GetNewProgress())
+			require.Equal(t, uint32(3), res.GetEvents()[0].GetGoal())
+			require.True(t, res.GetEvents()[0].GetAchieved())
+			require.Equal(t, "recalculate:vote", res.GetEvents()[0].GetTrigger())
+			require.Equal(t, "manual:grant", res.GetEvents()[1].GetTrigger())
+		})
+	}
+}
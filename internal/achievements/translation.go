@@ -0,0 +1,201 @@
+package achievements
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultLocale is the language of the catalog content, it's used when no translation matches
+const DefaultLocale = "en"
+
+var (
+	ErrInvalidTranslation = errors.New("invalid translation")
+
+	localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+)
+
+// Translation contains the achievement content for the locale, empty images mean the catalog ones are used
+type Translation struct {
+	AchievementID      string `gorm:"primaryKey"`
+	Locale             string `gorm:"primaryKey"`
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	Title              string
+	Subtitle           string
+	Description        string
+	AchievementMessage string
+	Images             []Image `gorm:"serializer:json"`
+}
+
+func (Translation) TableName() string {
+	return "achievement_translations"
+}
+
+// normalizeLocale converts BCP 47 like tags to the stored form: pt_BR -> pt-br
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// localeFallbacks returns locales in the lookup order: the full tag and its parents, pt-br -> pt.
+// The default locale is not included as its content is stored in the catalog.
+func localeFallbacks(locale string) []string {
+	locale = normalizeLocale(locale)
+	if !localePattern.MatchString(locale) {
+		return nil
+	}
+
+	var list []string
+	for locale != "" && locale != DefaultLocale {
+		list = append(list, locale)
+
+		idx := strings.LastIndex(locale, "-")
+		if idx < 0 {
+			break
+		}
+
+		locale = locale[:idx]
+	}
+
+	return list
+}
+
+func validateTranslation(t *Translation) error {
+	t.Locale = normalizeLocale(t.Locale)
+	if !localePattern.MatchString(t.Locale) {
+		return fmt.Errorf("%w: wrong locale: %s", ErrInvalidTranslation, t.Locale)
+	}
+
+	if t.Locale == DefaultLocale {
+		return fmt.Errorf("%w: content of the default locale is stored in the catalog", ErrInvalidTranslation)
+	}
+
+	if t.Title == "" {
+		return fmt.Errorf("%w: empty title", ErrInvalidTranslation)
+	}
+
+	for _, image := range t.Images {
+		if image.Size == "" || image.Path == "" {
+			return fmt.Errorf("%w: image size and path are required", ErrInvalidTranslation)
+		}
+	}
+
+	return nil
+}
+
+// validateTranslations validates translations of the achievement, each locale is translated once
+func validateTranslations(a *Achievement) error {
+	seen := make(map[string]struct{}, len(a.Translations))
+	for i := range a.Translations {
+		t := &a.Translations[i]
+		t.AchievementID = a.ID
+		if err := validateTranslation(t); err != nil {
+			return err
+		}
+
+		if _, ok := seen[t.Locale]; ok {
+			return fmt.Errorf("%w: duplicated locale: %s", ErrInvalidTranslation, t.Locale)
+		}
+
+		seen[t.Locale] = struct{}{}
+	}
+
+	return nil
+}
+
+// localize replaces the catalog content by the first matched translation
+func localize(list []*UserAchievement, translations []Translation, fallbacks []string) {
+	byAchievement := make(map[string]map[string]Translation, len(translations))
+	for _, t := range translations {
+		if byAchievement[t.AchievementID] == nil {
+			byAchievement[t.AchievementID] = make(map[string]Translation)
+		}
+
+		byAchievement[t.AchievementID][t.Locale] = t
+	}
+
+	for _, ua := range list {
+		for _, locale := range fallbacks {
+			t, ok := byAchievement[ua.AchievementID][locale]
+			if !ok {
+				continue
+			}
+
+			ua.Title = t.Title
+			ua.Subtitle = t.Subtitle
+			ua.Description = t.Description
+			ua.AchievementMessage = t.AchievementMessage
+			if len(t.Images) > 0 {
+				ua.Images = t.Images
+			}
+
+			break
+		}
+	}
+}
+
+// resolveLocale returns the requested locale or the locale of the latest user session
+func (s *Service) resolveLocale(userID uuid.UUID, requested string) string {
+	if requested != "" {
+		return requested
+	}
+
+	locale, err := s.up.GetLastLocale(userID)
+	if err != nil {
+		// the catalog content is used, so it doesn't break displaying achievements
+		log.Warn().Err(err).Msgf("get last locale: %s", userID)
+
+		return ""
+	}
+
+	return locale
+}
+
+func (s *Service) localize(list []*UserAchievement, locale string) error {
+	fallbacks := localeFallbacks(locale)
+	if len(fallbacks) == 0 || len(list) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(list))
+	for _, ua := range list {
+		ids = append(ids, ua.AchievementID)
+	}
+
+	translations, err := s.translations.GetTranslations(ids, fallbacks)
+	if err != nil {
+		return fmt.Errorf("get translations: %w", err)
+	}
+
+	localize(list, translations, fallbacks)
+
+	return nil
+}
+
+// localizeUnlocks localises unlocked achievements by the locale of the latest user session. The catalog content
+// is published if translations are not available, so the unlock is not delayed.
+func (s *Service) localizeUnlocks(list []UserAchievement) {
+	locales := make(map[uuid.UUID]string)
+	byLocale := make(map[string][]*UserAchievement)
+	for i := range list {
+		ua := &list[i]
+		locale, ok := locales[ua.UserID]
+		if !ok {
+			locale = s.resolveLocale(ua.UserID, "")
+			locales[ua.UserID] = locale
+		}
+
+		byLocale[locale] = append(byLocale[locale], ua)
+	}
+
+	for locale, group := range byLocale {
+		if err := s.localize(group, locale); err != nil {
+			log.Warn().Err(err).Msgf("localize unlocks: %s", locale)
+		}
+	}
+}
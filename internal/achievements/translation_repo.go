@@ -0,0 +1,64 @@
+package achievements
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetTranslations returns translations of the achievements for any of the locales
+func (r *Repo) GetTranslations(achievementIDs []string, locales []string) ([]Translation, error) {
+	var list []Translation
+	err := r.db.
+		Where("achievement_id in ? and locale in ?", achievementIDs, locales).
+		Find(&list).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// GetTranslationsByAchievements returns all translations of the achievements ordered by locale
+func (r *Repo) GetTranslationsByAchievements(achievementIDs []string) ([]Translation, error) {
+	var list []Translation
+	err := r.db.
+		Where("achievement_id in ?", achievementIDs).
+		Order("achievement_id, locale").
+		Find(&list).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// replaceTranslations stores translations of the achievement and removes other locales
+func replaceTranslations(tx *gorm.DB, achievementID string, list []Translation) error {
+	query := tx.Where("achievement_id = ?", achievementID)
+	if len(list) > 0 {
+		locales := make([]string, 0, len(list))
+		for _, t := range list {
+			locales = append(locales, t.Locale)
+		}
+
+		query = query.Where("locale not in ?", locales)
+	}
+
+	if err := query.Delete(&Translation{}).Error; err != nil {
+		return err
+	}
+
+	if len(list) == 0 {
+		return nil
+	}
+
+	return tx.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "achievement_id"}, {Name: "locale"}},
+			DoUpdates: clause.AssignmentColumns([]string{"updated_at", "title", "subtitle", "description", "achievement_message", "images"}),
+		}).
+		Create(&list).
+		Error
+}
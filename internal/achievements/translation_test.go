@@ -0,0 +1,191 @@
+package achievements
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-inbox-storage/internal/user"
+)
+
+type fakeLocaleUsers struct {
+	locales map[uuid.UUID]string
+	err     error
+}
+
+func (f *fakeLocaleUsers) GetByID(uuid.UUID) (*user.User, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeLocaleUsers) GetRegularByAddressOrENS(string) (*user.User, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeLocaleUsers) GetLastLocale(userID uuid.UUID) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+
+	return f.locales[userID], nil
+}
+
+// fakeTranslationStore returns translations for the requested locales as the repo does
+type fakeTranslationStore struct {
+	list []Translation
+	err  error
+}
+
+func (f *fakeTranslationStore) GetTranslations(_ []string, locales []string) ([]Translation, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	var list []Translation
+	for _, t := range f.list {
+		if slices.Contains(locales, t.Locale) {
+			list = append(list, t)
+		}
+	}
+
+	return list, nil
+}
+
+func Test_localeFallbacks(t *testing.T) {
+	for name, tc := range map[string]struct {
+		locale   string
+		expected []string
+	}{
+		"language":         {locale: "de", expected: []string{"de"}},
+		"region":           {locale: "pt_BR", expected: []string{"pt-br", "pt"}},
+		"script by region": {locale: "zh-Hant-TW", expected: []string{"zh-hant-tw", "zh-hant", "zh"}},
+		"default":          {locale: "en-US", expected: []string{"en-us"}},
+		"empty":            {locale: "", expected: nil},
+		"wrong":            {locale: "english!", expected: nil},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expected, localeFallbacks(tc.locale))
+		})
+	}
+}
+
+func Test_localize(t *testing.T) {
+	list := []*UserAchievement{
+		{AchievementID: "first-vote", Title: "First vote", Images: []Image{{Size: "large", Path: "en.png"}}},
+		{AchievementID: "beta", Title: "Beta"},
+	}
+	translations := []Translation{
+		{AchievementID: "first-vote", Locale: "pt", Title: "Primeiro voto"},
+		{AchievementID: "first-vote", Locale: "pt-br", Title: "Primeiro voto!", Images: []Image{{Size: "large", Path: "pt-br.png"}}},
+	}
+
+	localize(list, translations, localeFallbacks("pt-BR"))
+
+	require.Equal(t, "Primeiro voto!", list[0].Title)
+	require.Equal(t, "pt-br.png", list[0].Images[0].Path)
+	require.Equal(t, "Beta", list[1].Title)
+}
+
+func TestService_localize(t *testing.T) {
+	userID := uuid.New()
+	translations := &fakeTranslationStore{list: []Translation{
+		{AchievementID: "first-vote", Locale: "de", Title: "Erste Stimme"},
+		{AchievementID: "first-vote", Locale: "pt", Title: "Primeiro voto"},
+	}}
+
+	for name, tc := range map[string]struct {
+		requested    string
+		users        *fakeLocaleUsers
+		translations *fakeTranslationStore
+		title        string
+		err          bool
+	}{
+		"requested locale": {
+			requested:    "de-AT",
+			users:        &fakeLocaleUsers{locales: map[uuid.UUID]string{userID: "pt-BR"}},
+			translations: translations,
+			title:        "Erste Stimme",
+		},
+		"session locale": {
+			users:        &fakeLocaleUsers{locales: map[uuid.UUID]string{userID: "pt-BR"}},
+			translations: translations,
+			title:        "Primeiro voto",
+		},
+		"no session locale": {
+			users:        &fakeLocaleUsers{},
+			translations: translations,
+			title:        "First vote",
+		},
+		"session is not available": {
+			users:        &fakeLocaleUsers{err: errors.New("db is down")},
+			translations: translations,
+			title:        "First vote",
+		},
+		"translations are not available": {
+			requested:    "de",
+			users:        &fakeLocaleUsers{},
+			translations: &fakeTranslationStore{err: errors.New("db is down")},
+			title:        "First vote",
+			err:          true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := &Service{up: tc.users, translations: tc.translations}
+			list := []*UserAchievement{{UserID: userID, AchievementID: "first-vote", Title: "First vote"}}
+
+			err := s.localize(list, s.resolveLocale(userID, tc.requested))
+			if tc.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.title, list[0].Title)
+		})
+	}
+}
+
+func TestService_localizeUnlocks(t *testing.T) {
+	achievedAt := time.Now()
+	german, portuguese, unknown := uuid.New(), uuid.New(), uuid.New()
+	s := &Service{
+		up: &fakeLocaleUsers{locales: map[uuid.UUID]string{german: "de", portuguese: "pt-BR"}},
+		translations: &fakeTranslationStore{list: []Translation{
+			{AchievementID: "first-vote", Locale: "de", Title: "Erste Stimme", AchievementMessage: "Gut gemacht"},
+			{AchievementID: "first-vote", Locale: "pt", Title: "Primeiro voto", AchievementMessage: "Muito bem"},
+		}},
+	}
+	list := []UserAchievement{
+		{UserID: german, AchievementID: "first-vote", Title: "First vote", AchievementMessage: "Well done", AchievedAt: &achievedAt},
+		{UserID: portuguese, AchievementID: "first-vote", Title: "First vote", AchievementMessage: "Well done"},
+		{UserID: unknown, AchievementID: "first-vote", Title: "First vote", AchievementMessage: "Well done"},
+	}
+
+	s.localizeUnlocks(list)
+
+	payload := newUnlockedPayload(list[0])
+	require.Equal(t, "Erste Stimme", payload.Title)
+	require.Equal(t, "Gut gemacht", payload.AchievementMessage)
+	require.Equal(t, "Primeiro voto", list[1].Title)
+	require.Equal(t, "Muito bem", list[1].AchievementMessage)
+	require.Equal(t, "First vote", list[2].Title)
+}
+
+func Test_validateTranslations(t *testing.T) {
+	a := &Achievement{ID: "first-vote", Translations: []Translation{
+		{Locale: "pt_BR", Title: "Primeiro voto"},
+		{Locale: "de", Title: "Erste Stimme"},
+	}}
+	require.NoError(t, validateTranslations(a))
+	require.Equal(t, "first-vote", a.Translations[0].AchievementID)
+	require.Equal(t, "pt-br", a.Translations[0].Locale)
+
+	a.Translations = append(a.Translations, Translation{Locale: "pt-BR", Title: "Primeiro voto!"})
+	require.ErrorIs(t, validateTranslations(a), ErrInvalidTranslation)
+
+	a.Translations = []Translation{{Locale: "en", Title: "First vote"}}
+	require.ErrorIs(t, validateTranslations(a), ErrInvalidTranslation)
+}
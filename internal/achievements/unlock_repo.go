@@ -0,0 +1,52 @@
+package achievements
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-inbox-storage/internal/settings"
+)
+
+// addUnlocks fills unlocked achievements with the catalog content and stores their events built by messages
+// to the outbox in the transaction of the unlock, so the event is published once the unlock is committed
+func addUnlocks(tx *gorm.DB, list []UserAchievement, messages func(list []UserAchievement) ([]*settings.OutboxMessage, error)) error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(list))
+	for _, ua := range list {
+		ids = append(ids, ua.AchievementID)
+	}
+
+	var catalog []Achievement
+	if err := tx.Where("id in ?", ids).Find(&catalog).Error; err != nil {
+		return fmt.Errorf("get unlocked achievements: %w", err)
+	}
+
+	byID := make(map[string]Achievement, len(catalog))
+	for _, a := range catalog {
+		byID[a.ID] = a
+	}
+
+	for i := range list {
+		a := byID[list[i].AchievementID]
+		list[i].Title = a.Title
+		list[i].Subtitle = a.Subtitle
+		list[i].AchievementMessage = a.AchievementMessage
+		list[i].Images = a.Images
+		list[i].Exclusive = a.Exclusive
+	}
+
+	msgs, err := messages(list)
+	if err != nil {
+		return fmt.Errorf("build unlock messages: %w", err)
+	}
+
+	if err = tx.Create(&msgs).Error; err != nil {
+		return fmt.Errorf("create unlock messages: %w", err)
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package achievements
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"slices"
 	"sync"
@@ -40,9 +41,28 @@ type VotesParams struct {
 	Verified bool `json:"verified"`
 }
 
+func validateVotesParams(raw json.RawMessage) error {
+	var params VotesParams
+	if err := decodeParams(raw, &params); err != nil {
+		return err
+	}
+
+	if params.Goals <= 0 {
+		return errors.New("goals must be positive")
+	}
+
+	return nil
+}
+
+// votedDao describes the dao with times of user votes from our platform
+type votedDao struct {
+	dao     coresdkdao.Dao
+	votedAt []time.Time
+}
+
 type data struct {
 	expiresAt time.Time
-	list      []coresdkdao.Dao
+	list      []votedDao
 }
 
 type VotingHandler struct {
@@ -62,42 +82,49 @@ func NewVotingHandler(dp DataProvider, ug UserGetter) *VotingHandler {
 	}
 }
 
-func (h *VotingHandler) Allow(atype AchievementType) bool {
-	return atype == AchievementTypeVote
+func (h *VotingHandler) Type() AchievementType {
+	return AchievementTypeVote
 }
 
-func (h *VotingHandler) Process(ua *UserAchievement) error {
-	if ua.Type != AchievementTypeVote {
-		return nil
-	}
-
-	var details VotesParams
-	if err := json.Unmarshal(ua.Params, &details); err != nil {
-		return fmt.Errorf("unmarshalling votes params: %w", err)
-	}
-
+func (h *VotingHandler) Prepare(userID uuid.UUID) (Calculator, error) {
 	// get user with address
-	user, err := h.ug.GetByID(ua.UserID)
+	user, err := h.ug.GetByID(userID)
 	if err != nil {
-		log.Err(err).Msgf("get user by id: %s", ua.UserID)
+		log.Err(err).Msgf("get user by id: %s", userID)
 
-		return nil
+		return skipCalculation, nil
 	}
 
 	if !user.HasAddress() {
 		log.Warn().Msg("voting user does not have an address")
 
-		return nil
+		return skipCalculation, nil
 	}
 
 	list, err := h.getUniqueDaoListByVotes(*user.Address)
 	if err != nil {
-		return fmt.Errorf("get votes: %w", err)
+		return nil, fmt.Errorf("get votes: %w", err)
+	}
+
+	return func(ua *UserAchievement) error {
+		return h.process(ua, list)
+	}, nil
+}
+
+func (h *VotingHandler) process(ua *UserAchievement, list []votedDao) error {
+	var details VotesParams
+	if err := json.Unmarshal(ua.Params, &details); err != nil {
+		return fmt.Errorf("unmarshalling votes params: %w", err)
 	}
 
+	window := ua.Window()
 	counter := 0
 	for _, info := range list {
-		if details.Verified && !info.Verified {
+		if details.Verified && !info.dao.Verified {
+			continue
+		}
+
+		if !slices.ContainsFunc(info.votedAt, window.Contains) {
 			continue
 		}
 
@@ -121,20 +148,21 @@ func chunkBy[T any](items []T, chunkSize int) (chunks [][]T) {
 }
 
 // micro optimization for getting votes for similar achievements types
-func (h *VotingHandler) getUniqueDaoListByVotes(address string) ([]coresdkdao.Dao, error) {
+func (h *VotingHandler) getUniqueDaoListByVotes(address string) ([]votedDao, error) {
 	h.mu.RLock()
 	val, ok := h.cache[address]
 	h.mu.RUnlock()
 	if ok && val.expiresAt.After(time.Now()) {
-		list := make([]coresdkdao.Dao, len(val.list))
+		list := make([]votedDao, len(val.list))
 		copy(list, val.list)
 
 		return list, nil
 	}
 
-	val.list = make([]coresdkdao.Dao, 0, defaultLimit)
+	val.list = make([]votedDao, 0, defaultLimit)
 	limit, offset := defaultLimit, 0
 	daos := make([]string, 0, limit)
+	votedAt := make(map[string][]time.Time)
 	for {
 		list, err := h.dp.GetUserVotes(context.TODO(), address, coresdk.GetUserVotesRequest{
 			Offset: offset,
@@ -149,11 +177,12 @@ func (h *VotingHandler) getUniqueDaoListByVotes(address string) ([]coresdkdao.Da
 				continue
 			}
 
-			if slices.Contains(daos, item.DaoID.String()) {
-				continue
+			daoID := item.DaoID.String()
+			if _, ok := votedAt[daoID]; !ok {
+				daos = append(daos, daoID)
 			}
 
-			daos = append(daos, item.DaoID.String())
+			votedAt[daoID] = append(votedAt[daoID], time.Unix(int64(item.Created), 0))
 		}
 
 		if len(list.Items) < limit {
@@ -172,7 +201,12 @@ func (h *VotingHandler) getUniqueDaoListByVotes(address string) ([]coresdkdao.Da
 			return nil, fmt.Errorf("get dao list: %d: %w", idx, err)
 		}
 
-		val.list = append(val.list, list.Items...)
+		for _, dao := range list.Items {
+			val.list = append(val.list, votedDao{
+				dao:     dao,
+				votedAt: votedAt[dao.ID.String()],
+			})
+		}
 	}
 
 	val.expiresAt = time.Now().Add(defaultTTL)
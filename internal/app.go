@@ -21,11 +21,14 @@ import (
 
 	"github.com/goverland-labs/goverland-inbox-storage/internal/achievements"
 	"github.com/goverland-labs/goverland-inbox-storage/internal/appversions"
+	"github.com/goverland-labs/goverland-inbox-storage/internal/audit"
+	"github.com/goverland-labs/goverland-inbox-storage/internal/autofollow"
 	"github.com/goverland-labs/goverland-inbox-storage/internal/config"
 	"github.com/goverland-labs/goverland-inbox-storage/internal/delegate"
 	"github.com/goverland-labs/goverland-inbox-storage/internal/metrics"
 	"github.com/goverland-labs/goverland-inbox-storage/internal/proposal"
 	"github.com/goverland-labs/goverland-inbox-storage/internal/settings"
+	"github.com/goverland-labs/goverland-inbox-storage/internal/snapshot"
 	"github.com/goverland-labs/goverland-inbox-storage/internal/subscription"
 	"github.com/goverland-labs/goverland-inbox-storage/internal/user"
 	"github.com/goverland-labs/goverland-inbox-storage/internal/zerion"
@@ -33,6 +36,7 @@ import (
 	"github.com/goverland-labs/goverland-inbox-storage/pkg/health"
 	"github.com/goverland-labs/goverland-inbox-storage/pkg/prometheus"
 	zerionsdk "github.com/goverland-labs/goverland-inbox-storage/pkg/sdk/zerion"
+	"github.com/goverland-labs/goverland-inbox-storage/protobuf/inboxstorage"
 )
 
 type Application struct {
@@ -53,6 +57,8 @@ type Application struct {
 	zerionAPI       *zerionsdk.Client
 	zerionService   *zerion.Service
 	delegateService *delegate.Service
+	snapshotService *snapshot.Service
+	auditService    *audit.Service
 }
 
 func NewApplication(cfg config.App) (*Application, error) {
@@ -153,24 +159,27 @@ func (a *Application) initServices() error {
 		return err
 	}
 
-	if err = a.initSubscription(); err != nil {
+	if err = a.initSubscription(pb); err != nil {
 		return err
 	}
-	if err = a.initPushes(pb); err != nil {
+	if err = a.initPushes(nc, pb); err != nil {
 		return err
 	}
 	a.initUsers(pb)
-	if err = a.initAchievements(nc); err != nil {
+	a.initProposals(pb)
+	a.initDelegates(pb)
+	if err = a.initAchievements(nc, pb); err != nil {
 		return err
 	}
-	a.initProposals()
-	a.initDelegates()
+	a.initAutoFollow()
+	a.initSnapshots()
+	a.initAudit()
 	a.initAppVersions()
 
 	return nil
 }
 
-func (a *Application) initPushes(pb *natsclient.Publisher) error {
+func (a *Application) initPushes(nc *nats.Conn, pb *natsclient.Publisher) error {
 	vc, err := vaultapi.NewClient(&vaultapi.Config{
 		Address: a.cfg.Vault.Address,
 	})
@@ -179,27 +188,125 @@ func (a *Application) initPushes(pb *natsclient.Publisher) error {
 	}
 
 	vc.SetToken(a.cfg.Vault.Token)
-	pushRepo := settings.NewPushRepo(vc.Logical(), a.cfg.Vault.BasePath)
+	vaultRepo := settings.NewPushRepo(vc.Logical(), a.cfg.Vault.BasePath, a.cfg.PushTokens.LegacyFallback)
+	pushRepo, err := a.initPushTokens(vc, vaultRepo)
+	if err != nil {
+		return err
+	}
+
+	if a.cfg.PushTokens.MigrateLegacy {
+		// legacy tokens are written to the same storage as the service uses,
+		// the fallback of the serving repo is disabled when the migration is finished
+		legacyRepo := settings.NewPushRepo(vc.Logical(), a.cfg.Vault.BasePath, false)
+		migrator := settings.NewLegacyTokenMigrator(legacyRepo, pushRepo, settings.NewLegacyMigrationRepo(a.db), vaultRepo)
+		a.manager.AddWorker(process.NewCallbackWorker("push_tokens_legacy_migration", migrator.Start))
+	}
+
+	metadataRepo := settings.NewPushMetadataRepo(a.db)
 	detailsRepo := settings.NewDetailsRepo(a.db)
-	service := settings.NewService(pushRepo, detailsRepo, pb)
+	service := settings.NewService(pushRepo, metadataRepo, detailsRepo, a.sub, pb)
 
 	a.settings = service
 
+	outboxWorker := settings.NewOutboxWorker(settings.NewOutboxRepo(a.db), pb)
+	a.manager.AddWorker(process.NewCallbackWorker("settings-outbox", outboxWorker.Start))
+
+	cs, err := settings.NewConsumer(nc, service)
+	if err != nil {
+		return fmt.Errorf("settings consumer: %w", err)
+	}
+	a.manager.AddWorker(process.NewCallbackWorker("settings-consumer", cs.Start))
+
+	cleanupWorker := settings.NewPushCleanupWorker(service, a.cfg.PushTokens.CleanupInterval, a.cfg.PushTokens.StaleTTL)
+	a.manager.AddWorker(process.NewCallbackWorker("push_tokens_cleanup", cleanupWorker.Start))
+
 	return nil
 }
 
-func (a *Application) initProposals() {
+func (a *Application) initPushTokens(vc *vaultapi.Client, vaultRepo *settings.PushRepo) (settings.TokenProvider, error) {
+	cfg := a.cfg.PushTokens
+
+	if cfg.Storage == config.PushTokensStorageVault && !cfg.Mirror && !cfg.Migrate {
+		a.initPushMetadataBackfill(vaultRepo)
+
+		return vaultRepo, nil
+	}
+
+	var (
+		tc  settings.TokenCipher
+		err error
+	)
+	if cfg.TransitKey != "" {
+		tc = settings.NewTransitCipher(vc.Logical(), cfg.TransitMount, cfg.TransitKey)
+	} else if tc, err = settings.NewAESCipher(cfg.EncryptionKey); err != nil {
+		return nil, fmt.Errorf("push tokens cipher: %w", err)
+	}
+
+	pgRepo := settings.NewPgPushRepo(a.db, tc)
+	if cfg.Migrate {
+		migrator := settings.NewTokenMigrator(vaultRepo, pgRepo)
+		a.manager.AddWorker(process.NewCallbackWorker("push_tokens_migration", migrator.Start))
+	}
+
+	switch cfg.Storage {
+	case config.PushTokensStoragePostgres:
+		a.initPushMetadataBackfill(pgRepo)
+
+		return pgRepo, nil
+	case config.PushTokensStorageVault:
+		a.initPushMetadataBackfill(vaultRepo)
+
+		if cfg.Mirror {
+			return settings.NewMirrorPushRepo(vaultRepo, pgRepo), nil
+		}
+
+		return vaultRepo, nil
+	default:
+		return nil, fmt.Errorf("unknown push tokens storage: %s", cfg.Storage)
+	}
+}
+
+// initPushMetadataBackfill walks only the storage which serves tokens, metadata is shared by all storages
+func (a *Application) initPushMetadataBackfill(repo interface {
+	WalkDevices(fn func(userID, deviceUUID string) error) error
+}) {
+	if !a.cfg.PushTokens.BackfillMetadata {
+		return
+	}
+
+	backfiller := settings.NewMetadataBackfiller(repo, settings.NewPushMetadataRepo(a.db))
+	a.manager.AddWorker(process.NewCallbackWorker("push_tokens_metadata_backfill", backfiller.Start))
+}
+
+func (a *Application) initProposals(pb *natsclient.Publisher) {
 	repo := proposal.NewRepo(a.db)
 
 	aiClient := proposal.NewAIClient(a.cfg.AI.ExternalClientKey)
-	a.proposalService = proposal.NewService(repo, a.us, a.coreClient, aiClient, a.cfg.AI.MonthlyRateLimit)
+	a.proposalService = proposal.NewService(repo, a.us, a.coreClient, aiClient, a.cfg.AI.MonthlyRateLimit, pb)
 }
 
-func (a *Application) initDelegates() {
+func (a *Application) initAutoFollow() {
+	service := autofollow.NewService(a.settings, a.us, a.zerionService, a.sub)
+
+	worker := autofollow.NewWorker(service, a.cfg.AutoFollow.SyncInterval)
+	a.manager.AddWorker(process.NewCallbackWorker("auto_follow", worker.Start))
+}
+
+func (a *Application) initAudit() {
+	a.auditService = audit.NewService(audit.NewRepo(a.db))
+}
+
+func (a *Application) initSnapshots() {
+	signer := snapshot.NewSigner(a.cfg.Snapshot.SigningKey)
+
+	a.snapshotService = snapshot.NewService(a.settings, a.sub, signer)
+}
+
+func (a *Application) initDelegates(pb *natsclient.Publisher) {
 	adRepo := delegate.NewAllowedDaoRepo(a.db)
 	udRepo := delegate.NewUserDelegatedRepo(a.db)
 
-	a.delegateService = delegate.NewService(adRepo, udRepo)
+	a.delegateService = delegate.NewService(adRepo, udRepo, pb)
 }
 
 func (a *Application) initUsers(pb *natsclient.Publisher) {
@@ -229,12 +336,17 @@ func (a *Application) initUsers(pb *natsclient.Publisher) {
 	a.manager.AddWorker(process.NewCallbackWorker("can_vote", canVoteWorker.Start))
 }
 
-func (a *Application) initAchievements(nc *nats.Conn) error {
+func (a *Application) initAchievements(nc *nats.Conn, pb *natsclient.Publisher) error {
 	repo := achievements.NewRepo(a.db)
 	service := achievements.NewService(a.us, repo, []achievements.AchievementHandler{
 		achievements.NewAppInfoHandler(a.sr),
 		achievements.NewVotingHandler(a.coreClient, a.us),
-	})
+		achievements.NewSubscriptionsHandler(a.sub),
+		achievements.NewActivityStreakHandler(a.us),
+		achievements.NewAISummaryHandler(a.proposalService),
+		achievements.NewDelegationHandler(a.delegateService),
+		achievements.NewPushEnabledHandler(a.settings),
+	}, pb)
 
 	a.as = service
 
@@ -245,6 +357,9 @@ func (a *Application) initAchievements(nc *nats.Conn) error {
 
 	a.manager.AddWorker(process.NewCallbackWorker("achievements-consumer", cs.Start))
 
+	backfillWorker := achievements.NewBackfillWorker(service, a.cfg.Achievements.BackfillInterval, a.cfg.Achievements.BackfillBatchSize)
+	a.manager.AddWorker(process.NewCallbackWorker("achievements_backfill", backfillWorker.Start))
+
 	return nil
 }
 
@@ -269,9 +384,10 @@ func (a *Application) initHealthWorker() error {
 	return nil
 }
 
-func (a *Application) initSubscription() error {
+func (a *Application) initSubscription(pb *natsclient.Publisher) error {
 	repo := subscription.NewRepo(a.db)
 	globalRepo := subscription.NewGlobalRepo(a.db)
+	eventRepo := subscription.NewEventRepo(a.db)
 	cache := subscription.NewCache()
 
 	feedConn, err := grpc.NewClient(a.cfg.API.FeedAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -279,7 +395,7 @@ func (a *Application) initSubscription() error {
 		return fmt.Errorf("create connection with storage server: %v", err)
 	}
 	fc := inboxapi.NewFeedClient(feedConn)
-	service, err := subscription.NewService(repo, globalRepo, cache, a.cfg.Core.SubscriberID, a.coreClient, fc)
+	service, err := subscription.NewService(repo, globalRepo, eventRepo, cache, a.cfg.Core.SubscriberID, a.coreClient, a.coreClient, fc, pb)
 	if err != nil {
 		return fmt.Errorf("subscription service: %w", err)
 	}
@@ -304,13 +420,20 @@ func (a *Application) initAPI() error {
 	)
 
 	inboxapi.RegisterSubscriptionServer(srv, subscription.NewServer(a.sub))
-	inboxapi.RegisterUserServer(srv, user.NewServer(a.us))
+	inboxapi.RegisterUserServer(srv, user.NewServer(a.us, a.auditService))
 	inboxapi.RegisterProposalServer(srv, proposal.NewServer(a.proposalService))
-	inboxapi.RegisterSettingsServer(srv, settings.NewServer(a.settings, a.us))
+	inboxapi.RegisterSettingsServer(srv, settings.NewServer(a.settings, a.us, a.auditService))
 	inboxapi.RegisterAchievementServer(srv, achievements.NewServer(a.as))
 	inboxapi.RegisterAppVersionsServer(srv, appversions.NewServer(a.vs))
 	inboxapi.RegisterDelegateServer(srv, delegate.NewServer(a.delegateService))
 
+	inboxstorage.RegisterSubscriptionStorageServer(srv, subscription.NewStorageServer(a.sub))
+	inboxstorage.RegisterSettingsStorageServer(srv, settings.NewStorageServer(a.settings, a.auditService))
+	inboxstorage.RegisterSnapshotStorageServer(srv, snapshot.NewStorageServer(a.snapshotService))
+	inboxstorage.RegisterAuditStorageServer(srv, audit.NewStorageServer(a.auditService))
+	inboxstorage.RegisterAchievementStorageServer(srv, achievements.NewStorageServer(a.as))
+	inboxstorage.RegisterAchievementCatalogStorageServer(srv, achievements.NewCatalogStorageServer(a.as))
+
 	a.manager.AddWorker(grpcsrv.NewGrpcServerWorker("API", srv, a.cfg.API.Bind))
 
 	return nil
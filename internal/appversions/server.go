@@ -2,19 +2,26 @@ package appversions
 
 import (
 	"context"
+	"fmt"
 
 	proto "github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+
+	"github.com/goverland-labs/goverland-inbox-storage/pkg/grpcsrv"
 )
 
+var errorMapper = grpcsrv.NewErrorMapper()
+
+type ServiceProvider interface {
+	GetListByPlatform(pl Platform) ([]Info, error)
+}
+
 type Server struct {
 	proto.UnimplementedAppVersionsServer
 
-	sp *Service
+	sp ServiceProvider
 }
 
-func NewServer(s *Service) *Server {
+func NewServer(s ServiceProvider) *Server {
 	return &Server{
 		sp: s,
 	}
@@ -29,7 +36,7 @@ func (s *Server) GetVersionsDetails(_ context.Context, req *proto.GetVersionsDet
 
 	list, err := s.sp.GetListByPlatform(pl)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to fetch list: %v", err)
+		return nil, errorMapper.Error(fmt.Errorf("fetch list: %w", err))
 	}
 
 	return &proto.GetVersionsDetailsResponse{
@@ -0,0 +1,51 @@
+package appversions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	proto "github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeService struct {
+	platform Platform
+	err      error
+}
+
+func (f *fakeService) GetListByPlatform(pl Platform) ([]Info, error) {
+	f.platform = pl
+
+	return []Info{{Version: "1.0.0", Platform: pl}}, f.err
+}
+
+func TestUnitServerGetVersionsDetails(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		platform string
+		err      error
+		expected Platform
+		code     codes.Code
+	}{
+		{name: "ios by default", expected: PlatformIos, code: codes.OK},
+		{name: "requested platform", platform: "android", expected: PlatformAndroid, code: codes.OK},
+		{name: "backend error", err: errors.New("backend is down"), expected: PlatformIos, code: codes.Internal},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sp := &fakeService{err: tc.err}
+
+			resp, err := NewServer(sp).GetVersionsDetails(context.Background(), &proto.GetVersionsDetailsRequest{
+				Platform: tc.platform,
+			})
+
+			require.Equal(t, tc.code, status.Code(err), err)
+			require.Equal(t, tc.expected, sp.platform)
+			if tc.code == codes.OK {
+				require.Len(t, resp.GetDetails(), 1)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Filter interface {
+	Apply(*gorm.DB) *gorm.DB
+}
+
+type PageFilter struct {
+	Offset int
+	Limit  int
+}
+
+func (f PageFilter) Apply(db *gorm.DB) *gorm.DB {
+	return db.Offset(f.Offset).Limit(f.Limit)
+}
+
+type UserIDFilter struct {
+	ID uuid.UUID
+}
+
+func (f UserIDFilter) Apply(db *gorm.DB) *gorm.DB {
+	return db.Where("user_id = ?", f.ID)
+}
+
+type CreatedAtFilter struct {
+	From time.Time
+	To   time.Time
+}
+
+func (f CreatedAtFilter) Apply(db *gorm.DB) *gorm.DB {
+	if !f.From.IsZero() {
+		db = db.Where("created_at >= ?", f.From)
+	}
+
+	if !f.To.IsZero() {
+		db = db.Where("created_at < ?", f.To)
+	}
+
+	return db
+}
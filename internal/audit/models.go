@@ -0,0 +1,52 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Operation string
+
+const (
+	OperationAddPushToken            Operation = "add_push_token"
+	OperationRemovePushToken         Operation = "remove_push_token"
+	OperationSetPushDetails          Operation = "set_push_details"
+	OperationSetFeedSettings         Operation = "set_feed_settings"
+	OperationSetAchievementsSettings Operation = "set_achievements_settings"
+	OperationDeleteUser              Operation = "delete_user"
+	OperationCreateSession           Operation = "create_session"
+	OperationDeleteSession           Operation = "delete_session"
+	OperationUseAuthNonce            Operation = "use_auth_nonce"
+)
+
+// Record is the append-only audit log entry
+type Record struct {
+	ID        uint64 `gorm:"primaryKey"`
+	CreatedAt time.Time
+	// Actor is prefixed with "claimed:" while it's taken from the request metadata without authentication
+	Actor     string
+	UserID    uuid.UUID
+	Operation Operation
+	Before    json.RawMessage `gorm:"type:jsonb;serializer:json"`
+	After     json.RawMessage `gorm:"type:jsonb;serializer:json"`
+	Metadata  RequestMetadata `gorm:"type:jsonb;serializer:json"`
+}
+
+func (Record) TableName() string {
+	return "audit_log"
+}
+
+// RequestMetadata describes the request which caused the change
+type RequestMetadata struct {
+	Method    string `json:"method,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+	UserAgent string `json:"user_agent,omitempty"`
+	Peer      string `json:"peer,omitempty"`
+}
+
+type RecordList struct {
+	Records    []Record
+	TotalCount int64
+}
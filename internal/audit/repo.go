@@ -0,0 +1,51 @@
+package audit
+
+import (
+	"gorm.io/gorm"
+)
+
+type Repo struct {
+	db *gorm.DB
+}
+
+func NewRepo(db *gorm.DB) *Repo {
+	return &Repo{db: db}
+}
+
+func (r *Repo) Create(item *Record) error {
+	return r.db.Create(item).Error
+}
+
+// GetByFilters returns records from the newest to the oldest
+func (r *Repo) GetByFilters(filters []Filter) (RecordList, error) {
+	db := r.db.Model(&Record{})
+	for _, f := range filters {
+		if _, ok := f.(PageFilter); ok {
+			continue
+		}
+		db = f.Apply(db)
+	}
+
+	var cnt int64
+	err := db.Count(&cnt).Error
+	if err != nil {
+		return RecordList{}, err
+	}
+
+	for _, f := range filters {
+		if _, ok := f.(PageFilter); ok {
+			db = f.Apply(db)
+		}
+	}
+
+	var list []Record
+	err = db.Order("id desc").Find(&list).Error
+	if err != nil {
+		return RecordList{}, err
+	}
+
+	return RecordList{
+		Records:    list,
+		TotalCount: cnt,
+	}, nil
+}
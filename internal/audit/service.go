@@ -0,0 +1,118 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/goverland-labs/goverland-inbox-storage/pkg/grpcsrv"
+)
+
+const (
+	unknownActor = "unknown"
+
+	requestIDMetadataKey = "x-request-id"
+	userAgentMetadataKey = "user-agent"
+
+	visibleTokenChars = 4
+)
+
+type Storage interface {
+	Create(item *Record) error
+	GetByFilters(filters []Filter) (RecordList, error)
+}
+
+type Service struct {
+	repo Storage
+}
+
+func NewService(r Storage) *Service {
+	return &Service{repo: r}
+}
+
+// Record stores the audit entry. Audit must not break the operation, so errors are only logged.
+func (s *Service) Record(ctx context.Context, userID uuid.UUID, op Operation, before, after any) {
+	item := &Record{
+		CreatedAt: time.Now(),
+		Actor:     unknownActor,
+		UserID:    userID,
+		Operation: op,
+		Metadata:  requestMetadata(ctx),
+	}
+
+	if actor, ok := grpcsrv.ClaimedActorFromContext(ctx); ok {
+		item.Actor = grpcsrv.ClaimedActorPrefix + actor
+	}
+
+	var err error
+	if item.Before, err = marshal(before); err != nil {
+		log.Error().Err(err).Msgf("marshal audit before value: %s", op)
+	}
+
+	if item.After, err = marshal(after); err != nil {
+		log.Error().Err(err).Msgf("marshal audit after value: %s", op)
+	}
+
+	if err = s.repo.Create(item); err != nil {
+		log.Error().Err(err).Msgf("store audit record: %s: %s", op, userID)
+	}
+}
+
+func (s *Service) GetByFilters(filters []Filter) (RecordList, error) {
+	list, err := s.repo.GetByFilters(filters)
+	if err != nil {
+		return RecordList{}, fmt.Errorf("get audit records: %w", err)
+	}
+
+	return list, nil
+}
+
+// RedactToken keeps only the last chars of the token to distinguish tokens without exposing them
+func RedactToken(token string) string {
+	if len(token) <= visibleTokenChars {
+		return "***"
+	}
+
+	return "***" + token[len(token)-visibleTokenChars:]
+}
+
+func marshal(value any) (json.RawMessage, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(value)
+}
+
+func requestMetadata(ctx context.Context) RequestMetadata {
+	var rm RequestMetadata
+	if method, ok := grpc.Method(ctx); ok {
+		rm.Method = method
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		rm.Peer = p.Addr.String()
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return rm
+	}
+
+	if values := md.Get(requestIDMetadataKey); len(values) > 0 {
+		rm.RequestID = values[0]
+	}
+
+	if values := md.Get(userAgentMetadataKey); len(values) > 0 {
+		rm.UserAgent = values[0]
+	}
+
+	return rm
+}
@@ -0,0 +1,85 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/goverland-labs/goverland-inbox-storage/pkg/grpcsrv"
+)
+
+type fakeStorage struct {
+	records []Record
+	err     error
+}
+
+func (f *fakeStorage) Create(item *Record) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	f.records = append(f.records, *item)
+
+	return nil
+}
+
+func (f *fakeStorage) GetByFilters([]Filter) (RecordList, error) {
+	return RecordList{Records: f.records, TotalCount: int64(len(f.records))}, f.err
+}
+
+func TestUnitRecord(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("claimed actor and request metadata", func(t *testing.T) {
+		storage := &fakeStorage{}
+		ctx, err := grpcsrv.NewAuthInterceptor().AuthAndIdentifyTickerFunc(
+			metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+				grpcsrv.ActorMetadataKey, "admin",
+				requestIDMetadataKey, "request-1",
+				userAgentMetadataKey, "inbox-api",
+			)),
+		)
+		require.NoError(t, err)
+
+		NewService(storage).Record(ctx, userID, OperationSetPushDetails, map[string]bool{"enabled": false}, map[string]bool{"enabled": true})
+
+		require.Len(t, storage.records, 1)
+		record := storage.records[0]
+		require.Equal(t, "claimed:admin", record.Actor)
+		require.Equal(t, userID, record.UserID)
+		require.Equal(t, OperationSetPushDetails, record.Operation)
+		require.JSONEq(t, `{"enabled":false}`, string(record.Before))
+		require.JSONEq(t, `{"enabled":true}`, string(record.After))
+		require.Equal(t, RequestMetadata{RequestID: "request-1", UserAgent: "inbox-api"}, record.Metadata)
+	})
+
+	t.Run("unknown actor without values", func(t *testing.T) {
+		storage := &fakeStorage{}
+
+		NewService(storage).Record(context.Background(), userID, OperationDeleteUser, nil, nil)
+
+		require.Len(t, storage.records, 1)
+		require.Equal(t, unknownActor, storage.records[0].Actor)
+		require.Nil(t, storage.records[0].Before)
+		require.Nil(t, storage.records[0].After)
+	})
+
+	t.Run("storage error does not break the operation", func(t *testing.T) {
+		storage := &fakeStorage{err: errors.New("db is down")}
+
+		require.NotPanics(t, func() {
+			NewService(storage).Record(context.Background(), userID, OperationDeleteUser, nil, nil)
+		})
+	})
+}
+
+func TestUnitRedactToken(t *testing.T) {
+	require.Equal(t, "***", RedactToken(""))
+	require.Equal(t, "***", RedactToken("abcd"))
+	require.Equal(t, "***bcde", RedactToken("abcde"))
+	require.Equal(t, "***7890", RedactToken("fcm-token-1234567890"))
+}
@@ -0,0 +1,86 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/goverland-labs/goverland-inbox-storage/pkg/grpcsrv"
+	storagepb "github.com/goverland-labs/goverland-inbox-storage/protobuf/inboxstorage"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 500
+)
+
+var errorMapper = grpcsrv.NewErrorMapper()
+
+// StorageServer implements audit methods of the storage protocol
+type StorageServer struct {
+	storagepb.UnimplementedAuditStorageServer
+
+	sp *Service
+}
+
+func NewStorageServer(s *Service) *StorageServer {
+	return &StorageServer{
+		sp: s,
+	}
+}
+
+func (s *StorageServer) ListAuditRecords(_ context.Context, req *storagepb.ListAuditRecordsRequest) (*storagepb.ListAuditRecordsResponse, error) {
+	userID, err := grpcsrv.ParseUUID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	period := CreatedAtFilter{}
+	if req.GetFrom() != nil {
+		period.From = req.GetFrom().AsTime()
+	}
+	if req.GetTo() != nil {
+		period.To = req.GetTo().AsTime()
+	}
+
+	if !period.From.IsZero() && !period.To.IsZero() && !period.From.Before(period.To) {
+		return nil, grpcsrv.InvalidArgument("to", "must be after from")
+	}
+
+	limit := defaultLimit
+	if req.GetLimit() > 0 {
+		limit = min(int(req.GetLimit()), maxLimit)
+	}
+
+	list, err := s.sp.GetByFilters([]Filter{
+		UserIDFilter{ID: userID},
+		period,
+		PageFilter{Limit: limit, Offset: int(req.GetOffset())},
+	})
+	if err != nil {
+		return nil, errorMapper.Error(fmt.Errorf("list audit records: %s: %w", req.GetUserId(), err))
+	}
+
+	res := &storagepb.ListAuditRecordsResponse{
+		Records:    make([]*storagepb.AuditRecord, 0, len(list.Records)),
+		TotalCount: uint64(list.TotalCount),
+	}
+	for _, item := range list.Records {
+		res.Records = append(res.Records, &storagepb.AuditRecord{
+			Id:        item.ID,
+			CreatedAt: timestamppb.New(item.CreatedAt),
+			Actor:     item.Actor,
+			UserId:    item.UserID.String(),
+			Operation: string(item.Operation),
+			Before:    string(item.Before),
+			After:     string(item.After),
+			Method:    item.Metadata.Method,
+			RequestId: item.Metadata.RequestID,
+			UserAgent: item.Metadata.UserAgent,
+			Peer:      item.Metadata.Peer,
+		})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,120 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/goverland-labs/goverland-inbox-storage/pkg/grpcsrv"
+	storagepb "github.com/goverland-labs/goverland-inbox-storage/protobuf/inboxstorage"
+)
+
+type filtersStorage struct {
+	fakeStorage
+
+	filters []Filter
+}
+
+func (f *filtersStorage) GetByFilters(filters []Filter) (RecordList, error) {
+	f.filters = filters
+
+	return f.fakeStorage.GetByFilters(filters)
+}
+
+func TestUnitListAuditRecords(t *testing.T) {
+	userID := uuid.New()
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	record := Record{
+		ID:        3,
+		CreatedAt: from.Add(time.Hour),
+		Actor:     "claimed:admin",
+		UserID:    userID,
+		Operation: OperationSetPushDetails,
+		After:     json.RawMessage(`{"enabled":true}`),
+		Metadata:  RequestMetadata{Method: "/Settings/SetPushDetails", RequestID: "req"},
+	}
+
+	for name, tc := range map[string]struct {
+		req     *storagepb.ListAuditRecordsRequest
+		filters []Filter
+		code    codes.Code
+		field   string
+	}{
+		"user in period": {
+			req: &storagepb.ListAuditRecordsRequest{
+				UserId: userID.String(),
+				From:   timestamppb.New(from),
+				To:     timestamppb.New(to),
+				Limit:  10,
+				Offset: 20,
+			},
+			filters: []Filter{
+				UserIDFilter{ID: userID},
+				CreatedAtFilter{From: from, To: to},
+				PageFilter{Limit: 10, Offset: 20},
+			},
+		},
+		"default limit without period": {
+			req: &storagepb.ListAuditRecordsRequest{UserId: userID.String()},
+			filters: []Filter{
+				UserIDFilter{ID: userID},
+				CreatedAtFilter{},
+				PageFilter{Limit: defaultLimit},
+			},
+		},
+		"max limit": {
+			req: &storagepb.ListAuditRecordsRequest{UserId: userID.String(), Limit: 10000},
+			filters: []Filter{
+				UserIDFilter{ID: userID},
+				CreatedAtFilter{},
+				PageFilter{Limit: maxLimit},
+			},
+		},
+		"invalid user": {
+			req:   &storagepb.ListAuditRecordsRequest{UserId: "wrong"},
+			code:  codes.InvalidArgument,
+			field: "user_id",
+		},
+		"reversed period": {
+			req:   &storagepb.ListAuditRecordsRequest{UserId: userID.String(), From: timestamppb.New(to), To: timestamppb.New(from)},
+			code:  codes.InvalidArgument,
+			field: "to",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			storage := &filtersStorage{fakeStorage: fakeStorage{records: []Record{record}}}
+			server := NewStorageServer(NewService(storage))
+
+			res, err := server.ListAuditRecords(context.Background(), tc.req)
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.code != codes.OK {
+				return
+			}
+
+			require.Equal(t, tc.filters, storage.filters)
+			require.EqualValues(t, 1, res.GetTotalCount())
+			require.Len(t, res.GetRecords(), 1)
+
+			item := res.GetRecords()[0]
+			require.EqualValues(t, 3, item.GetId())
+			require.Equal(t, record.CreatedAt, item.GetCreatedAt().AsTime())
+			require.Equal(t, "claimed:admin", item.GetActor())
+			require.Equal(t, userID.String(), item.GetUserId())
+			require.Equal(t, "set_push_details", item.GetOperation())
+			require.Empty(t, item.GetBefore())
+			require.Equal(t, `{"enabled":true}`, item.GetAfter())
+			require.Equal(t, "/Settings/SetPushDetails", item.GetMethod())
+			require.Equal(t, "req", item.GetRequestId())
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package autofollow
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-inbox-storage/internal/settings"
+	"github.com/goverland-labs/goverland-inbox-storage/internal/subscription"
+	"github.com/goverland-labs/goverland-inbox-storage/internal/user"
+)
+
+const usersBatchLimit = 100
+
+type SettingsProvider interface {
+	GetAutoFollowUserIDs(limit, offset int) ([]uuid.UUID, error)
+	GetAutoFollowSettings(userID uuid.UUID) (*settings.AutoFollowSettings, error)
+}
+
+type UserProvider interface {
+	GetByID(id uuid.UUID) (*user.User, error)
+}
+
+type WalletPositioner interface {
+	Ready() bool
+	GetWalletPositions(address string) ([]uuid.UUID, error)
+}
+
+type Subscriber interface {
+	GetByFilters(filters []subscription.Filter) (subscription.UserSubscriptionList, error)
+	Subscribe(ctx context.Context, info subscription.UserSubscription) (*subscription.UserSubscription, error)
+	Unsubscribe(ctx context.Context, id uuid.UUID, source subscription.Source) error
+	WasUnsubscribedByUser(userID, daoID uuid.UUID) (bool, error)
+}
+
+// Service follows daos by wallet positions for users who opted in
+type Service struct {
+	settings SettingsProvider
+	users    UserProvider
+	wallets  WalletPositioner
+	subs     Subscriber
+}
+
+func NewService(sp SettingsProvider, up UserProvider, wp WalletPositioner, s Subscriber) *Service {
+	return &Service{
+		settings: sp,
+		users:    up,
+		wallets:  wp,
+		subs:     s,
+	}
+}
+
+func (s *Service) SyncAll(ctx context.Context) error {
+	// without mapping all positions are empty, so we can unsubscribe users by mistake
+	if !s.wallets.Ready() {
+		log.Warn().Msg("wallet positions are not ready, skip auto follow sync")
+
+		return nil
+	}
+
+	offset := 0
+	for {
+		ids, err := s.settings.GetAutoFollowUserIDs(usersBatchLimit, offset)
+		if err != nil {
+			return fmt.Errorf("get auto follow users: %w", err)
+		}
+
+		for _, id := range ids {
+			if err = s.SyncUser(ctx, id); err != nil {
+				log.Error().Err(err).Str("user", id.String()).Msg("sync auto follow")
+			}
+		}
+
+		if len(ids) < usersBatchLimit {
+			return nil
+		}
+
+		offset += usersBatchLimit
+	}
+}
+
+// SyncUser subscribes the user to daos held by the wallet and optionally removes auto subscriptions
+// for daos without positions. Subscriptions made by the user are never touched.
+func (s *Service) SyncUser(ctx context.Context, userID uuid.UUID) error {
+	afs, err := s.settings.GetAutoFollowSettings(userID)
+	if err != nil {
+		return fmt.Errorf("get auto follow settings: %w", err)
+	}
+
+	if afs.Enabled == nil || !*afs.Enabled {
+		return nil
+	}
+
+	u, err := s.users.GetByID(userID)
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+
+	if !u.HasAddress() {
+		return nil
+	}
+
+	positions, err := s.wallets.GetWalletPositions(*u.Address)
+	if err != nil {
+		return fmt.Errorf("get wallet positions: %w", err)
+	}
+
+	list, err := s.subs.GetByFilters([]subscription.Filter{
+		subscription.UserIDFilter{ID: userID.String()},
+		subscription.WithoutTotalFilter{},
+	})
+	if err != nil {
+		return fmt.Errorf("get user subscriptions: %w", err)
+	}
+
+	subscribed := make(map[uuid.UUID]subscription.UserSubscription, len(list.Subscriptions))
+	for _, sub := range list.Subscriptions {
+		subscribed[sub.DaoID] = sub
+	}
+
+	for _, daoID := range positions {
+		if _, ok := subscribed[daoID]; ok {
+			continue
+		}
+
+		// respect the user choice and do not follow the dao again
+		unsubscribed, err := s.subs.WasUnsubscribedByUser(userID, daoID)
+		if err != nil {
+			return fmt.Errorf("check user unsubscribes: %s: %w", daoID, err)
+		}
+
+		if unsubscribed {
+			continue
+		}
+
+		if _, err = s.subs.Subscribe(ctx, subscription.UserSubscription{
+			UserID: userID,
+			DaoID:  daoID,
+			Source: subscription.SourceAutoFollow,
+		}); err != nil {
+			return fmt.Errorf("subscribe: %s: %w", daoID, err)
+		}
+
+		log.Info().Str("user", userID.String()).Str("dao", daoID.String()).Msg("auto followed")
+	}
+
+	if afs.AutoUnfollow == nil || !*afs.AutoUnfollow {
+		return nil
+	}
+
+	for daoID, sub := range subscribed {
+		if sub.Source != subscription.SourceAutoFollow || slices.Contains(positions, daoID) {
+			continue
+		}
+
+		if err = s.subs.Unsubscribe(ctx, sub.ID, subscription.SourceAutoFollow); err != nil {
+			return fmt.Errorf("unsubscribe: %s: %w", sub.ID, err)
+		}
+
+		log.Info().Str("user", userID.String()).Str("dao", daoID.String()).Msg("auto unfollowed")
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package autofollow
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.openly.dev/pointy"
+
+	"github.com/goverland-labs/goverland-inbox-storage/internal/settings"
+	"github.com/goverland-labs/goverland-inbox-storage/internal/subscription"
+	"github.com/goverland-labs/goverland-inbox-storage/internal/user"
+)
+
+type fakeSettings struct {
+	settings settings.AutoFollowSettings
+}
+
+func (f *fakeSettings) GetAutoFollowUserIDs(int, int) ([]uuid.UUID, error) { return nil, nil }
+
+func (f *fakeSettings) GetAutoFollowSettings(uuid.UUID) (*settings.AutoFollowSettings, error) {
+	return &f.settings, nil
+}
+
+type fakeUsers struct {
+	address *string
+}
+
+func (f *fakeUsers) GetByID(id uuid.UUID) (*user.User, error) {
+	return &user.User{ID: id, Address: f.address}, nil
+}
+
+type fakeWallets struct {
+	positions []uuid.UUID
+	err       error
+}
+
+func (f *fakeWallets) Ready() bool { return true }
+
+func (f *fakeWallets) GetWalletPositions(string) ([]uuid.UUID, error) { return f.positions, f.err }
+
+type fakeSubscriber struct {
+	subscriptions []subscription.UserSubscription
+	unsubscribed  map[uuid.UUID]bool
+
+	followed   []uuid.UUID
+	unfollowed []uuid.UUID
+}
+
+func (f *fakeSubscriber) GetByFilters([]subscription.Filter) (subscription.UserSubscriptionList, error) {
+	return subscription.UserSubscriptionList{Subscriptions: f.subscriptions}, nil
+}
+
+func (f *fakeSubscriber) Subscribe(_ context.Context, info subscription.UserSubscription) (*subscription.UserSubscription, error) {
+	if info.Source != subscription.SourceAutoFollow {
+		return nil, errors.New("unexpected source")
+	}
+
+	f.followed = append(f.followed, info.DaoID)
+
+	return &info, nil
+}
+
+func (f *fakeSubscriber) Unsubscribe(_ context.Context, id uuid.UUID, _ subscription.Source) error {
+	f.unfollowed = append(f.unfollowed, id)
+
+	return nil
+}
+
+func (f *fakeSubscriber) WasUnsubscribedByUser(_, daoID uuid.UUID) (bool, error) {
+	return f.unsubscribed[daoID], nil
+}
+
+func TestUnitSyncUser(t *testing.T) {
+	var (
+		userID     = uuid.New()
+		held       = uuid.New()
+		followed   = uuid.New()
+		rejected   = uuid.New()
+		autoSold   = uuid.New()
+		manualSold = uuid.New()
+		address    = pointy.String("0x1")
+	)
+
+	subscriptions := []subscription.UserSubscription{
+		{ID: uuid.New(), DaoID: followed, Source: subscription.SourceApp},
+		{ID: autoSold, DaoID: uuid.New(), Source: subscription.SourceAutoFollow},
+		{ID: manualSold, DaoID: uuid.New(), Source: subscription.SourceApp},
+	}
+
+	for name, tc := range map[string]struct {
+		settings   settings.AutoFollowSettings
+		address    *string
+		followed   []uuid.UUID
+		unfollowed []uuid.UUID
+	}{
+		"disabled": {
+			settings: settings.AutoFollowSettings{Enabled: pointy.Bool(false)},
+			address:  address,
+		},
+		"without address": {
+			settings: settings.AutoFollowSettings{Enabled: pointy.Bool(true)},
+		},
+		"follow only": {
+			settings: settings.AutoFollowSettings{Enabled: pointy.Bool(true)},
+			address:  address,
+			followed: []uuid.UUID{held},
+		},
+		"follow and unfollow auto subscriptions": {
+			settings:   settings.AutoFollowSettings{Enabled: pointy.Bool(true), AutoUnfollow: pointy.Bool(true)},
+			address:    address,
+			followed:   []uuid.UUID{held},
+			unfollowed: []uuid.UUID{autoSold},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			subs := &fakeSubscriber{
+				subscriptions: subscriptions,
+				unsubscribed:  map[uuid.UUID]bool{rejected: true},
+			}
+			service := NewService(
+				&fakeSettings{settings: tc.settings},
+				&fakeUsers{address: tc.address},
+				&fakeWallets{positions: []uuid.UUID{held, followed, rejected}},
+				subs,
+			)
+
+			require.NoError(t, service.SyncUser(context.Background(), userID))
+			require.Equal(t, tc.followed, subs.followed)
+			require.Equal(t, tc.unfollowed, subs.unfollowed)
+		})
+	}
+
+	t.Run("wallet positions error", func(t *testing.T) {
+		service := NewService(
+			&fakeSettings{settings: settings.AutoFollowSettings{Enabled: pointy.Bool(true)}},
+			&fakeUsers{address: address},
+			&fakeWallets{err: errors.New("unavailable")},
+			&fakeSubscriber{},
+		)
+
+		require.Error(t, service.SyncUser(context.Background(), userID))
+	})
+}
@@ -0,0 +1,10 @@
+package config
+
+import (
+	"time"
+)
+
+type Achievements struct {
+	BackfillInterval  time.Duration `env:"ACHIEVEMENTS_BACKFILL_INTERVAL" envDefault:"1m"`
+	BackfillBatchSize int           `env:"ACHIEVEMENTS_BACKFILL_BATCH_SIZE" envDefault:"500"`
+}
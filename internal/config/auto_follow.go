@@ -0,0 +1,9 @@
+package config
+
+import (
+	"time"
+)
+
+type AutoFollow struct {
+	SyncInterval time.Duration `env:"AUTO_FOLLOW_SYNC_INTERVAL" envDefault:"1h"`
+}
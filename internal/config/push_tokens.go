@@ -0,0 +1,34 @@
+package config
+
+import (
+	"time"
+)
+
+const (
+	PushTokensStorageVault    = "vault"
+	PushTokensStoragePostgres = "postgres"
+)
+
+type PushTokens struct {
+	// Storage describes where push tokens are stored: vault or postgres
+	Storage string `env:"PUSH_TOKENS_STORAGE" envDefault:"vault"`
+	// EncryptionKey is base64 encoded AES key for postgres storage, used if transit key is empty
+	EncryptionKey string `env:"PUSH_TOKENS_ENCRYPTION_KEY"`
+	TransitMount  string `env:"PUSH_TOKENS_TRANSIT_MOUNT" envDefault:"transit"`
+	TransitKey    string `env:"PUSH_TOKENS_TRANSIT_KEY"`
+	// Mirror duplicates writes from the vault storage to the postgres one
+	Mirror bool `env:"PUSH_TOKENS_MIRROR" envDefault:"false"`
+	// Migrate copies tokens from the vault storage to the postgres one on start
+	Migrate bool `env:"PUSH_TOKENS_MIGRATE" envDefault:"false"`
+	// MigrateLegacy moves tokens from the deprecated v1 path to the path by device on start
+	MigrateLegacy bool `env:"PUSH_TOKENS_MIGRATE_LEGACY" envDefault:"false"`
+	// LegacyFallback enables reading tokens by the deprecated v1 path. It's disabled automatically when
+	// the legacy migration is finished, disable it in the config after that to skip it on start
+	LegacyFallback bool `env:"PUSH_TOKENS_LEGACY_FALLBACK" envDefault:"true"`
+	// BackfillMetadata creates metadata for tokens of the configured storage stored before it was introduced
+	// on start, so they are removed by the cleanup if the app doesn't refresh them in StaleTTL after that
+	BackfillMetadata bool `env:"PUSH_TOKENS_BACKFILL_METADATA" envDefault:"false"`
+	// StaleTTL describes how long the token is kept without refreshing by the app
+	StaleTTL        time.Duration `env:"PUSH_TOKENS_STALE_TTL" envDefault:"1440h"`
+	CleanupInterval time.Duration `env:"PUSH_TOKENS_CLEANUP_INTERVAL" envDefault:"24h"`
+}
@@ -0,0 +1,6 @@
+package config
+
+type SettingsSnapshot struct {
+	// SigningKey is used for signing exported settings, export and import are disabled if it's empty
+	SigningKey string `env:"SETTINGS_SNAPSHOT_SIGNING_KEY"`
+}
@@ -2,24 +2,34 @@ package delegate
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	proto "github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
-	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/goverland-labs/goverland-inbox-storage/pkg/grpcsrv"
 )
 
+var errorMapper = grpcsrv.NewErrorMapper()
+
+type ServiceProvider interface {
+	ListAllowedDaos() ([]AllowedDao, error)
+	StoreDelegated(ctx context.Context, ud *UserDelegate) error
+	GetLastDelegation(ctx context.Context, userID uuid.UUID, daoID string) (*UserDelegate, error)
+}
+
 type Server struct {
 	proto.UnimplementedDelegateServer
 
-	sp *Service
+	sp ServiceProvider
 }
 
-func NewServer(s *Service) *Server {
+func NewServer(s ServiceProvider) *Server {
 	return &Server{
 		sp: s,
 	}
@@ -28,9 +38,7 @@ func NewServer(s *Service) *Server {
 func (s *Server) GetAllowedDaos(_ context.Context, _ *emptypb.Empty) (*proto.GetAllowedDaosResponse, error) {
 	daos, err := s.sp.ListAllowedDaos()
 	if err != nil {
-		log.Error().Err(err).Msgf("failed to list allowed daos")
-
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, errorMapper.Error(fmt.Errorf("list allowed daos: %w", err))
 	}
 
 	var response proto.GetAllowedDaosResponse
@@ -42,11 +50,9 @@ func (s *Server) GetAllowedDaos(_ context.Context, _ *emptypb.Empty) (*proto.Get
 }
 
 func (s *Server) StoreDelegated(ctx context.Context, req *proto.StoreDelegatedRequest) (*emptypb.Empty, error) {
-	userUUID, err := uuid.Parse(req.UserId)
+	userUUID, err := grpcsrv.ParseUUID("user_id", req.GetUserId())
 	if err != nil {
-		log.Error().Err(err).Msgf("failed to parse user UUID")
-
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, err
 	}
 
 	var exp time.Time
@@ -62,27 +68,21 @@ func (s *Server) StoreDelegated(ctx context.Context, req *proto.StoreDelegatedRe
 		Expiration: &exp,
 	})
 	if err != nil {
-		log.Error().Err(err).Msgf("failed to store delegated")
-
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, errorMapper.Error(fmt.Errorf("store delegated: %w", err))
 	}
 
 	return &emptypb.Empty{}, nil
 }
 
 func (s *Server) GetLastDelegation(ctx context.Context, req *proto.GetLastDelegationRequest) (*proto.GetLastDelegationResponse, error) {
-	userUUID, err := uuid.Parse(req.UserId)
+	userUUID, err := grpcsrv.ParseUUID("user_id", req.GetUserId())
 	if err != nil {
-		log.Error().Err(err).Msgf("failed to parse user UUID")
-
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, err
 	}
 
 	ud, err := s.sp.GetLastDelegation(ctx, userUUID, req.DaoId)
 	if err != nil {
-		log.Error().Err(err).Msgf("failed to get last delegation")
-
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, errorMapper.Error(fmt.Errorf("get last delegation: %w", err))
 	}
 
 	if ud == nil {
@@ -0,0 +1,212 @@
+package delegate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	proto "github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/goverland-labs/goverland-inbox-storage/pkg/grpcsrv"
+)
+
+var errBackend = errors.New("backend is down")
+
+type fakeDelegations struct {
+	daos   []AllowedDao
+	stored *UserDelegate
+	last   *UserDelegate
+	err    error
+}
+
+func (f *fakeDelegations) ListAllowedDaos() ([]AllowedDao, error) {
+	return f.daos, f.err
+}
+
+func (f *fakeDelegations) StoreDelegated(_ context.Context, ud *UserDelegate) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	f.stored = ud
+
+	return nil
+}
+
+func (f *fakeDelegations) GetLastDelegation(context.Context, uuid.UUID, string) (*UserDelegate, error) {
+	return f.last, f.err
+}
+
+func TestUnitServerGetAllowedDaos(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err      error
+		expected []string
+		code     codes.Code
+	}{
+		"daos": {
+			expected: []string{"aave.eth", "uniswap"},
+		},
+		"backend error": {
+			err:  errBackend,
+			code: codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := NewServer(&fakeDelegations{
+				daos: []AllowedDao{{DaoName: "aave.eth"}, {DaoName: "uniswap"}},
+				err:  tc.err,
+			})
+
+			res, err := server.GetAllowedDaos(context.Background(), &emptypb.Empty{})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.expected, res.GetDaosNames())
+		})
+	}
+}
+
+func TestUnitServerStoreDelegated(t *testing.T) {
+	userID := uuid.New()
+	expiration := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		userID string
+		err    error
+		code   codes.Code
+		field  string
+	}{
+		"stored": {
+			userID: userID.String(),
+		},
+		"invalid user id": {
+			userID: "wrong",
+			code:   codes.InvalidArgument,
+			field:  "user_id",
+		},
+		"backend error": {
+			userID: userID.String(),
+			err:    errBackend,
+			code:   codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sp := &fakeDelegations{err: tc.err}
+
+			_, err := NewServer(sp).StoreDelegated(context.Background(), &proto.StoreDelegatedRequest{
+				UserId:     tc.userID,
+				DaoId:      "dao",
+				TxHash:     "0x1",
+				Delegates:  `[{"address":"0x2","weight":100}]`,
+				Expiration: timestamppb.New(expiration),
+			})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.code != codes.OK {
+				require.Nil(t, sp.stored)
+
+				return
+			}
+
+			require.Equal(t, &UserDelegate{
+				UserID:     userID,
+				DaoID:      "dao",
+				TxHash:     "0x1",
+				Delegates:  `[{"address":"0x2","weight":100}]`,
+				Expiration: &expiration,
+			}, sp.stored)
+		})
+	}
+}
+
+func TestUnitServerGetLastDelegation(t *testing.T) {
+	userID := uuid.New()
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	expiration := createdAt.AddDate(0, 1, 0)
+
+	for name, tc := range map[string]struct {
+		userID   string
+		last     *UserDelegate
+		err      error
+		expected *proto.GetLastDelegationResponse
+		code     codes.Code
+		reason   string
+		field    string
+	}{
+		"delegation": {
+			userID: userID.String(),
+			last: &UserDelegate{
+				CreatedAt:  createdAt,
+				UserID:     userID,
+				DaoID:      "dao",
+				TxHash:     "0x1",
+				Delegates:  "[]",
+				Expiration: &expiration,
+			},
+			expected: &proto.GetLastDelegationResponse{
+				UserId:     userID.String(),
+				CreatedAt:  timestamppb.New(createdAt),
+				DaoId:      "dao",
+				TxHash:     "0x1",
+				Delegates:  "[]",
+				Expiration: timestamppb.New(expiration),
+			},
+		},
+		"without expiration": {
+			userID: userID.String(),
+			last:   &UserDelegate{CreatedAt: createdAt, UserID: userID, DaoID: "dao"},
+			expected: &proto.GetLastDelegationResponse{
+				UserId:    userID.String(),
+				CreatedAt: timestamppb.New(createdAt),
+				DaoId:     "dao",
+			},
+		},
+		"invalid user id": {
+			userID: "wrong",
+			code:   codes.InvalidArgument,
+			field:  "user_id",
+		},
+		"not found": {
+			userID: userID.String(),
+			code:   codes.NotFound,
+		},
+		"backend error": {
+			userID: userID.String(),
+			err:    errBackend,
+			code:   codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := NewServer(&fakeDelegations{last: tc.last, err: tc.err})
+
+			res, err := server.GetLastDelegation(context.Background(), &proto.GetLastDelegationRequest{
+				UserId: tc.userID,
+				DaoId:  "dao",
+			})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.reason, grpcsrv.ErrorReason(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.expected == nil {
+				return
+			}
+
+			require.Equal(t, tc.expected.GetUserId(), res.GetUserId())
+			require.Equal(t, tc.expected.GetCreatedAt().AsTime(), res.GetCreatedAt().AsTime())
+			require.Equal(t, tc.expected.GetDaoId(), res.GetDaoId())
+			require.Equal(t, tc.expected.GetTxHash(), res.GetTxHash())
+			require.Equal(t, tc.expected.GetDelegates(), res.GetDelegates())
+			require.Equal(t, tc.expected.GetExpiration() == nil, res.GetExpiration() == nil)
+			if tc.expected.GetExpiration() != nil {
+				require.Equal(t, tc.expected.GetExpiration().AsTime(), res.GetExpiration().AsTime())
+			}
+		})
+	}
+}
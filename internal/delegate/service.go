@@ -2,19 +2,29 @@ package delegate
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/goverland-labs/goverland-inbox-storage/internal/achievements/recalc"
 )
 
+type Publisher interface {
+	PublishJSON(ctx context.Context, subject string, obj any) error
+}
+
 type Service struct {
-	adRepo *AllowedDaoRepo
-	udRepo *UserDelegatedRepo
+	adRepo    *AllowedDaoRepo
+	udRepo    *UserDelegatedRepo
+	publisher Publisher
 }
 
-func NewService(adRepo *AllowedDaoRepo, udRepo *UserDelegatedRepo) *Service {
+func NewService(adRepo *AllowedDaoRepo, udRepo *UserDelegatedRepo, pb Publisher) *Service {
 	return &Service{
-		adRepo: adRepo,
-		udRepo: udRepo,
+		adRepo:    adRepo,
+		udRepo:    udRepo,
+		publisher: pb,
 	}
 }
 
@@ -22,8 +32,24 @@ func (s *Service) ListAllowedDaos() ([]AllowedDao, error) {
 	return s.adRepo.List()
 }
 
-func (s *Service) StoreDelegated(_ context.Context, ud *UserDelegate) error {
-	return s.udRepo.Create(ud)
+func (s *Service) StoreDelegated(ctx context.Context, ud *UserDelegate) error {
+	if err := s.udRepo.Create(ud); err != nil {
+		return err
+	}
+
+	recalc.Request(ctx, s.publisher, ud.UserID, recalc.TypeDelegation)
+
+	return nil
+}
+
+// CountDelegations returns the number of delegations made by the user in all daos in the optional period
+func (s *Service) CountDelegations(userID uuid.UUID, from, to *time.Time) (int, error) {
+	count, err := s.udRepo.CountByUser(userID, from, to)
+	if err != nil {
+		return 0, fmt.Errorf("count delegations: %w", err)
+	}
+
+	return int(count), nil
 }
 
 func (s *Service) GetLastDelegation(_ context.Context, userID uuid.UUID, daoID string) (*UserDelegate, error) {
@@ -32,6 +32,24 @@ func (r *UserDelegatedRepo) Create(userDelegated *UserDelegate) error {
 	return r.db.Create(userDelegated).Error
 }
 
+// CountByUser counts delegations made in the optional period
+func (r *UserDelegatedRepo) CountByUser(userID uuid.UUID, from, to *time.Time) (int64, error) {
+	query := r.db.
+		Model(&UserDelegate{}).
+		Where("user_id = ?", userID)
+	if from != nil {
+		query = query.Where("created_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("created_at < ?", *to)
+	}
+
+	var count int64
+	err := query.Count(&count).Error
+
+	return count, err
+}
+
 func (r *UserDelegatedRepo) GetLast(userID uuid.UUID, daoID string) (*UserDelegate, error) {
 	var userDelegated UserDelegate
 	err := r.db.
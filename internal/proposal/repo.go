@@ -59,6 +59,26 @@ func (r *Repo) GetCurrentAIRequestsCount(userID string, address string) (int64,
 	return count, nil
 }
 
+// CountAIRequestsByUser counts requests created in the optional period
+func (r *Repo) CountAIRequestsByUser(userID string, from, to *time.Time) (int64, error) {
+	query := r.db.
+		Model(&AIRequest{}).
+		Where("user_id = ?", userID)
+	if from != nil {
+		query = query.Where("created_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("created_at < ?", *to)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func beginningOfMonth(now time.Time) time.Time {
 	y, m, _ := now.Date()
 	return time.Date(y, m, 1, 0, 0, 0, 0, now.Location())
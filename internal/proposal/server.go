@@ -2,24 +2,47 @@ package proposal
 
 import (
 	"context"
-	"errors"
+	"fmt"
 
-	"github.com/google/uuid"
 	proto "github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
-	"github.com/rs/zerolog/log"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
 	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/goverland-labs/goverland-inbox-storage/pkg/grpcsrv"
+)
+
+var errorMapper = grpcsrv.NewErrorMapper(
+	grpcsrv.ErrorRule{Err: ErrUserInvalidState, Code: codes.FailedPrecondition, Reason: "USER_INVALID_STATE"},
+	grpcsrv.ErrorRule{
+		Err:    ErrRequestLimitExceeded,
+		Code:   codes.ResourceExhausted,
+		Reason: "AI_SUMMARY_LIMIT_EXCEEDED",
+		Details: func(error) []protoadapt.MessageV1 {
+			return []protoadapt.MessageV1{&errdetails.QuotaFailure{
+				Violations: []*errdetails.QuotaFailure_Violation{
+					{
+						Description: "You've reached your AI summarization limit for this month",
+					},
+				},
+			}}
+		},
+	},
 )
 
+type ServiceProvider interface {
+	GetActualFeaturedProposals(ctx context.Context) ([]Featured, error)
+	GetAISummary(ctx context.Context, req AISummaryRequest) (string, error)
+}
+
 type Server struct {
 	proto.UnimplementedProposalServer
 
-	service *Service
+	service ServiceProvider
 }
 
-func NewServer(s *Service) *Server {
+func NewServer(s ServiceProvider) *Server {
 	return &Server{
 		service: s,
 	}
@@ -28,9 +51,7 @@ func NewServer(s *Service) *Server {
 func (s *Server) GetFeaturedProposals(ctx context.Context, _ *emptypb.Empty) (*proto.GetFeaturedProposalsResponse, error) {
 	proposals, err := s.service.GetActualFeaturedProposals(ctx)
 	if err != nil {
-		log.Error().Err(err).Msg("failed to get featured proposals")
-
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, errorMapper.Error(fmt.Errorf("get featured proposals: %w", err))
 	}
 
 	ids := make([]string, 0, len(proposals))
@@ -44,17 +65,13 @@ func (s *Server) GetFeaturedProposals(ctx context.Context, _ *emptypb.Empty) (*p
 }
 
 func (s *Server) GetAISummary(_ context.Context, req *proto.GetAISummaryRequest) (*proto.GetAISummaryResponse, error) {
-	if req.GetUserId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "missing user id")
-	}
-
 	if req.GetProposalId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "missing proposal id")
+		return nil, grpcsrv.InvalidArgument("proposal_id", "must not be empty")
 	}
 
-	userID, err := uuid.Parse(req.GetUserId())
+	userID, err := grpcsrv.ParseUUID("user_id", req.GetUserId())
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+		return nil, err
 	}
 
 	// skip parent context due to aborting requests to the external API
@@ -62,26 +79,8 @@ func (s *Server) GetAISummary(_ context.Context, req *proto.GetAISummaryRequest)
 		UserID:     userID,
 		ProposalID: req.GetProposalId(),
 	})
-
-	if errors.Is(err, ErrRequestLimitExceeded) {
-		serr := status.New(codes.ResourceExhausted, err.Error())
-		serr, _ = serr.WithDetails(&errdetails.QuotaFailure{
-			Violations: []*errdetails.QuotaFailure_Violation{
-				{
-					Description: "You've reached your AI summarization limit for this month",
-				},
-			},
-		})
-
-		return nil, serr.Err()
-	}
-
-	if errors.Is(err, ErrUserInvalidState) {
-		return nil, status.Error(codes.FailedPrecondition, err.Error())
-	}
-
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, errorMapper.Error(fmt.Errorf("get ai summary: %w", err))
 	}
 
 	return &proto.GetAISummaryResponse{Summary: summary}, nil
@@ -0,0 +1,95 @@
+package proposal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	proto "github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var errBackend = errors.New("backend is down")
+
+type fakeService struct {
+	err error
+}
+
+func (f *fakeService) GetActualFeaturedProposals(context.Context) ([]Featured, error) {
+	return []Featured{{ProposalID: "proposal"}}, f.err
+}
+
+func (f *fakeService) GetAISummary(context.Context, AISummaryRequest) (string, error) {
+	return "summary", f.err
+}
+
+func TestUnitServerGetFeaturedProposals(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{name: "ok", code: codes.OK},
+		{name: "backend error", err: errBackend, code: codes.Internal},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := NewServer(&fakeService{err: tc.err}).GetFeaturedProposals(context.Background(), &emptypb.Empty{})
+
+			require.Equal(t, tc.code, status.Code(err), err)
+			if tc.code == codes.OK {
+				require.Equal(t, []string{"proposal"}, resp.GetProposalIds())
+			}
+		})
+	}
+}
+
+func TestUnitServerGetAISummary(t *testing.T) {
+	userID := uuid.NewString()
+
+	for _, tc := range []struct {
+		name       string
+		userID     string
+		proposalID string
+		err        error
+		code       codes.Code
+	}{
+		{name: "ok", userID: userID, proposalID: "proposal", code: codes.OK},
+		{name: "empty proposal id", userID: userID, code: codes.InvalidArgument},
+		{name: "invalid user id", userID: "wrong", proposalID: "proposal", code: codes.InvalidArgument},
+		{name: "invalid user state", userID: userID, proposalID: "proposal", err: ErrUserInvalidState, code: codes.FailedPrecondition},
+		{name: "limit exceeded", userID: userID, proposalID: "proposal", err: fmt.Errorf("check limit: %w", ErrRequestLimitExceeded), code: codes.ResourceExhausted},
+		{name: "backend error", userID: userID, proposalID: "proposal", err: errBackend, code: codes.Internal},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewServer(&fakeService{err: tc.err}).GetAISummary(context.Background(), &proto.GetAISummaryRequest{
+				UserId:     tc.userID,
+				ProposalId: tc.proposalID,
+			})
+
+			require.Equal(t, tc.code, status.Code(err), err)
+		})
+	}
+
+	t.Run("limit exceeded details", func(t *testing.T) {
+		_, err := NewServer(&fakeService{err: ErrRequestLimitExceeded}).GetAISummary(context.Background(), &proto.GetAISummaryRequest{
+			UserId:     userID,
+			ProposalId: "proposal",
+		})
+
+		var quota *errdetails.QuotaFailure
+		for _, detail := range status.Convert(err).Details() {
+			if q, ok := detail.(*errdetails.QuotaFailure); ok {
+				quota = q
+			}
+		}
+		require.NotNil(t, quota)
+		require.Len(t, quota.GetViolations(), 1)
+		require.Equal(t, "You've reached your AI summarization limit for this month", quota.GetViolations()[0].GetDescription())
+	})
+}
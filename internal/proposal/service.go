@@ -11,6 +11,7 @@ import (
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
 
+	"github.com/goverland-labs/goverland-inbox-storage/internal/achievements/recalc"
 	"github.com/goverland-labs/goverland-inbox-storage/internal/user"
 )
 
@@ -27,10 +28,15 @@ type UserProvider interface {
 	GetByID(uuid uuid.UUID) (*user.User, error)
 }
 
+type Publisher interface {
+	PublishJSON(ctx context.Context, subject string, obj any) error
+}
+
 type Service struct {
-	repo *Repo
-	up   UserProvider
-	dp   DataProvider
+	repo      *Repo
+	up        UserProvider
+	dp        DataProvider
+	publisher Publisher
 
 	// aiMonthlyRequestLimit describe the number of request per user
 	aiMonthlyRequestLimit int64
@@ -44,11 +50,13 @@ func NewService(
 	dp DataProvider,
 	aiProvider *AIClient,
 	aiMonthlyRequestLimit int64,
+	pb Publisher,
 ) *Service {
 	return &Service{
 		repo:                  featuredRepo,
 		up:                    up,
 		dp:                    dp,
+		publisher:             pb,
 		aiProvider:            aiProvider,
 		aiMonthlyRequestLimit: aiMonthlyRequestLimit,
 	}
@@ -100,11 +108,25 @@ func (s *Service) GetAISummary(ctx context.Context, req AISummaryRequest) (strin
 	})
 	if err != nil {
 		log.Err(err).Msg("create AI request row")
+
+		return summary, nil
 	}
 
+	recalc.Request(ctx, s.publisher, u.ID, recalc.TypeAISummary)
+
 	return summary, nil
 }
 
+// CountAIRequests returns the number of AI summaries requested by the user in the optional period
+func (s *Service) CountAIRequests(userID uuid.UUID, from, to *time.Time) (int, error) {
+	count, err := s.repo.CountAIRequestsByUser(userID.String(), from, to)
+	if err != nil {
+		return 0, fmt.Errorf("count ai requests: %w", err)
+	}
+
+	return int(count), nil
+}
+
 func (s *Service) getAiSummary(ctx context.Context, proposalID string) (string, error) {
 	sum, err := s.repo.GetSummary(proposalID)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
@@ -0,0 +1,98 @@
+package settings
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-platform-events/events"
+	client "github.com/goverland-labs/goverland-platform-events/pkg/natsclient"
+
+	"github.com/goverland-labs/goverland-inbox-storage/internal/config"
+)
+
+const (
+	groupName                = "settings"
+	maxPendingAckPerConsumer = 10
+
+	// SubjectPushTokenInvalidated is published by the push sender when the provider rejects the token
+	SubjectPushTokenInvalidated = "inbox.push.token.invalidated"
+)
+
+type PushTokenInvalidatedPayload struct {
+	UserID     string `json:"user_id"`
+	DeviceUUID string `json:"device_uuid"`
+	// Token is the rejected token, used to skip invalidation if the device has already registered a new one
+	Token  string `json:"token"`
+	Reason string `json:"reason"`
+}
+
+type PushTokenInvalidatedHandler = events.Handler[PushTokenInvalidatedPayload]
+
+type closable interface {
+	Close() error
+}
+
+type Consumer struct {
+	conn      *nats.Conn
+	service   *Service
+	consumers []closable
+}
+
+func NewConsumer(nc *nats.Conn, s *Service) (*Consumer, error) {
+	c := &Consumer{
+		conn:      nc,
+		service:   s,
+		consumers: make([]closable, 0),
+	}
+
+	return c, nil
+}
+
+func (c *Consumer) tokenInvalidated() PushTokenInvalidatedHandler {
+	return func(payload PushTokenInvalidatedPayload) error {
+		if payload.UserID == "" || payload.DeviceUUID == "" {
+			log.Warn().
+				Str("user_id", payload.UserID).
+				Str("device_uuid", payload.DeviceUUID).
+				Msg("skip invalid push token invalidation")
+
+			return nil
+		}
+
+		if err := c.service.InvalidateToken(payload.UserID, payload.DeviceUUID, payload.Token, payload.Reason); err != nil {
+			log.Error().Err(err).Msg("process event")
+
+			return err
+		}
+
+		return nil
+	}
+}
+
+func (c *Consumer) Start(ctx context.Context) error {
+	group := config.GenerateGroupName(groupName)
+	ic, err := client.NewConsumer(ctx, c.conn, group, SubjectPushTokenInvalidated, c.tokenInvalidated(), client.WithMaxAckPending(maxPendingAckPerConsumer))
+	if err != nil {
+		return fmt.Errorf("consume for %s/%s: %w", group, SubjectPushTokenInvalidated, err)
+	}
+
+	c.consumers = append(c.consumers, ic)
+
+	log.Info().Msg("settings consumers are started")
+
+	<-ctx.Done()
+	return c.stop()
+}
+
+func (c *Consumer) stop() error {
+	for _, cs := range c.consumers {
+		if err := cs.Close(); err != nil {
+			log.Error().Err(err).Msg("close settings consumer")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+package settings
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+const (
+	SubjectPushSettingsUpdated         = "inbox.settings.push.updated"
+	SubjectAutoFollowSettingsUpdated   = "inbox.settings.auto_follow.updated"
+	SubjectAchievementsSettingsUpdated = "inbox.settings.achievements.updated"
+)
+
+// SettingsUpdatedPayload is published on each settings change. Settings contain the full effective value,
+// Version is increased on each change of the user settings by type, so consumers can skip outdated events.
+type SettingsUpdatedPayload struct {
+	UserID   uuid.UUID       `json:"user_id"`
+	Type     DetailsType     `json:"type"`
+	Version  int64           `json:"version"`
+	Settings json.RawMessage `json:"settings"`
+}
@@ -0,0 +1,183 @@
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/goverland-labs/goverland-platform-events/events/inbox"
+	"go.openly.dev/pointy"
+)
+
+const (
+	defaultAutoarchiveDuration = "1d"
+	minAutoarchiveDays         = 1
+	maxAutoarchiveDays         = 90
+
+	maxMutedDaos    = 100
+	maxMuteDuration = 365 * 24 * time.Hour
+)
+
+// legacyAutoarchiveDurations were applied by the feed before the validation, other values were treated as 1 day
+var legacyAutoarchiveDurations = []string{"1d", "3d", "7d", "30d"}
+
+var (
+	ErrInvalidAutoarchiveDuration = errors.New("invalid autoarchive duration")
+	ErrInvalidDigestMode          = errors.New("invalid digest mode")
+	ErrInvalidMute                = errors.New("invalid mute")
+)
+
+// FeedSettingsUpdatedPayload extends the platform payload with options which are not there yet,
+// so current consumers keep working with the same subject.
+type FeedSettingsUpdatedPayload struct {
+	inbox.FeedSettingsPayload
+
+	Version                  int64      `json:"version"`
+	ArchiveProposalAfterVote bool       `json:"archive_proposal_after_vote"`
+	HideUnverifiedDaos       bool       `json:"hide_unverified_daos"`
+	DigestMode               DigestMode `json:"digest_mode"`
+	MutedDaos                []MutedDao `json:"muted_daos"`
+}
+
+// parseAutoarchiveDuration converts the duration in days like "7d" to the number of days
+func parseAutoarchiveDuration(value string) (int, error) {
+	days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+	if err != nil || !strings.HasSuffix(value, "d") {
+		return 0, fmt.Errorf("%w: %q, expected format is <days>d", ErrInvalidAutoarchiveDuration, value)
+	}
+
+	if days < minAutoarchiveDays || days > maxAutoarchiveDays {
+		return 0, fmt.Errorf("%w: %q, allowed from %dd to %dd", ErrInvalidAutoarchiveDuration, value, minAutoarchiveDays, maxAutoarchiveDays)
+	}
+
+	return days, nil
+}
+
+func validateFeedSettings(fs *FeedSettings) error {
+	if _, err := parseAutoarchiveDuration(pointy.StringValue(fs.AutoarchiveAfterDuration, defaultAutoarchiveDuration)); err != nil {
+		return err
+	}
+
+	if fs.DigestMode != nil {
+		switch *fs.DigestMode {
+		case DigestModeOff, DigestModeDaily, DigestModeWeekly:
+		default:
+			return fmt.Errorf("%w: %q", ErrInvalidDigestMode, *fs.DigestMode)
+		}
+	}
+
+	if len(fs.MutedDaos) > maxMutedDaos {
+		return fmt.Errorf("%w: too many muted daos, max %d", ErrInvalidMute, maxMutedDaos)
+	}
+
+	for _, item := range fs.MutedDaos {
+		if item.DaoID == uuid.Nil {
+			return fmt.Errorf("%w: empty dao id", ErrInvalidMute)
+		}
+	}
+
+	return nil
+}
+
+// upgradeFeedSettingsV1 replaces autoarchive durations which were accepted before the validation
+// with the value that was actually applied by the feed. Values like "14d" are valid now, but the feed
+// archived them after 1 day, so they are replaced too.
+func upgradeFeedSettingsV1(raw json.RawMessage) (json.RawMessage, error) {
+	var fs FeedSettings
+	if err := json.Unmarshal(raw, &fs); err != nil {
+		return nil, err
+	}
+
+	if fs.AutoarchiveAfterDuration != nil && !slices.Contains(legacyAutoarchiveDurations, *fs.AutoarchiveAfterDuration) {
+		fs.AutoarchiveAfterDuration = pointy.String(defaultAutoarchiveDuration)
+	}
+
+	return json.Marshal(fs)
+}
+
+// activeMutes drops expired mutes
+func activeMutes(list []MutedDao, now time.Time) []MutedDao {
+	result := make([]MutedDao, 0, len(list))
+	for _, item := range list {
+		if item.Until.After(now) {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+// MuteDao hides proposals of the dao from the feed until provided time
+func (s *Service) MuteDao(userID, daoID uuid.UUID, until time.Time) error {
+	now := time.Now()
+	if !until.After(now) || until.Sub(now) > maxMuteDuration {
+		return fmt.Errorf("%w: %w: mute period must be in the future and not longer than %s", ErrInvalidSettings, ErrInvalidMute, maxMuteDuration)
+	}
+
+	return s.updateMutes(userID, func(list []MutedDao) []MutedDao {
+		for i := range list {
+			if list[i].DaoID == daoID {
+				list[i].Until = until
+
+				return list
+			}
+		}
+
+		return append(list, MutedDao{DaoID: daoID, Until: until})
+	})
+}
+
+func (s *Service) UnmuteDao(userID, daoID uuid.UUID) error {
+	return s.updateMutes(userID, func(list []MutedDao) []MutedDao {
+		result := make([]MutedDao, 0, len(list))
+		for _, item := range list {
+			if item.DaoID != daoID {
+				result = append(result, item)
+			}
+		}
+
+		return result
+	})
+}
+
+func (s *Service) updateMutes(userID uuid.UUID, fn func([]MutedDao) []MutedDao) error {
+	fs, err := getDetails(s, feedSchema, userID)
+	if err != nil {
+		return err
+	}
+
+	fs.MutedDaos = fn(activeMutes(fs.MutedDaos, time.Now()))
+
+	return replaceDetails(s, feedSchema, userID, fs)
+}
+
+// feedSettingsPayload builds the published event, the stored value is validated, so invalid duration is
+// returned as an error instead of publishing the value which differs from the stored one
+func feedSettingsPayload(userID uuid.UUID, fs *FeedSettings, version int64) (any, error) {
+	days, err := parseAutoarchiveDuration(pointy.StringValue(fs.AutoarchiveAfterDuration, defaultAutoarchiveDuration))
+	if err != nil {
+		return nil, err
+	}
+
+	payload := FeedSettingsUpdatedPayload{
+		FeedSettingsPayload: inbox.FeedSettingsPayload{
+			SubscriberID:         userID,
+			AutoarchiveAfterDays: days,
+		},
+		Version:                  version,
+		ArchiveProposalAfterVote: pointy.BoolValue(fs.ArchiveProposalAfterVote, true),
+		HideUnverifiedDaos:       pointy.BoolValue(fs.HideUnverifiedDaos, false),
+		DigestMode:               DigestModeOff,
+		MutedDaos:                activeMutes(fs.MutedDaos, time.Now()),
+	}
+	if fs.DigestMode != nil {
+		payload.DigestMode = *fs.DigestMode
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,70 @@
+package settings
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.openly.dev/pointy"
+)
+
+func TestUnitParseAutoarchiveDuration(t *testing.T) {
+	for _, tc := range []struct {
+		value string
+		days  int
+		valid bool
+	}{
+		{value: "1d", days: 1, valid: true},
+		{value: "14d", days: 14, valid: true},
+		{value: "90d", days: 90, valid: true},
+		{value: "0d"},
+		{value: "91d"},
+		{value: "7"},
+		{value: "1w"},
+		{value: ""},
+	} {
+		t.Run(tc.value, func(t *testing.T) {
+			days, err := parseAutoarchiveDuration(tc.value)
+			if !tc.valid {
+				require.ErrorIs(t, err, ErrInvalidAutoarchiveDuration)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.days, days)
+		})
+	}
+}
+
+func TestUnitFeedSettingsUpgrade(t *testing.T) {
+	for stored, expected := range map[string]string{
+		"unknown": defaultAutoarchiveDuration,
+		"14d":     defaultAutoarchiveDuration,
+		"2d":      defaultAutoarchiveDuration,
+		"3d":      "3d",
+		"30d":     "30d",
+	} {
+		t.Run(stored, func(t *testing.T) {
+			raw, err := json.Marshal(FeedSettings{AutoarchiveAfterDuration: &stored})
+			require.NoError(t, err)
+
+			fs, err := feedSchema.decode(raw, 1, false)
+			require.NoError(t, err)
+			require.Equal(t, expected, *fs.AutoarchiveAfterDuration)
+			require.Equal(t, DigestModeOff, *fs.DigestMode)
+		})
+	}
+}
+
+func TestUnitFeedSettingsPayload(t *testing.T) {
+	userID := uuid.New()
+
+	payload, err := feedSettingsPayload(userID, &FeedSettings{AutoarchiveAfterDuration: pointy.String("14d")}, 2)
+	require.NoError(t, err)
+	require.Equal(t, 14, payload.(FeedSettingsUpdatedPayload).AutoarchiveAfterDays)
+
+	_, err = feedSettingsPayload(userID, &FeedSettings{AutoarchiveAfterDuration: pointy.String("unknown")}, 2)
+	require.ErrorIs(t, err, ErrInvalidAutoarchiveDuration)
+}
@@ -0,0 +1,112 @@
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OutboxMessage is the event stored in the same transaction with the settings change or the achievement unlock
+// and published later
+type OutboxMessage struct {
+	ID          uint64 `gorm:"primaryKey"`
+	CreatedAt   time.Time
+	Subject     string
+	Payload     json.RawMessage `gorm:"type:jsonb;serializer:json"`
+	PublishedAt *time.Time
+}
+
+func (OutboxMessage) TableName() string {
+	return "settings_outbox"
+}
+
+// NewOutboxMessage builds the message to be stored by the transaction of the change
+func NewOutboxMessage(subject string, payload any) (*OutboxMessage, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshal %s payload: %w", subject, err)
+	}
+
+	return &OutboxMessage{
+		CreatedAt: time.Now(),
+		Subject:   subject,
+		Payload:   raw,
+	}, nil
+}
+
+type OutboxRepo struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepo(db *gorm.DB) *OutboxRepo {
+	return &OutboxRepo{db: db}
+}
+
+// PublishPending locks the batch of not published messages in creation order and marks them published
+// if the publish func succeeds. Locked rows are skipped, so several instances can publish concurrently.
+func (r *OutboxRepo) PublishPending(limit int, publish func(msg OutboxMessage) error) (int, error) {
+	var (
+		published  int
+		publishErr error
+	)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var list []OutboxMessage
+		err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("published_at is null").
+			Order("id").
+			Limit(limit).
+			Find(&list).
+			Error
+		if err != nil {
+			return fmt.Errorf("get pending messages: %w", err)
+		}
+
+		ids := make([]uint64, 0, len(list))
+		for _, msg := range list {
+			if publishErr = publish(msg); publishErr != nil {
+				// keep order of messages, the rest will be published on the next run
+				break
+			}
+
+			ids = append(ids, msg.ID)
+		}
+
+		if len(ids) == 0 {
+			return nil
+		}
+
+		err = tx.
+			Model(&OutboxMessage{}).
+			Where("id in ?", ids).
+			Update("published_at", time.Now()).
+			Error
+		if err != nil {
+			return fmt.Errorf("mark published: %w", err)
+		}
+
+		published = len(ids)
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if publishErr != nil {
+		return published, fmt.Errorf("publish message: %w", publishErr)
+	}
+
+	return published, nil
+}
+
+// DeletePublished removes messages published before provided date
+func (r *OutboxRepo) DeletePublished(before time.Time) error {
+	return r.db.
+		Where("published_at < ?", before).
+		Delete(&OutboxMessage{}).
+		Error
+}
@@ -0,0 +1,63 @@
+package settings
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	outboxBatchSize       = 100
+	outboxPollInterval    = time.Second
+	outboxCleanupInterval = time.Hour
+	outboxRetention       = 7 * 24 * time.Hour
+)
+
+type Publisher interface {
+	PublishJSON(ctx context.Context, subject string, obj any) error
+}
+
+// OutboxWorker publishes settings and achievement unlock events stored by the transactional outbox
+type OutboxWorker struct {
+	repo      *OutboxRepo
+	publisher Publisher
+}
+
+func NewOutboxWorker(repo *OutboxRepo, publisher Publisher) *OutboxWorker {
+	return &OutboxWorker{
+		repo:      repo,
+		publisher: publisher,
+	}
+}
+
+func (w *OutboxWorker) Start(ctx context.Context) error {
+	lastCleanup := time.Time{}
+	for {
+		published, err := w.repo.PublishPending(outboxBatchSize, func(msg OutboxMessage) error {
+			return w.publisher.PublishJSON(ctx, msg.Subject, msg.Payload)
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("publish settings outbox")
+		}
+
+		if time.Since(lastCleanup) > outboxCleanupInterval {
+			if err = w.repo.DeletePublished(time.Now().Add(-outboxRetention)); err != nil {
+				log.Error().Err(err).Msg("cleanup settings outbox")
+			}
+
+			lastCleanup = time.Now()
+		}
+
+		// continue without waiting while there are pending messages
+		if published == outboxBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(outboxPollInterval):
+		}
+	}
+}
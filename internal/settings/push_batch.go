@@ -0,0 +1,201 @@
+package settings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/goverland-labs/goverland-inbox-storage/internal/subscription"
+)
+
+// MaxPushTokensUsers limits the number of users requested in one call
+const MaxPushTokensUsers = 1000
+
+var ErrTooManyUsers = errors.New("too many users requested")
+
+type PushTokensRequest struct {
+	UserIDs []uuid.UUID
+	// DaoID is used for applying push settings overrides from the dao subscription, optional
+	DaoID uuid.UUID
+	// EventType filters out users who disabled pushes by the type, optional
+	EventType PushEventType
+}
+
+type UserPushTokens struct {
+	UserID uuid.UUID
+	Tokens []PushDetails
+}
+
+// GetPushTokensForUsers returns tokens grouped by user. Users without tokens or with disabled
+// pushes by requested event type are skipped.
+func (s *Service) GetPushTokensForUsers(req PushTokensRequest) ([]UserPushTokens, error) {
+	if len(req.UserIDs) > MaxPushTokensUsers {
+		return nil, fmt.Errorf("%w: %d, max %d", ErrTooManyUsers, len(req.UserIDs), MaxPushTokensUsers)
+	}
+
+	if req.EventType != "" {
+		if _, err := pushSchema.Defaults().Enabled(req.EventType); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(req.UserIDs) == 0 {
+		return nil, nil
+	}
+
+	userIDs := req.UserIDs
+	if req.EventType != "" {
+		allowed, err := s.filterByPushSettings(req)
+		if err != nil {
+			return nil, fmt.Errorf("filter by push settings: %w", err)
+		}
+
+		userIDs = allowed
+	}
+
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		ids = append(ids, id.String())
+	}
+
+	tokens, err := s.getTokensByUsers(ids)
+	if err != nil {
+		return nil, fmt.Errorf("get tokens: %w", err)
+	}
+
+	metadata, err := s.metadata.GetByUsers(ids)
+	if err != nil {
+		return nil, fmt.Errorf("get token metadata: %w", err)
+	}
+
+	type metadataKey struct{ userID, deviceUUID string }
+	byDevice := make(map[metadataKey]PushTokenMetadata, len(metadata))
+	for _, info := range metadata {
+		byDevice[metadataKey{info.UserID, info.DeviceUUID}] = info
+	}
+
+	result := make([]UserPushTokens, 0, len(tokens))
+	for idx, id := range ids {
+		list := tokens[id]
+		if len(list) == 0 {
+			continue
+		}
+
+		for i := range list {
+			if info, ok := byDevice[metadataKey{id, list[i].DeviceUUID}]; ok {
+				list[i].Metadata = &info
+			}
+		}
+
+		result = append(result, UserPushTokens{
+			UserID: userIDs[idx],
+			Tokens: list,
+		})
+	}
+
+	return result, nil
+}
+
+// StreamPushTokensForUsers works like GetPushTokensForUsers without the users limit, calling fn per chunk
+func (s *Service) StreamPushTokensForUsers(ctx context.Context, req PushTokensRequest, fn func([]UserPushTokens) error) error {
+	for start := 0; start < len(req.UserIDs); start += MaxPushTokensUsers {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		end := min(start+MaxPushTokensUsers, len(req.UserIDs))
+		chunk := req
+		chunk.UserIDs = req.UserIDs[start:end]
+
+		list, err := s.GetPushTokensForUsers(chunk)
+		if err != nil {
+			return err
+		}
+
+		if len(list) == 0 {
+			continue
+		}
+
+		if err = fn(list); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) filterByPushSettings(req PushTokensRequest) ([]uuid.UUID, error) {
+	details, err := s.details.GetByUsersAndType(req.UserIDs, DetailsTypePushConfig)
+	if err != nil {
+		return nil, fmt.Errorf("get push details: %w", err)
+	}
+
+	settings := make(map[uuid.UUID]*PushSettingsDetails, len(details))
+	for _, info := range details {
+		psd, err := pushSchema.decode(info.Value, info.Version, false)
+		if err != nil {
+			return nil, fmt.Errorf("decode push details: %s: %w", info.UserID, err)
+		}
+
+		settings[info.UserID] = psd
+	}
+
+	overrides := make(map[uuid.UUID]*subscription.PushSettings)
+	if req.DaoID != uuid.Nil {
+		subs, err := s.subscriptions.GetByFilters([]subscription.Filter{
+			subscription.UserIDsFilter{IDs: req.UserIDs},
+			subscription.DaoIDsFilter{IDs: []uuid.UUID{req.DaoID}},
+			subscription.WithoutTotalFilter{},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("get subscriptions: %w", err)
+		}
+
+		for _, sub := range subs.Subscriptions {
+			overrides[sub.UserID] = sub.PushSettings
+		}
+	}
+
+	allowed := make([]uuid.UUID, 0, len(req.UserIDs))
+	for _, id := range req.UserIDs {
+		psd, ok := settings[id]
+		if !ok {
+			psd = pushSchema.Defaults()
+		}
+
+		enabled, err := applyPushOverrides(psd, overrides[id]).Enabled(req.EventType)
+		if err != nil {
+			return nil, err
+		}
+
+		if enabled {
+			allowed = append(allowed, id)
+		}
+	}
+
+	return allowed, nil
+}
+
+func (s *Service) getTokensByUsers(userIDs []string) (map[string][]PushDetails, error) {
+	if bulk, ok := s.tokens.(BulkTokenProvider); ok {
+		return bulk.GetListByUserIDs(userIDs)
+	}
+
+	result := make(map[string][]PushDetails, len(userIDs))
+	for _, id := range userIDs {
+		list, err := s.tokens.GetListByUserID(id)
+		if err != nil {
+			return nil, fmt.Errorf("get token list: %s: %w", id, err)
+		}
+
+		result[id] = list
+	}
+
+	return result, nil
+}
@@ -0,0 +1,178 @@
+package settings
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var ErrInvalidCiphertext = errors.New("invalid ciphertext")
+
+// TokenCipher encrypts push tokens before storing them in the database
+type TokenCipher interface {
+	Encrypt(plaintexts []string) ([]string, error)
+	Decrypt(ciphertexts []string) ([]string, error)
+}
+
+// AESCipher encrypts tokens by AES-GCM with the key from config
+type AESCipher struct {
+	aead cipher.AEAD
+}
+
+// NewAESCipher creates cipher by base64 encoded 16, 24 or 32 bytes key
+func NewAESCipher(encodedKey string) (*AESCipher, error) {
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("decode key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("create block cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("create gcm: %w", err)
+	}
+
+	return &AESCipher{aead: aead}, nil
+}
+
+func (c *AESCipher) Encrypt(plaintexts []string) ([]string, error) {
+	result := make([]string, 0, len(plaintexts))
+	for _, plaintext := range plaintexts {
+		nonce := make([]byte, c.aead.NonceSize())
+		if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+			return nil, fmt.Errorf("generate nonce: %w", err)
+		}
+
+		sealed := c.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+		result = append(result, base64.StdEncoding.EncodeToString(sealed))
+	}
+
+	return result, nil
+}
+
+func (c *AESCipher) Decrypt(ciphertexts []string) ([]string, error) {
+	result := make([]string, 0, len(ciphertexts))
+	for _, ciphertext := range ciphertexts {
+		raw, err := base64.StdEncoding.DecodeString(ciphertext)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidCiphertext, err)
+		}
+
+		if len(raw) < c.aead.NonceSize() {
+			return nil, ErrInvalidCiphertext
+		}
+
+		nonce, sealed := raw[:c.aead.NonceSize()], raw[c.aead.NonceSize():]
+		plaintext, err := c.aead.Open(nil, nonce, sealed, nil)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidCiphertext, err)
+		}
+
+		result = append(result, string(plaintext))
+	}
+
+	return result, nil
+}
+
+// TransitCipher encrypts tokens by the Vault transit engine with batch requests
+type TransitCipher struct {
+	cli   vaultReadWriter
+	mount string
+	key   string
+}
+
+func NewTransitCipher(cli vaultReadWriter, mount, key string) *TransitCipher {
+	return &TransitCipher{
+		cli:   cli,
+		mount: mount,
+		key:   key,
+	}
+}
+
+func (c *TransitCipher) Encrypt(plaintexts []string) ([]string, error) {
+	input := make([]map[string]interface{}, 0, len(plaintexts))
+	for _, plaintext := range plaintexts {
+		input = append(input, map[string]interface{}{
+			"plaintext": base64.StdEncoding.EncodeToString([]byte(plaintext)),
+		})
+	}
+
+	return c.batch("encrypt", input, "ciphertext", len(plaintexts))
+}
+
+func (c *TransitCipher) Decrypt(ciphertexts []string) ([]string, error) {
+	input := make([]map[string]interface{}, 0, len(ciphertexts))
+	for _, ciphertext := range ciphertexts {
+		input = append(input, map[string]interface{}{
+			"ciphertext": ciphertext,
+		})
+	}
+
+	encoded, err := c.batch("decrypt", input, "plaintext", len(ciphertexts))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(encoded))
+	for _, item := range encoded {
+		raw, err := base64.StdEncoding.DecodeString(item)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidCiphertext, err)
+		}
+
+		result = append(result, string(raw))
+	}
+
+	return result, nil
+}
+
+func (c *TransitCipher) batch(operation string, input []map[string]interface{}, resultKey string, size int) ([]string, error) {
+	if size == 0 {
+		return nil, nil
+	}
+
+	sec, err := c.cli.Write(fmt.Sprintf("%s/%s/%s", c.mount, operation, c.key), map[string]interface{}{
+		"batch_input": input,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("transit %s: %w", operation, err)
+	}
+
+	if sec == nil {
+		return nil, fmt.Errorf("transit %s: empty response", operation)
+	}
+
+	results, ok := sec.Data["batch_results"].([]interface{})
+	if !ok || len(results) != size {
+		return nil, fmt.Errorf("transit %s results: %w", operation, ErrUnableToCastData)
+	}
+
+	list := make([]string, 0, size)
+	for _, item := range results {
+		data, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("transit %s item: %w", operation, ErrUnableToCastData)
+		}
+
+		if msg, ok := data["error"].(string); ok && msg != "" {
+			return nil, fmt.Errorf("transit %s item: %s", operation, msg)
+		}
+
+		val, ok := data[resultKey].(string)
+		if !ok {
+			return nil, fmt.Errorf("transit %s %s: %w", operation, resultKey, ErrUnableToCastData)
+		}
+
+		list = append(list, val)
+	}
+
+	return list, nil
+}
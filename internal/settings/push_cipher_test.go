@@ -0,0 +1,35 @@
+package settings
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitAESCipher(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	c, err := NewAESCipher(key)
+	require.NoError(t, err)
+
+	t.Run("encrypt and decrypt", func(t *testing.T) {
+		encrypted, err := c.Encrypt([]string{"token-1", "token-2"})
+		require.NoError(t, err)
+		require.Len(t, encrypted, 2)
+		require.NotContains(t, encrypted, "token-1")
+
+		decrypted, err := c.Decrypt(encrypted)
+		require.NoError(t, err)
+		require.Equal(t, []string{"token-1", "token-2"}, decrypted)
+	})
+
+	t.Run("invalid ciphertext", func(t *testing.T) {
+		_, err := c.Decrypt([]string{"dG9rZW4"})
+		require.ErrorIs(t, err, ErrInvalidCiphertext)
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		_, err := NewAESCipher(base64.StdEncoding.EncodeToString([]byte("short")))
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,44 @@
+package settings
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const cleanupBatchSize = 500
+
+// PushCleanupWorker periodically drops tokens which were not refreshed by the app for a long time
+type PushCleanupWorker struct {
+	service  *Service
+	interval time.Duration
+	ttl      time.Duration
+}
+
+func NewPushCleanupWorker(service *Service, interval, ttl time.Duration) *PushCleanupWorker {
+	return &PushCleanupWorker{
+		service:  service,
+		interval: interval,
+		ttl:      ttl,
+	}
+}
+
+func (w *PushCleanupWorker) Start(ctx context.Context) error {
+	for {
+		removed, err := w.service.CleanupStale(ctx, time.Now().Add(-w.ttl), cleanupBatchSize)
+		if err != nil {
+			log.Error().Err(err).Msg("cleanup stale push tokens")
+		}
+
+		if removed > 0 {
+			log.Info().Msgf("removed %d stale push tokens", removed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(w.interval):
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package settings
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LegacyMigrationStatus string
+
+const (
+	// LegacyMigrationStatusMoved means the legacy token was saved by the default device path
+	LegacyMigrationStatusMoved LegacyMigrationStatus = "moved"
+	// LegacyMigrationStatusDropped means the user already had tokens by devices and the legacy one was obsolete
+	LegacyMigrationStatusDropped LegacyMigrationStatus = "dropped"
+)
+
+// LegacyTokenMigration stores the progress of moving tokens from the deprecated v1 path
+type LegacyTokenMigration struct {
+	UserID     string `gorm:"primaryKey"`
+	DeviceUUID string
+	Status     LegacyMigrationStatus
+	MigratedAt time.Time
+}
+
+func (LegacyTokenMigration) TableName() string {
+	return "push_tokens_legacy_migration"
+}
+
+type LegacyMigrationRepo struct {
+	db *gorm.DB
+}
+
+func NewLegacyMigrationRepo(db *gorm.DB) *LegacyMigrationRepo {
+	return &LegacyMigrationRepo{db: db}
+}
+
+func (r *LegacyMigrationRepo) Save(item *LegacyTokenMigration) error {
+	return r.db.
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		Create(item).
+		Error
+}
+
+func (r *LegacyMigrationRepo) Count() (int64, error) {
+	var cnt int64
+	err := r.db.
+		Model(&LegacyTokenMigration{}).
+		Count(&cnt).
+		Error
+	if err != nil {
+		return 0, err
+	}
+
+	return cnt, nil
+}
+
+type legacyProgress interface {
+	Save(item *LegacyTokenMigration) error
+	Count() (int64, error)
+}
+
+type legacyTokenSource interface {
+	WalkLegacy(fn func(userID, token string) error) error
+	HasDeviceTokens(userID string) (bool, error)
+	DeleteLegacy(userID string) error
+}
+
+type legacyFallbackSwitch interface {
+	DisableLegacyFallback()
+}
+
+// LegacyTokenMigrator moves tokens from the deprecated v1 path in Vault to the token storage used by the service,
+// so moved tokens reach every configured storage. The legacy entry is removed after moving, so the job is safe
+// to restart and continues with remaining entries. The legacy fallback is disabled once all entries are moved.
+type LegacyTokenMigrator struct {
+	legacy   legacyTokenSource
+	tokens   TokenProvider
+	progress legacyProgress
+	fallback legacyFallbackSwitch
+}
+
+func NewLegacyTokenMigrator(legacy legacyTokenSource, tokens TokenProvider, progress legacyProgress, fallback legacyFallbackSwitch) *LegacyTokenMigrator {
+	return &LegacyTokenMigrator{
+		legacy:   legacy,
+		tokens:   tokens,
+		progress: progress,
+		fallback: fallback,
+	}
+}
+
+func (m *LegacyTokenMigrator) Migrate(ctx context.Context) error {
+	start := time.Now()
+	processed := 0
+	err := m.legacy.WalkLegacy(func(userID, token string) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := m.migrateUser(userID, token); err != nil {
+			return fmt.Errorf("migrate legacy token: %s: %w", userID, err)
+		}
+
+		processed++
+		if processed%1000 == 0 {
+			log.Info().Msgf("legacy push tokens migration: %d tokens processed", processed)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("walk legacy tokens: %w", err)
+	}
+
+	total, err := m.progress.Count()
+	if err != nil {
+		return fmt.Errorf("count migrated tokens: %w", err)
+	}
+
+	// there are no legacy entries anymore, so reading them only makes extra requests to Vault
+	m.fallback.DisableLegacyFallback()
+
+	log.Info().Msgf("legacy push tokens migration finished in %s: %d tokens processed, %d in total, the legacy fallback is disabled", time.Since(start), processed, total)
+
+	return nil
+}
+
+func (m *LegacyTokenMigrator) migrateUser(userID, token string) error {
+	hasDevices, err := m.hasDeviceTokens(userID, token)
+	if err != nil {
+		return fmt.Errorf("check device tokens: %w", err)
+	}
+
+	item := &LegacyTokenMigration{
+		UserID:     userID,
+		Status:     LegacyMigrationStatusDropped,
+		MigratedAt: time.Now(),
+	}
+	if !hasDevices {
+		if err = m.tokens.Save(userID, defaultDeviceUUID, token); err != nil {
+			return fmt.Errorf("save token: %w", err)
+		}
+
+		item.DeviceUUID = defaultDeviceUUID
+		item.Status = LegacyMigrationStatusMoved
+	}
+
+	if err = m.progress.Save(item); err != nil {
+		return fmt.Errorf("save progress: %w", err)
+	}
+
+	if err = m.legacy.DeleteLegacy(userID); err != nil {
+		return fmt.Errorf("delete legacy token: %w", err)
+	}
+
+	return nil
+}
+
+// hasDeviceTokens checks tokens by devices in the legacy storage and in the target one. The legacy token
+// returned by the Vault fallback under the default device is not treated as the device token.
+func (m *LegacyTokenMigrator) hasDeviceTokens(userID, legacyToken string) (bool, error) {
+	hasDevices, err := m.legacy.HasDeviceTokens(userID)
+	if err != nil || hasDevices {
+		return hasDevices, err
+	}
+
+	list, err := m.tokens.GetListByUserID(userID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, info := range list {
+		if info.DeviceUUID != defaultDeviceUUID || info.Token != legacyToken {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Start runs migration once and waits for stopping to not stop other workers
+func (m *LegacyTokenMigrator) Start(ctx context.Context) error {
+	if err := m.Migrate(ctx); err != nil {
+		log.Error().Err(err).Msg("migrate legacy push tokens")
+	}
+
+	<-ctx.Done()
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package settings
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeLegacySource struct {
+	legacy  map[string]string
+	devices map[string]bool
+}
+
+func (f *fakeLegacySource) WalkLegacy(fn func(userID, token string) error) error {
+	for userID, token := range f.legacy {
+		if err := fn(userID, token); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeLegacySource) HasDeviceTokens(userID string) (bool, error) {
+	return f.devices[userID], nil
+}
+
+func (f *fakeLegacySource) DeleteLegacy(userID string) error {
+	delete(f.legacy, userID)
+
+	return nil
+}
+
+type fakeProgress struct {
+	items map[string]LegacyTokenMigration
+}
+
+func (f *fakeProgress) Save(item *LegacyTokenMigration) error {
+	f.items[item.UserID] = *item
+
+	return nil
+}
+
+func (f *fakeProgress) Count() (int64, error) {
+	return int64(len(f.items)), nil
+}
+
+type fakeFallbackSwitch struct {
+	disabled bool
+}
+
+func (f *fakeFallbackSwitch) DisableLegacyFallback() {
+	f.disabled = true
+}
+
+func TestUnitLegacyTokenMigrator(t *testing.T) {
+	source := &fakeLegacySource{
+		legacy: map[string]string{
+			"moved":        "legacy-1",
+			"vault-device": "legacy-2",
+			"pg-device":    "legacy-3",
+		},
+		devices: map[string]bool{"vault-device": true},
+	}
+	target := &fakeTokens{tokens: map[tokenKey]string{
+		{userID: "pg-device", deviceUUID: "phone"}: "token",
+		// the vault fallback returns the legacy token as the default device one
+		{userID: "moved", deviceUUID: defaultDeviceUUID}: "legacy-1",
+	}}
+	progress := &fakeProgress{items: map[string]LegacyTokenMigration{}}
+	fallback := &fakeFallbackSwitch{}
+
+	err := NewLegacyTokenMigrator(source, target, progress, fallback).Migrate(context.Background())
+	require.NoError(t, err)
+	require.True(t, fallback.disabled)
+
+	require.Empty(t, source.legacy)
+	require.Equal(t, LegacyMigrationStatusMoved, progress.items["moved"].Status)
+	require.Equal(t, "legacy-1", target.tokens[tokenKey{userID: "moved", deviceUUID: defaultDeviceUUID}])
+	require.Equal(t, LegacyMigrationStatusDropped, progress.items["vault-device"].Status)
+	require.Equal(t, LegacyMigrationStatusDropped, progress.items["pg-device"].Status)
+	require.NotContains(t, target.tokens, tokenKey{userID: "pg-device", deviceUUID: defaultDeviceUUID})
+}
+
+func TestUnitLegacyTokenMigratorKeepsFallbackOnError(t *testing.T) {
+	source := &fakeLegacySource{legacy: map[string]string{"user": "legacy"}}
+	target := &fakeTokens{tokens: map[tokenKey]string{}, err: errBackend}
+	fallback := &fakeFallbackSwitch{}
+
+	err := NewLegacyTokenMigrator(source, target, &fakeProgress{items: map[string]LegacyTokenMigration{}}, fallback).
+		Migrate(context.Background())
+	require.ErrorIs(t, err, errBackend)
+	require.False(t, fallback.disabled)
+	require.Contains(t, source.legacy, "user")
+}
@@ -0,0 +1,156 @@
+package settings
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/goverland-labs/goverland-inbox-storage/internal/appversions"
+)
+
+type PushProvider string
+
+const (
+	PushProviderUnknown PushProvider = "unknown"
+	PushProviderAPNs    PushProvider = "apns"
+	PushProviderFCM     PushProvider = "fcm"
+)
+
+// providerByPlatform describes which provider is used by the app platform
+var providerByPlatform = map[string]PushProvider{
+	string(appversions.PlatformIos):     PushProviderAPNs,
+	string(appversions.PlatformAndroid): PushProviderFCM,
+}
+
+// ProviderByPlatform returns push provider by the app platform
+func ProviderByPlatform(platform string) PushProvider {
+	provider, ok := providerByPlatform[platform]
+	if !ok {
+		return PushProviderUnknown
+	}
+
+	return provider
+}
+
+// TokenMetadata describes the push token origin
+type TokenMetadata struct {
+	Provider   PushProvider
+	Platform   string
+	AppVersion string
+}
+
+// PushTokenMetadata is stored separately of the token to be available for any token storage
+type PushTokenMetadata struct {
+	UserID             string `gorm:"primaryKey"`
+	DeviceUUID         string `gorm:"primaryKey"`
+	Provider           PushProvider
+	Platform           string
+	AppVersion         string
+	CreatedAt          time.Time
+	RefreshedAt        time.Time
+	InvalidatedAt      *time.Time
+	InvalidationReason string
+}
+
+func (PushTokenMetadata) TableName() string {
+	return "push_token_metadata"
+}
+
+type PushMetadataRepo struct {
+	db *gorm.DB
+}
+
+func NewPushMetadataRepo(db *gorm.DB) *PushMetadataRepo {
+	return &PushMetadataRepo{db: db}
+}
+
+// Upsert stores the token metadata and marks the token as refreshed
+func (r *PushMetadataRepo) Upsert(info *PushTokenMetadata) error {
+	return r.db.
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_id"}, {Name: "device_uuid"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"provider",
+				"platform",
+				"app_version",
+				"refreshed_at",
+				"invalidated_at",
+				"invalidation_reason",
+			}),
+		}).
+		Create(info).
+		Error
+}
+
+// CreateMissing stores metadata only for tokens which don't have it yet
+func (r *PushMetadataRepo) CreateMissing(list []PushTokenMetadata) error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	return r.db.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&list).
+		Error
+}
+
+func (r *PushMetadataRepo) GetByUser(userID string) ([]PushTokenMetadata, error) {
+	var list []PushTokenMetadata
+	err := r.db.
+		Where("user_id = ?", userID).
+		Find(&list).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (r *PushMetadataRepo) GetByUsers(userIDs []string) ([]PushTokenMetadata, error) {
+	var list []PushTokenMetadata
+	err := r.db.
+		Where("user_id in ?", userIDs).
+		Find(&list).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (r *PushMetadataRepo) MarkInvalidated(userID, deviceUUID, reason string) error {
+	return r.db.
+		Model(&PushTokenMetadata{}).
+		Where("user_id = ? and device_uuid = ?", userID, deviceUUID).
+		Updates(map[string]any{
+			"invalidated_at":      time.Now(),
+			"invalidation_reason": reason,
+		}).
+		Error
+}
+
+func (r *PushMetadataRepo) Delete(userID, deviceUUID string) error {
+	return r.db.
+		Where("user_id = ? and device_uuid = ?", userID, deviceUUID).
+		Delete(&PushTokenMetadata{}).
+		Error
+}
+
+// GetStale returns tokens which were not refreshed since provided date or were invalidated before it
+func (r *PushMetadataRepo) GetStale(before time.Time, limit int) ([]PushTokenMetadata, error) {
+	var list []PushTokenMetadata
+	err := r.db.
+		Where("refreshed_at < ? or invalidated_at < ?", before, before).
+		Order("refreshed_at").
+		Limit(limit).
+		Find(&list).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
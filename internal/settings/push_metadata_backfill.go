@@ -0,0 +1,92 @@
+package settings
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const metadataBackfillBatchSize = 500
+
+type deviceWalker interface {
+	WalkDevices(fn func(userID, deviceUUID string) error) error
+}
+
+type metadataCreator interface {
+	CreateMissing(list []PushTokenMetadata) error
+}
+
+// MetadataBackfiller creates metadata for tokens stored before metadata was introduced. Such tokens are treated
+// as refreshed at the moment of backfilling, so the cleanup removes them if the app doesn't refresh them in time.
+// Legacy v1 tokens are backfilled with the default device and removed with it.
+type MetadataBackfiller struct {
+	source   deviceWalker
+	metadata metadataCreator
+}
+
+func NewMetadataBackfiller(source deviceWalker, metadata metadataCreator) *MetadataBackfiller {
+	return &MetadataBackfiller{
+		source:   source,
+		metadata: metadata,
+	}
+}
+
+func (b *MetadataBackfiller) Backfill(ctx context.Context) (int, error) {
+	now := time.Now()
+	processed := 0
+	batch := make([]PushTokenMetadata, 0, metadataBackfillBatchSize)
+	flush := func() error {
+		if err := b.metadata.CreateMissing(batch); err != nil {
+			return fmt.Errorf("create metadata: %w", err)
+		}
+
+		processed += len(batch)
+		batch = batch[:0]
+
+		return nil
+	}
+
+	err := b.source.WalkDevices(func(userID, deviceUUID string) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		batch = append(batch, PushTokenMetadata{
+			UserID:      userID,
+			DeviceUUID:  deviceUUID,
+			Provider:    PushProviderUnknown,
+			CreatedAt:   now,
+			RefreshedAt: now,
+		})
+		if len(batch) < metadataBackfillBatchSize {
+			return nil
+		}
+
+		return flush()
+	})
+	if err != nil {
+		return processed, fmt.Errorf("walk tokens: %w", err)
+	}
+
+	if err = flush(); err != nil {
+		return processed, err
+	}
+
+	return processed, nil
+}
+
+// Start runs backfilling once and waits for stopping to not stop other workers
+func (b *MetadataBackfiller) Start(ctx context.Context) error {
+	processed, err := b.Backfill(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("backfill push tokens metadata")
+	}
+
+	log.Info().Msgf("push tokens metadata backfill: %d tokens processed", processed)
+
+	<-ctx.Done()
+
+	return nil
+}
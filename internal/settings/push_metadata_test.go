@@ -0,0 +1,54 @@
+package settings
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-inbox-storage/internal/appversions"
+)
+
+func TestUnitProviderByPlatform(t *testing.T) {
+	require.Equal(t, PushProviderAPNs, ProviderByPlatform(string(appversions.PlatformIos)))
+	require.Equal(t, PushProviderFCM, ProviderByPlatform(string(appversions.PlatformAndroid)))
+	require.Equal(t, PushProviderUnknown, ProviderByPlatform("web"))
+}
+
+type fakeWalker struct {
+	count int
+}
+
+func (f fakeWalker) WalkDevices(fn func(userID, deviceUUID string) error) error {
+	for i := 0; i < f.count; i++ {
+		if err := fn(fmt.Sprintf("user-%d", i), "device"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type fakeMetadataCreator struct {
+	batches [][]PushTokenMetadata
+}
+
+func (f *fakeMetadataCreator) CreateMissing(list []PushTokenMetadata) error {
+	f.batches = append(f.batches, append([]PushTokenMetadata(nil), list...))
+
+	return nil
+}
+
+func TestUnitMetadataBackfiller(t *testing.T) {
+	creator := &fakeMetadataCreator{}
+	backfiller := NewMetadataBackfiller(fakeWalker{count: metadataBackfillBatchSize + 1}, creator)
+
+	processed, err := backfiller.Backfill(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, metadataBackfillBatchSize+1, processed)
+	require.Len(t, creator.batches, 2)
+	require.Len(t, creator.batches[1], 1)
+	require.Equal(t, PushProviderUnknown, creator.batches[1][0].Provider)
+	require.False(t, creator.batches[1][0].RefreshedAt.IsZero())
+}
@@ -0,0 +1,70 @@
+package settings
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type tokenWalker interface {
+	Walk(fn func(userID, deviceUUID, token string) error) error
+}
+
+type tokenImporter interface {
+	Import(userID, deviceUUID, token string) error
+}
+
+// TokenMigrator copies push tokens from the Vault layout to another storage without overwriting
+// tokens which are already there, so it's safe to run it while the service is serving requests.
+type TokenMigrator struct {
+	source tokenWalker
+	target tokenImporter
+}
+
+func NewTokenMigrator(source tokenWalker, target tokenImporter) *TokenMigrator {
+	return &TokenMigrator{
+		source: source,
+		target: target,
+	}
+}
+
+func (m *TokenMigrator) Migrate(ctx context.Context) error {
+	start := time.Now()
+	processed := 0
+	err := m.source.Walk(func(userID, deviceUUID, token string) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := m.target.Import(userID, deviceUUID, token); err != nil {
+			return fmt.Errorf("import token: %s/%s: %w", userID, deviceUUID, err)
+		}
+
+		processed++
+		if processed%1000 == 0 {
+			log.Info().Msgf("push tokens migration: %d tokens processed", processed)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("walk tokens: %w", err)
+	}
+
+	log.Info().Msgf("push tokens migration finished in %s: %d tokens processed", time.Since(start), processed)
+
+	return nil
+}
+
+// Start runs migration once and waits for stopping to not stop other workers
+func (m *TokenMigrator) Start(ctx context.Context) error {
+	if err := m.Migrate(ctx); err != nil {
+		log.Error().Err(err).Msg("migrate push tokens")
+	}
+
+	<-ctx.Done()
+
+	return nil
+}
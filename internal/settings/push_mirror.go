@@ -0,0 +1,76 @@
+package settings
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+)
+
+// MirrorPushRepo reads tokens from the primary storage and duplicates writes to the secondary one.
+// It's used for the online migration between storages.
+type MirrorPushRepo struct {
+	primary   TokenProvider
+	secondary TokenProvider
+}
+
+func NewMirrorPushRepo(primary, secondary TokenProvider) *MirrorPushRepo {
+	return &MirrorPushRepo{
+		primary:   primary,
+		secondary: secondary,
+	}
+}
+
+func (r *MirrorPushRepo) GetByUserID(userID string) (string, error) {
+	return r.primary.GetByUserID(userID)
+}
+
+func (r *MirrorPushRepo) GetByUserAndDevice(userID, deviceUUID string) (string, error) {
+	return r.primary.GetByUserAndDevice(userID, deviceUUID)
+}
+
+func (r *MirrorPushRepo) GetListByUserID(userID string) ([]PushDetails, error) {
+	return r.primary.GetListByUserID(userID)
+}
+
+// GetListByUserIDs reads tokens of many users from the primary storage
+func (r *MirrorPushRepo) GetListByUserIDs(userIDs []string) (map[string][]PushDetails, error) {
+	if bulk, ok := r.primary.(BulkTokenProvider); ok {
+		return bulk.GetListByUserIDs(userIDs)
+	}
+
+	result := make(map[string][]PushDetails, len(userIDs))
+	for _, id := range userIDs {
+		list, err := r.primary.GetListByUserID(id)
+		if err != nil {
+			return nil, fmt.Errorf("get token list: %s: %w", id, err)
+		}
+
+		result[id] = list
+	}
+
+	return result, nil
+}
+
+func (r *MirrorPushRepo) Save(userID, deviceUUID, token string) error {
+	if err := r.primary.Save(userID, deviceUUID, token); err != nil {
+		return err
+	}
+
+	if err := r.secondary.Save(userID, deviceUUID, token); err != nil {
+		log.Error().Err(err).Str("user", userID).Msg("mirror push token save")
+	}
+
+	return nil
+}
+
+func (r *MirrorPushRepo) Delete(userID, deviceUUID string) error {
+	if err := r.primary.Delete(userID, deviceUUID); err != nil {
+		return err
+	}
+
+	if err := r.secondary.Delete(userID, deviceUUID); err != nil {
+		log.Error().Err(err).Str("user", userID).Msg("mirror push token delete")
+	}
+
+	return nil
+}
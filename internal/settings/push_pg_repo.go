@@ -0,0 +1,230 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const walkBatchSize = 500
+
+// PushToken describes the encrypted push token in the database
+type PushToken struct {
+	UserID     string `gorm:"primaryKey"`
+	DeviceUUID string `gorm:"primaryKey"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	Token      string
+}
+
+func (PushToken) TableName() string {
+	return "push_tokens"
+}
+
+// PgPushRepo provides capability to store and get encrypted keys in the database.
+type PgPushRepo struct {
+	db     *gorm.DB
+	cipher TokenCipher
+}
+
+func NewPgPushRepo(db *gorm.DB, c TokenCipher) *PgPushRepo {
+	return &PgPushRepo{
+		db:     db,
+		cipher: c,
+	}
+}
+
+func (r *PgPushRepo) GetListByUserID(userID string) ([]PushDetails, error) {
+	var list []PushToken
+	err := r.db.
+		Where("user_id = ?", userID).
+		Order("created_at").
+		Find(&list).
+		Error
+	if err != nil {
+		return nil, fmt.Errorf("get tokens by user: %w", err)
+	}
+
+	if len(list) == 0 {
+		return nil, nil
+	}
+
+	ciphertexts := make([]string, 0, len(list))
+	for _, info := range list {
+		ciphertexts = append(ciphertexts, info.Token)
+	}
+
+	tokens, err := r.cipher.Decrypt(ciphertexts)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt tokens: %w", err)
+	}
+
+	result := make([]PushDetails, 0, len(list))
+	for idx, info := range list {
+		result = append(result, PushDetails{
+			DeviceUUID: info.DeviceUUID,
+			Token:      tokens[idx],
+		})
+	}
+
+	return result, nil
+}
+
+// GetListByUserIDs returns tokens grouped by users decrypting them in one batch
+func (r *PgPushRepo) GetListByUserIDs(userIDs []string) (map[string][]PushDetails, error) {
+	var list []PushToken
+	err := r.db.
+		Where("user_id in ?", userIDs).
+		Order("user_id, created_at").
+		Find(&list).
+		Error
+	if err != nil {
+		return nil, fmt.Errorf("get tokens by users: %w", err)
+	}
+
+	if len(list) == 0 {
+		return nil, nil
+	}
+
+	ciphertexts := make([]string, 0, len(list))
+	for _, info := range list {
+		ciphertexts = append(ciphertexts, info.Token)
+	}
+
+	tokens, err := r.cipher.Decrypt(ciphertexts)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt tokens: %w", err)
+	}
+
+	result := make(map[string][]PushDetails, len(userIDs))
+	for idx, info := range list {
+		result[info.UserID] = append(result[info.UserID], PushDetails{
+			DeviceUUID: info.DeviceUUID,
+			Token:      tokens[idx],
+		})
+	}
+
+	return result, nil
+}
+
+// GetByUserID returns the latest saved user token
+// @deprecated: use GetByUserAndDevice instead
+func (r *PgPushRepo) GetByUserID(userID string) (string, error) {
+	var info PushToken
+	err := r.db.
+		Where("user_id = ?", userID).
+		Order("updated_at desc").
+		First(&info).
+		Error
+
+	return r.decrypt(info, err)
+}
+
+func (r *PgPushRepo) GetByUserAndDevice(userID, deviceUUID string) (string, error) {
+	var info PushToken
+	err := r.db.
+		Where("user_id = ? and device_uuid = ?", userID, deviceUUID).
+		First(&info).
+		Error
+
+	return r.decrypt(info, err)
+}
+
+// Save storing provided token for user by device
+func (r *PgPushRepo) Save(userID, deviceUUID, token string) error {
+	return r.save(userID, deviceUUID, token, true)
+}
+
+// Import stores provided token only if there is no token for the user device
+func (r *PgPushRepo) Import(userID, deviceUUID, token string) error {
+	return r.save(userID, deviceUUID, token, false)
+}
+
+// Delete remove token from storage by user and device
+func (r *PgPushRepo) Delete(userID, deviceUUID string) error {
+	return r.db.
+		Where("user_id = ? and device_uuid = ?", userID, deviceUUID).
+		Delete(&PushToken{}).
+		Error
+}
+
+// WalkDevices iterates over users and devices of all stored tokens without decrypting them
+func (r *PgPushRepo) WalkDevices(fn func(userID, deviceUUID string) error) error {
+	var last PushToken
+	for {
+		var list []PushToken
+		err := r.db.
+			Select("user_id", "device_uuid").
+			Where("(user_id, device_uuid) > (?, ?)", last.UserID, last.DeviceUUID).
+			Order("user_id, device_uuid").
+			Limit(walkBatchSize).
+			Find(&list).
+			Error
+		if err != nil {
+			return fmt.Errorf("get tokens: %w", err)
+		}
+
+		for _, info := range list {
+			if err = fn(info.UserID, info.DeviceUUID); err != nil {
+				return err
+			}
+		}
+
+		if len(list) < walkBatchSize {
+			return nil
+		}
+
+		last = list[len(list)-1]
+	}
+}
+
+func (r *PgPushRepo) save(userID, deviceUUID, token string, overwrite bool) error {
+	encrypted, err := r.cipher.Encrypt([]string{token})
+	if err != nil {
+		return fmt.Errorf("encrypt token: %w", err)
+	}
+
+	onConflict := clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "device_uuid"}},
+		DoNothing: true,
+	}
+	if overwrite {
+		onConflict = clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "device_uuid"}},
+			DoUpdates: clause.AssignmentColumns([]string{"token", "updated_at"}),
+		}
+	}
+
+	now := time.Now()
+
+	return r.db.
+		Clauses(onConflict).
+		Create(&PushToken{
+			UserID:     userID,
+			DeviceUUID: deviceUUID,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+			Token:      encrypted[0],
+		}).
+		Error
+}
+
+func (r *PgPushRepo) decrypt(info PushToken, err error) (string, error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", ErrTokenNotFound
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	tokens, err := r.cipher.Decrypt([]string{info.Token})
+	if err != nil {
+		return "", fmt.Errorf("decrypt token: %w", err)
+	}
+
+	return tokens[0], nil
+}
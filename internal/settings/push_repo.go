@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	vaultapi "github.com/hashicorp/vault/api"
 	"github.com/rs/zerolog/log"
@@ -13,6 +15,13 @@ const (
 	keyData  = "data"
 	keysData = "keys"
 	keyToken = "token"
+
+	// defaultDeviceUUID is used for tokens stored before supporting multiple devices
+	defaultDeviceUUID  = "default_device"
+	tokensByDevicesDir = "tokens_by_devices"
+
+	// bulkConcurrency limits parallel requests to Vault on reading tokens of many users
+	bulkConcurrency = 16
 )
 
 type vaultReadWriter interface {
@@ -25,6 +34,7 @@ type vaultReadWriter interface {
 type PushDetails struct {
 	DeviceUUID string
 	Token      string
+	Metadata   *PushTokenMetadata
 }
 
 var (
@@ -36,13 +46,23 @@ var (
 type PushRepo struct {
 	cli      vaultReadWriter
 	basePath string
+	// legacyFallback enables reading tokens by the deprecated v1 path if there is no token by device
+	legacyFallback atomic.Bool
 }
 
-func NewPushRepo(cli vaultReadWriter, path string) *PushRepo {
-	return &PushRepo{
+func NewPushRepo(cli vaultReadWriter, path string, legacyFallback bool) *PushRepo {
+	repo := &PushRepo{
 		cli:      cli,
 		basePath: strings.TrimRight(path, "/"),
 	}
+	repo.legacyFallback.Store(legacyFallback)
+
+	return repo
+}
+
+// DisableLegacyFallback stops reading tokens by the deprecated v1 path
+func (s *PushRepo) DisableLegacyFallback() {
+	s.legacyFallback.Store(false)
 }
 
 func (s *PushRepo) getPathV1(userID string) string {
@@ -50,7 +70,7 @@ func (s *PushRepo) getPathV1(userID string) string {
 }
 
 func (s *PushRepo) getPathByUser(userID string) string {
-	return fmt.Sprintf("%s/tokens_by_devices/%s", s.basePath, userID)
+	return fmt.Sprintf("%s/%s/%s", s.basePath, tokensByDevicesDir, userID)
 }
 
 func (s *PushRepo) getPathByUserDevice(userID, deviceUUID string) string {
@@ -63,6 +83,10 @@ func (s *PushRepo) GetListByUserID(userID string) ([]PushDetails, error) {
 		return nil, err
 	}
 
+	if sec == nil && !s.legacyFallback.Load() {
+		return nil, nil
+	}
+
 	if sec == nil {
 		// let's try fallback logic
 		token, err := s.GetByUserID(userID)
@@ -76,7 +100,7 @@ func (s *PushRepo) GetListByUserID(userID string) ([]PushDetails, error) {
 
 		return []PushDetails{
 			{
-				DeviceUUID: "default_device",
+				DeviceUUID: defaultDeviceUUID,
 				Token:      token,
 			},
 		}, nil
@@ -108,6 +132,58 @@ func (s *PushRepo) GetListByUserID(userID string) ([]PushDetails, error) {
 	return result, nil
 }
 
+// GetListByUserIDs returns tokens grouped by users. Vault has no bulk read, so users are requested
+// in parallel with limited concurrency and the first error cancels not started requests.
+func (s *PushRepo) GetListByUserIDs(userIDs []string) (map[string][]PushDetails, error) {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+		result   = make(map[string][]PushDetails, len(userIDs))
+		sem      = make(chan struct{}, bulkConcurrency)
+	)
+
+	for _, id := range userIDs {
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed {
+			break
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(userID string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			list, err := s.GetListByUserID(userID)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("get token list: %s: %w", userID, err)
+				}
+
+				return
+			}
+
+			result[userID] = list
+		}(id)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return result, nil
+}
+
 // GetByUserID returns saved user token
 // @deprecated: use GetByUserAndDevice instead
 func (s *PushRepo) GetByUserID(userID string) (string, error) {
@@ -140,7 +216,7 @@ func (s *PushRepo) GetByUserAndDevice(userID, deviceUUID string) (token string,
 	}
 
 	defer func() {
-		if err == nil {
+		if err == nil || !s.legacyFallback.Load() {
 			return
 		}
 
@@ -151,7 +227,7 @@ func (s *PushRepo) GetByUserAndDevice(userID, deviceUUID string) (token string,
 		}
 
 		if errSave := s.Save(userID, deviceUUID, token); errSave != nil {
-			log.Err(err).Msg("failed to resave token")
+			log.Err(errSave).Msg("failed to resave token")
 		}
 	}()
 
@@ -186,12 +262,171 @@ func (s *PushRepo) Save(userID, deviceUUID, token string) error {
 	return nil
 }
 
-// Delete remove token from storage by user and device
+// Delete remove token from storage by user and device. The default device also removes the legacy v1 token,
+// because it's returned with the default device by the fallback and by walking.
 func (s *PushRepo) Delete(userID, deviceUUID string) error {
 	_, err := s.cli.Delete(s.getPathByUserDevice(userID, deviceUUID))
 	if err != nil {
 		return err
 	}
 
+	if deviceUUID != defaultDeviceUUID {
+		return nil
+	}
+
+	if _, err = s.cli.Delete(s.getPathV1(userID)); err != nil {
+		return fmt.Errorf("delete legacy token: %w", err)
+	}
+
+	return nil
+}
+
+// Walk iterates over all stored tokens including legacy v1 ones. Legacy token is provided with default
+// device only if the user has no tokens by devices.
+func (s *PushRepo) Walk(fn func(userID, deviceUUID, token string) error) error {
+	users, err := s.listKeys(fmt.Sprintf("%s/%s", s.basePath, tokensByDevicesDir))
+	if err != nil {
+		return fmt.Errorf("list users: %w", err)
+	}
+
+	withDevices := make(map[string]struct{}, len(users))
+	for _, key := range users {
+		userID := strings.TrimSuffix(key, "/")
+		devices, err := s.listKeys(s.getPathByUser(userID))
+		if err != nil {
+			return fmt.Errorf("list devices: %s: %w", userID, err)
+		}
+
+		for _, deviceUUID := range devices {
+			token, err := s.readToken(s.getPathByUserDevice(userID, deviceUUID))
+			if errors.Is(err, ErrTokenNotFound) {
+				continue
+			}
+
+			if err != nil {
+				return fmt.Errorf("read token: %s/%s: %w", userID, deviceUUID, err)
+			}
+
+			withDevices[userID] = struct{}{}
+			if err = fn(userID, deviceUUID, token); err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.WalkLegacy(func(userID, token string) error {
+		if _, ok := withDevices[userID]; ok {
+			return nil
+		}
+
+		return fn(userID, defaultDeviceUUID, token)
+	})
+}
+
+// WalkDevices iterates over users and devices of all stored tokens
+func (s *PushRepo) WalkDevices(fn func(userID, deviceUUID string) error) error {
+	return s.Walk(func(userID, deviceUUID, _ string) error {
+		return fn(userID, deviceUUID)
+	})
+}
+
+// WalkLegacy iterates over tokens stored by the deprecated v1 path
+func (s *PushRepo) WalkLegacy(fn func(userID, token string) error) error {
+	legacy, err := s.listKeys(s.basePath)
+	if err != nil {
+		return fmt.Errorf("list legacy users: %w", err)
+	}
+
+	for _, userID := range legacy {
+		// directories are not the legacy tokens
+		if strings.HasSuffix(userID, "/") {
+			continue
+		}
+
+		token, err := s.readToken(s.getPathV1(userID))
+		if errors.Is(err, ErrTokenNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("read legacy token: %s: %w", userID, err)
+		}
+
+		if err = fn(userID, token); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// HasDeviceTokens checks if the user has at least one token stored by device
+func (s *PushRepo) HasDeviceTokens(userID string) (bool, error) {
+	devices, err := s.listKeys(s.getPathByUser(userID))
+	if err != nil {
+		return false, err
+	}
+
+	return len(devices) > 0, nil
+}
+
+// DeleteLegacy removes the token stored by the deprecated v1 path
+func (s *PushRepo) DeleteLegacy(userID string) error {
+	_, err := s.cli.Delete(s.getPathV1(userID))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
+
+func (s *PushRepo) listKeys(path string) ([]string, error) {
+	sec, err := s.cli.List(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if sec == nil {
+		return nil, nil
+	}
+
+	data, ok := sec.Data[keysData].([]interface{})
+	if !ok {
+		return nil, ErrUnableToCastData
+	}
+
+	keys := make([]string, 0, len(data))
+	for idx := range data {
+		key, ok := data[idx].(string)
+		if !ok {
+			return nil, fmt.Errorf("cast key: %w", ErrUnableToCastData)
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func (s *PushRepo) readToken(path string) (string, error) {
+	sec, err := s.cli.Read(path)
+	if err != nil {
+		return "", err
+	}
+
+	if sec == nil {
+		return "", ErrTokenNotFound
+	}
+
+	data, ok := sec.Data[keyData].(map[string]interface{})
+	if !ok {
+		return "", ErrUnableToCastData
+	}
+
+	token, ok := data[keyToken].(string)
+	if !ok {
+		return "", ErrUnableToCastData
+	}
+
+	return token, nil
+}
@@ -0,0 +1,118 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	vaultapi "github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeVault struct {
+	mu      sync.Mutex
+	secrets map[string]string
+	reads   int
+	err     error
+}
+
+func (f *fakeVault) List(path string) (*vaultapi.Secret, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var keys []interface{}
+	for key := range f.secrets {
+		if rest, ok := strings.CutPrefix(key, path+"/"); ok {
+			keys = append(keys, rest)
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	return &vaultapi.Secret{Data: map[string]interface{}{keysData: keys}}, nil
+}
+
+func (f *fakeVault) Read(path string) (*vaultapi.Secret, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.reads++
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	token, ok := f.secrets[path]
+	if !ok {
+		return nil, nil
+	}
+
+	return &vaultapi.Secret{Data: map[string]interface{}{keyData: map[string]interface{}{keyToken: token}}}, nil
+}
+
+func (f *fakeVault) Write(string, map[string]interface{}) (*vaultapi.Secret, error) { return nil, nil }
+
+func (f *fakeVault) Delete(path string) (*vaultapi.Secret, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.secrets, path)
+
+	return nil, nil
+}
+
+func TestUnitPushRepoGetListByUserIDs(t *testing.T) {
+	vault := &fakeVault{secrets: map[string]string{}}
+	repo := NewPushRepo(vault, "push", false)
+
+	var ids []string
+	for i := 0; i < bulkConcurrency*3; i++ {
+		id := fmt.Sprintf("user-%d", i)
+		ids = append(ids, id)
+		vault.secrets[repo.getPathByUserDevice(id, "phone")] = "token-" + id
+	}
+	ids = append(ids, "without-tokens")
+
+	result, err := repo.GetListByUserIDs(ids)
+	require.NoError(t, err)
+	require.Len(t, result, len(ids))
+	require.Equal(t, []PushDetails{{DeviceUUID: "phone", Token: "token-user-7"}}, result["user-7"])
+	require.Empty(t, result["without-tokens"])
+
+	vault.err = errors.New("vault is sealed")
+	_, err = repo.GetListByUserIDs(ids)
+	require.ErrorIs(t, err, vault.err)
+}
+
+func TestUnitPushRepoDelete(t *testing.T) {
+	vault := &fakeVault{secrets: map[string]string{}}
+	repo := NewPushRepo(vault, "push", true)
+
+	vault.secrets[repo.getPathV1("legacy")] = "legacy-token"
+	vault.secrets[repo.getPathV1("user")] = "legacy-token"
+	vault.secrets[repo.getPathByUserDevice("user", "phone")] = "token"
+
+	t.Run("device keeps legacy token", func(t *testing.T) {
+		require.NoError(t, repo.Delete("user", "phone"))
+		require.Equal(t, map[string]string{
+			repo.getPathV1("legacy"): "legacy-token",
+			repo.getPathV1("user"):   "legacy-token",
+		}, vault.secrets)
+	})
+
+	t.Run("default device removes legacy token", func(t *testing.T) {
+		list, err := repo.GetListByUserID("legacy")
+		require.NoError(t, err)
+		require.Equal(t, []PushDetails{{DeviceUUID: defaultDeviceUUID, Token: "legacy-token"}}, list)
+
+		require.NoError(t, repo.Delete("legacy", defaultDeviceUUID))
+		require.NotContains(t, vault.secrets, repo.getPathV1("legacy"))
+
+		list, err = repo.GetListByUserID("legacy")
+		require.NoError(t, err)
+		require.Empty(t, list)
+	})
+}
@@ -0,0 +1,192 @@
+package settings
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUnknownDetailsType = errors.New("unknown settings type")
+	ErrInvalidSettings    = errors.New("invalid settings")
+	ErrUnsupportedVersion = errors.New("unsupported settings version")
+)
+
+// Upgrade converts the stored value to the next schema version
+type Upgrade func(raw json.RawMessage) (json.RawMessage, error)
+
+// Schema describes the settings type: the Go struct, defaults, validation and version of the stored value.
+// All struct fields must be pointers with omitempty, so unset request fields don't override stored ones.
+type Schema[T any] struct {
+	Type    DetailsType
+	Version int
+	// Defaults returns the value used for unset fields
+	Defaults func() *T
+	// Validate checks the value before storing, optional
+	Validate func(value *T) error
+	// Upgrades contains converters by the version they upgrade from
+	Upgrades map[int]Upgrade
+	// Subject is used for publishing settings changes
+	Subject string
+	// Payload builds the event payload, SettingsUpdatedPayload is used if it's empty
+	Payload func(userID uuid.UUID, value *T, version int64) (any, error)
+}
+
+// schema allows working with registered settings without knowing their Go type
+type schema interface {
+	version() int
+	// normalize upgrades, fills defaults and validates the raw value returning it in the actual version
+	normalize(raw json.RawMessage, version int) (json.RawMessage, error)
+	// defaults returns the default value in the actual version
+	defaults() (json.RawMessage, error)
+	// message builds the outbox message by the normalized value
+	message(userID uuid.UUID, raw json.RawMessage, version int64) (*OutboxMessage, error)
+}
+
+var registry = map[DetailsType]schema{}
+
+func register[T any](sc *Schema[T]) *Schema[T] {
+	if _, ok := registry[sc.Type]; ok {
+		panic(fmt.Sprintf("settings schema %s is already registered", sc.Type))
+	}
+
+	registry[sc.Type] = sc
+
+	return sc
+}
+
+func getSchema(dt DetailsType) (schema, error) {
+	sc, ok := registry[dt]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDetailsType, dt)
+	}
+
+	return sc, nil
+}
+
+// registeredTypes returns types of all registered settings in stable order
+func registeredTypes() []DetailsType {
+	types := make([]DetailsType, 0, len(registry))
+	for dt := range registry {
+		types = append(types, dt)
+	}
+
+	slices.Sort(types)
+
+	return types
+}
+
+func (sc *Schema[T]) version() int {
+	return sc.Version
+}
+
+func (sc *Schema[T]) normalize(raw json.RawMessage, version int) (json.RawMessage, error) {
+	value, err := sc.decode(raw, version, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = sc.validate(value); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(value)
+}
+
+func (sc *Schema[T]) defaults() (json.RawMessage, error) {
+	return json.Marshal(sc.Defaults())
+}
+
+func (sc *Schema[T]) message(userID uuid.UUID, raw json.RawMessage, version int64) (*OutboxMessage, error) {
+	if sc.Payload == nil {
+		return NewOutboxMessage(sc.Subject, SettingsUpdatedPayload{
+			UserID:   userID,
+			Type:     sc.Type,
+			Version:  version,
+			Settings: raw,
+		})
+	}
+
+	value := new(T)
+	if err := json.Unmarshal(raw, value); err != nil {
+		return nil, fmt.Errorf("unmarshal %s: %w", sc.Type, err)
+	}
+
+	payload, err := sc.Payload(userID, value, version)
+	if err != nil {
+		return nil, fmt.Errorf("build %s payload: %w", sc.Type, err)
+	}
+
+	return NewOutboxMessage(sc.Subject, payload)
+}
+
+// decode upgrades the stored value to the actual version and applies it on top of defaults.
+// Unknown fields are rejected in the strict mode.
+func (sc *Schema[T]) decode(raw json.RawMessage, version int, strict bool) (*T, error) {
+	if version > sc.Version {
+		return nil, fmt.Errorf("%w: %s v%d", ErrUnsupportedVersion, sc.Type, version)
+	}
+
+	var err error
+	for ; version < sc.Version; version++ {
+		upgrade, ok := sc.Upgrades[version]
+		if !ok {
+			continue
+		}
+
+		if raw, err = upgrade(raw); err != nil {
+			return nil, fmt.Errorf("upgrade %s from v%d: %w", sc.Type, version, err)
+		}
+	}
+
+	value := sc.Defaults()
+	if len(raw) == 0 {
+		return value, nil
+	}
+
+	if !strict {
+		if err = json.Unmarshal(raw, value); err != nil {
+			return nil, fmt.Errorf("unmarshal %s: %w", sc.Type, err)
+		}
+
+		return value, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(value); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidSettings, sc.Type, err)
+	}
+
+	return value, nil
+}
+
+// merge applies set fields of the request on top of the current value
+func (sc *Schema[T]) merge(current *T, req T) (*T, error) {
+	raw, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("marshal %s: %w", sc.Type, err)
+	}
+
+	if err = json.Unmarshal(raw, current); err != nil {
+		return nil, fmt.Errorf("unmarshal %s: %w", sc.Type, err)
+	}
+
+	return current, nil
+}
+
+func (sc *Schema[T]) validate(value *T) error {
+	if sc.Validate == nil {
+		return nil
+	}
+
+	if err := sc.Validate(value); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrInvalidSettings, sc.Type, err)
+	}
+
+	return nil
+}
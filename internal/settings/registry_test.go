@@ -0,0 +1,80 @@
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.openly.dev/pointy"
+)
+
+type testSettings struct {
+	Enabled *bool   `json:"enabled,omitempty"`
+	Mode    *string `json:"mode,omitempty"`
+}
+
+func newTestSchema() *Schema[testSettings] {
+	return &Schema[testSettings]{
+		Type:    "test_config",
+		Version: 2,
+		Defaults: func() *testSettings {
+			return &testSettings{
+				Enabled: pointy.Bool(true),
+				Mode:    pointy.String("default"),
+			}
+		},
+		Validate: func(value *testSettings) error {
+			if pointy.StringValue(value.Mode, "") == "" {
+				return errors.New("empty mode")
+			}
+
+			return nil
+		},
+		Upgrades: map[int]Upgrade{
+			1: func(raw json.RawMessage) (json.RawMessage, error) {
+				var old struct {
+					Disabled bool `json:"disabled"`
+				}
+				if err := json.Unmarshal(raw, &old); err != nil {
+					return nil, err
+				}
+
+				return json.Marshal(testSettings{Enabled: pointy.Bool(!old.Disabled)})
+			},
+		},
+	}
+}
+
+func TestUnitSchema(t *testing.T) {
+	sc := newTestSchema()
+
+	t.Run("upgrade old version", func(t *testing.T) {
+		value, err := sc.decode(json.RawMessage(`{"disabled":true}`), 1, false)
+		require.NoError(t, err)
+		require.False(t, *value.Enabled)
+		require.Equal(t, "default", *value.Mode)
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		_, err := sc.decode(json.RawMessage(`{}`), 3, false)
+		require.ErrorIs(t, err, ErrUnsupportedVersion)
+	})
+
+	t.Run("merge keeps unset fields", func(t *testing.T) {
+		value, err := sc.merge(sc.Defaults(), testSettings{Mode: pointy.String("custom")})
+		require.NoError(t, err)
+		require.True(t, *value.Enabled)
+		require.Equal(t, "custom", *value.Mode)
+	})
+
+	t.Run("normalize rejects unknown fields", func(t *testing.T) {
+		_, err := sc.normalize(json.RawMessage(`{"unknown":1}`), 2)
+		require.ErrorIs(t, err, ErrInvalidSettings)
+	})
+
+	t.Run("normalize rejects invalid value", func(t *testing.T) {
+		_, err := sc.normalize(json.RawMessage(`{"mode":""}`), 2)
+		require.ErrorIs(t, err, ErrInvalidSettings)
+	})
+}
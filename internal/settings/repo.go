@@ -1,7 +1,9 @@
 package settings
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -14,15 +16,26 @@ type DetailsType string
 const (
 	DetailsTypePushConfig DetailsType = "push_config"
 	DetailsTypeFeedConfig DetailsType = "feed_config"
+	// DetailsTypeAutoFollowConfig describes wallet driven auto subscriptions
+	DetailsTypeAutoFollowConfig DetailsType = "auto_follow_config"
+	// DetailsTypeAchievementsConfig describes displaying user achievements to others
+	DetailsTypeAchievementsConfig DetailsType = "achievements_config"
 )
 
+// AchievementsFlagHideFromLeaderboard is the name of the opt-out flag in the stored value
+const AchievementsFlagHideFromLeaderboard = "hide_from_leaderboard"
+
 type Details struct {
 	UserID    uuid.UUID
 	Type      DetailsType
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt
-	Value     json.RawMessage `gorm:"type:jsonb;serializer:json"`
+	// Version is the schema version of the stored value
+	Version int             `gorm:"column:schema_version"`
+	Value   json.RawMessage `gorm:"type:jsonb;serializer:json"`
+	// Revision is increased on each change
+	Revision int64
 }
 
 type PushSettingsDetails struct {
@@ -30,11 +43,75 @@ type PushSettingsDetails struct {
 	QuorumReached      *bool `json:"quorum_reached,omitempty"`
 	VoteFinishesSoon   *bool `json:"vote_finishes_soon,omitempty"`
 	VoteFinished       *bool `json:"vote_finished,omitempty"`
+	// AchievementUnlocked is opt-in, unset value is treated as disabled
+	AchievementUnlocked *bool `json:"achievement_unlocked,omitempty"`
+}
+
+type PushEventType string
+
+const (
+	PushEventTypeNewProposalCreated  PushEventType = "new_proposal_created"
+	PushEventTypeQuorumReached       PushEventType = "quorum_reached"
+	PushEventTypeVoteFinishesSoon    PushEventType = "vote_finishes_soon"
+	PushEventTypeVoteFinished        PushEventType = "vote_finished"
+	PushEventTypeAchievementUnlocked PushEventType = "achievement_unlocked"
+)
+
+var ErrUnknownPushEventType = errors.New("unknown push event type")
+
+// Enabled returns the setting value by event type, unset values are treated as enabled except opt-in ones
+func (d *PushSettingsDetails) Enabled(et PushEventType) (bool, error) {
+	var val *bool
+	switch et {
+	case PushEventTypeAchievementUnlocked:
+		return d.AchievementUnlocked != nil && *d.AchievementUnlocked, nil
+	case PushEventTypeNewProposalCreated:
+		val = d.NewProposalCreated
+	case PushEventTypeQuorumReached:
+		val = d.QuorumReached
+	case PushEventTypeVoteFinishesSoon:
+		val = d.VoteFinishesSoon
+	case PushEventTypeVoteFinished:
+		val = d.VoteFinished
+	default:
+		return false, fmt.Errorf("%w: %s", ErrUnknownPushEventType, et)
+	}
+
+	return val == nil || *val, nil
 }
 
 type FeedSettings struct {
 	ArchiveProposalAfterVote *bool   `json:"archive_proposal_after_vote,omitempty"`
 	AutoarchiveAfterDuration *string `json:"autoarchive_after_duration,omitempty"`
+	// HideUnverifiedDaos hides proposals of not verified daos from the feed
+	HideUnverifiedDaos *bool       `json:"hide_unverified_daos,omitempty"`
+	DigestMode         *DigestMode `json:"digest_mode,omitempty"`
+	// MutedDaos is managed by mute and unmute methods only, expired items are dropped on reading
+	MutedDaos []MutedDao `json:"muted_daos,omitempty"`
+}
+
+type DigestMode string
+
+const (
+	DigestModeOff    DigestMode = "off"
+	DigestModeDaily  DigestMode = "daily"
+	DigestModeWeekly DigestMode = "weekly"
+)
+
+type MutedDao struct {
+	DaoID uuid.UUID `json:"dao_id"`
+	Until time.Time `json:"until"`
+}
+
+type AutoFollowSettings struct {
+	Enabled *bool `json:"enabled,omitempty"`
+	// AutoUnfollow removes auto subscriptions when the wallet has no positions in the dao
+	AutoUnfollow *bool `json:"auto_unfollow,omitempty"`
+}
+
+type AchievementsSettings struct {
+	// HideFromLeaderboard excludes the user from achievements leaderboards
+	HideFromLeaderboard *bool `json:"hide_from_leaderboard,omitempty"`
 }
 
 func (Details) TableName() string {
@@ -66,17 +143,103 @@ func (r *DetailsRepo) GetByUserAndType(userID uuid.UUID, dt DetailsType) (*Detai
 	return &details, nil
 }
 
-func (r *DetailsRepo) StoreDetails(info *Details) error {
+func (r *DetailsRepo) GetByUser(userID uuid.UUID) ([]Details, error) {
+	var list []Details
+	err := r.db.
+		Where("user_id = ?", userID).
+		Order("type").
+		Find(&list).
+		Error
+	if err != nil {
+		return nil, fmt.Errorf("get user details: %w", err)
+	}
+
+	return list, nil
+}
+
+func (r *DetailsRepo) GetByUsersAndType(userIDs []uuid.UUID, dt DetailsType) ([]Details, error) {
+	var list []Details
+	err := r.db.
+		Where("user_id in ?", userIDs).
+		Where("type = ?", dt).
+		Find(&list).
+		Error
+	if err != nil {
+		return nil, fmt.Errorf("get users details by params: %w", err)
+	}
+
+	return list, nil
+}
+
+// StoreDetails stores settings increasing the revision and adds the event built by the stored details
+// to the outbox in the same transaction
+func (r *DetailsRepo) StoreDetails(info *Details, event func(info *Details) (*OutboxMessage, error)) error {
+	return r.StoreDetailsList([]*Details{info}, event)
+}
+
+// StoreDetailsList works like StoreDetails storing all settings and their events in one transaction
+func (r *DetailsRepo) StoreDetailsList(list []*Details, event func(info *Details) (*OutboxMessage, error)) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, info := range list {
+			if err := upsertDetails(tx, info, event); err != nil {
+				return fmt.Errorf("store %s: %w", info.Type, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func upsertDetails(tx *gorm.DB, info *Details, event func(info *Details) (*OutboxMessage, error)) error {
+	err := tx.
+		Raw(`
+			insert into user_settings (user_id, type, schema_version, value, revision, created_at, updated_at)
+			values (@user_id, @type, @schema_version, cast(@value as jsonb), 1, now(), now())
+			on conflict (user_id, type) do update
+			set schema_version = excluded.schema_version,
+			    value = excluded.value,
+			    revision = user_settings.revision + 1,
+			    updated_at = now(),
+			    deleted_at = null
+			returning revision`,
+			sql.Named("user_id", info.UserID),
+			sql.Named("type", info.Type),
+			sql.Named("schema_version", info.Version),
+			sql.Named("value", string(info.Value)),
+		).
+		Scan(&info.Revision).
+		Error
+	if err != nil {
+		return fmt.Errorf("upsert details: %w", err)
+	}
+
+	msg, err := event(info)
+	if err != nil {
+		return fmt.Errorf("build event: %w", err)
+	}
+
+	if err = tx.Create(msg).Error; err != nil {
+		return fmt.Errorf("create outbox message: %w", err)
+	}
+
+	return nil
+}
+
+// GetUserIDsByEnabledFlag returns users which have enabled boolean flag in settings by type
+func (r *DetailsRepo) GetUserIDsByEnabledFlag(dt DetailsType, flag string, limit, offset int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
 	err := r.db.
 		Model(&Details{}).
-		Where("user_id = ?", info.UserID).
-		Where("type = ?", info.Type).
-		Updates(&Details{
-			UpdatedAt: time.Now(),
-			Value:     info.Value,
-		}).
-		FirstOrCreate(&info).
+		Where("type = ?", dt).
+		Where("(value->>?)::boolean", flag).
+		Order("user_id").
+		Limit(limit).
+		Offset(offset).
+		Pluck("user_id", &ids).
 		Error
+	if err != nil {
+		return nil, fmt.Errorf("get user ids by flag: %w", err)
+	}
 
-	return err
+	return ids, nil
 }
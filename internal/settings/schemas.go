@@ -0,0 +1,63 @@
+package settings
+
+import (
+	"github.com/goverland-labs/goverland-platform-events/events/inbox"
+	"go.openly.dev/pointy"
+)
+
+var pushSchema = register(&Schema[PushSettingsDetails]{
+	Type:    DetailsTypePushConfig,
+	Version: 1,
+	Defaults: func() *PushSettingsDetails {
+		return &PushSettingsDetails{
+			NewProposalCreated:  pointy.Bool(true),
+			QuorumReached:       pointy.Bool(true),
+			VoteFinishesSoon:    pointy.Bool(true),
+			VoteFinished:        pointy.Bool(true),
+			AchievementUnlocked: pointy.Bool(false),
+		}
+	},
+	Subject: SubjectPushSettingsUpdated,
+})
+
+var feedSchema = register(&Schema[FeedSettings]{
+	Type:    DetailsTypeFeedConfig,
+	Version: 2,
+	Defaults: func() *FeedSettings {
+		return &FeedSettings{
+			ArchiveProposalAfterVote: pointy.Bool(true),
+			AutoarchiveAfterDuration: pointy.String(defaultAutoarchiveDuration),
+			HideUnverifiedDaos:       pointy.Bool(false),
+			DigestMode:               pointy.Pointer(DigestModeOff),
+		}
+	},
+	Validate: validateFeedSettings,
+	Upgrades: map[int]Upgrade{
+		1: upgradeFeedSettingsV1,
+	},
+	Subject: inbox.SubjectFeedSettingsUpdated,
+	Payload: feedSettingsPayload,
+})
+
+var autoFollowSchema = register(&Schema[AutoFollowSettings]{
+	Type:    DetailsTypeAutoFollowConfig,
+	Version: 1,
+	Defaults: func() *AutoFollowSettings {
+		return &AutoFollowSettings{
+			Enabled:      pointy.Bool(false),
+			AutoUnfollow: pointy.Bool(false),
+		}
+	},
+	Subject: SubjectAutoFollowSettingsUpdated,
+})
+
+var achievementsSchema = register(&Schema[AchievementsSettings]{
+	Type:    DetailsTypeAchievementsConfig,
+	Version: 1,
+	Defaults: func() *AchievementsSettings {
+		return &AchievementsSettings{
+			HideFromLeaderboard: pointy.Bool(false),
+		}
+	},
+	Subject: SubjectAchievementsSettingsUpdated,
+})
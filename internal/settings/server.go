@@ -8,121 +8,143 @@ import (
 	proto "github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"gorm.io/gorm"
 
+	"github.com/goverland-labs/goverland-inbox-storage/internal/audit"
 	"github.com/goverland-labs/goverland-inbox-storage/internal/user"
+	"github.com/goverland-labs/goverland-inbox-storage/pkg/grpcsrv"
+)
+
+var errorMapper = grpcsrv.NewErrorMapper(
+	grpcsrv.ErrorRule{Err: ErrTokenNotFound, Code: codes.NotFound, Reason: "TOKEN_NOT_FOUND"},
+	grpcsrv.ErrorRule{Err: ErrInvalidSettings, Code: codes.InvalidArgument, Reason: "INVALID_SETTINGS"},
+	grpcsrv.ErrorRule{Err: ErrUnknownDetailsType, Code: codes.InvalidArgument, Reason: "UNKNOWN_SETTINGS_TYPE"},
+	grpcsrv.ErrorRule{Err: ErrUnknownPushEventType, Code: codes.InvalidArgument, Reason: "UNKNOWN_PUSH_EVENT_TYPE"},
+	grpcsrv.ErrorRule{Err: ErrTooManyUsers, Code: codes.ResourceExhausted, Reason: "TOO_MANY_USERS"},
 )
 
 type UserProvider interface {
 	GetByID(id uuid.UUID) (*user.User, error)
+	GetLastSessionByDevice(userID uuid.UUID, deviceUUID string) (*user.Session, error)
+}
+
+type Auditor interface {
+	Record(ctx context.Context, userID uuid.UUID, op audit.Operation, before, after any)
+}
+
+// pushTokenAudit is stored in the audit log with the redacted token
+type pushTokenAudit struct {
+	DeviceUUID string `json:"device_uuid"`
+	Token      string `json:"token"`
 }
 
 type Server struct {
 	proto.UnimplementedSettingsServer
 
-	sp    *Service
-	users UserProvider
+	sp      *Service
+	users   UserProvider
+	auditor Auditor
 }
 
-func NewServer(s *Service, up UserProvider) *Server {
+func NewServer(s *Service, up UserProvider, a Auditor) *Server {
 	return &Server{
-		users: up,
-		sp:    s,
+		users:   up,
+		sp:      s,
+		auditor: a,
 	}
 }
 
-func (s *Server) AddPushToken(_ context.Context, req *proto.AddPushTokenRequest) (*emptypb.Empty, error) {
-	userID, err := uuid.Parse(req.GetUserId())
+// getTokenAudit returns the current token state for the audit log, nil if there is no token
+func (s *Server) getTokenAudit(userID, deviceUUID string) *pushTokenAudit {
+	token, err := s.sp.GetByUserAndDevice(userID, deviceUUID)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+		return nil
+	}
+
+	return &pushTokenAudit{
+		DeviceUUID: deviceUUID,
+		Token:      audit.RedactToken(token),
 	}
+}
 
+func (s *Server) AddPushToken(ctx context.Context, req *proto.AddPushTokenRequest) (*emptypb.Empty, error) {
 	if req.GetToken() == "" {
-		return nil, status.Error(codes.InvalidArgument, "invalid token")
+		return nil, grpcsrv.InvalidArgument("token", "must not be empty")
 	}
 
 	if req.GetDeviceUuid() == "" {
-		return nil, status.Error(codes.InvalidArgument, "invalid device uuid")
+		return nil, grpcsrv.InvalidArgument("device_uuid", "must not be empty")
 	}
 
-	if _, err := s.users.GetByID(userID); err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+	userID, err := s.getUserID(req.GetUserId())
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.sp.Upsert(req.GetUserId(), req.GetDeviceUuid(), req.GetToken()); err != nil {
-		log.Error().Err(err).Msgf("upsert token for user: %s", req.GetUserId())
+	var meta TokenMetadata
+	session, err := s.users.GetLastSessionByDevice(userID, req.GetDeviceUuid())
+	switch {
+	case err == nil:
+		meta.Platform = session.AppPlatform
+		meta.AppVersion = session.AppVersion
+	case errors.Is(err, gorm.ErrRecordNotFound):
+	default:
+		log.Warn().Err(err).Msgf("get session for push token metadata: %s", req.GetUserId())
+	}
 
-		return nil, status.Error(codes.Internal, "internal error")
+	before := s.getTokenAudit(req.GetUserId(), req.GetDeviceUuid())
+	if err := s.sp.Upsert(req.GetUserId(), req.GetDeviceUuid(), req.GetToken(), meta); err != nil {
+		return nil, errorMapper.Error(err)
 	}
 
+	s.auditor.Record(ctx, userID, audit.OperationAddPushToken, before, pushTokenAudit{
+		DeviceUUID: req.GetDeviceUuid(),
+		Token:      audit.RedactToken(req.GetToken()),
+	})
+
 	return &emptypb.Empty{}, nil
 }
 
-func (s *Server) RemovePushToken(_ context.Context, req *proto.RemovePushTokenRequest) (*emptypb.Empty, error) {
-	userID, err := uuid.Parse(req.GetUserId())
+func (s *Server) RemovePushToken(ctx context.Context, req *proto.RemovePushTokenRequest) (*emptypb.Empty, error) {
+	userID, err := s.getUserID(req.GetUserId())
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user ID")
-	}
-
-	if _, err := s.users.GetByID(userID); err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+		return nil, err
 	}
 
+	before := s.getTokenAudit(req.GetUserId(), req.GetDeviceUuid())
 	if err := s.sp.DeleteByUserID(req.GetUserId(), req.GetDeviceUuid()); err != nil {
-		log.Error().Err(err).Msgf("delete token for user: %s", req.GetUserId())
-
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, errorMapper.Error(err)
 	}
 
+	s.auditor.Record(ctx, userID, audit.OperationRemovePushToken, before, nil)
+
 	return &emptypb.Empty{}, nil
 }
 
 func (s *Server) PushTokenExists(_ context.Context, req *proto.PushTokenExistsRequest) (*proto.PushTokenExistsResponse, error) {
-	userID, err := uuid.Parse(req.GetUserId())
-	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user ID")
-	}
-
-	if _, err := s.users.GetByID(userID); err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+	if _, err := s.getUserID(req.GetUserId()); err != nil {
+		return nil, err
 	}
 
-	_, err = s.sp.GetByUserAndDevice(req.GetUserId(), req.GetDeviceUuid())
+	_, err := s.sp.GetByUserAndDevice(req.GetUserId(), req.GetDeviceUuid())
 	if err != nil && !errors.Is(err, ErrTokenNotFound) {
-		log.Error().Err(err).Msgf("get token for user: %s", req.GetUserId())
-
-		return nil, status.Error(codes.Internal, "internal error")
-	}
-
-	exists := true
-	if errors.Is(err, ErrTokenNotFound) {
-		exists = false
+		return nil, errorMapper.Error(err)
 	}
 
 	return &proto.PushTokenExistsResponse{
-		Exists: exists,
+		Exists: err == nil,
 	}, nil
 }
 
 func (s *Server) GetPushToken(_ context.Context, req *proto.GetPushTokenRequest) (*proto.PushTokenResponse, error) {
-	userID, err := uuid.Parse(req.GetUserId())
-	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user ID")
-	}
-
-	if _, err := s.users.GetByID(userID); err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+	if _, err := s.getUserID(req.GetUserId()); err != nil {
+		return nil, err
 	}
 
 	token, err := s.sp.GetByUserAndDevice(req.GetUserId(), req.GetDeviceUuid())
 	if err != nil {
-		if !errors.Is(err, ErrTokenNotFound) {
-			return nil, status.Error(codes.InvalidArgument, "invalid user ID")
-		}
-
-		log.Error().Err(err).Msgf("get token for user: %s", req.GetUserId())
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, errorMapper.Error(err)
 	}
 
 	return &proto.PushTokenResponse{
@@ -131,9 +153,13 @@ func (s *Server) GetPushToken(_ context.Context, req *proto.GetPushTokenRequest)
 }
 
 func (s *Server) GetPushTokenList(_ context.Context, req *proto.GetPushTokenListRequest) (*proto.PushTokenListResponse, error) {
+	if _, err := grpcsrv.ParseUUID("user_id", req.GetUserId()); err != nil {
+		return nil, err
+	}
+
 	list, err := s.sp.GetListByUserID(req.GetUserId())
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, errorMapper.Error(err)
 	}
 
 	resp := &proto.PushTokenListResponse{
@@ -149,26 +175,55 @@ func (s *Server) GetPushTokenList(_ context.Context, req *proto.GetPushTokenList
 	return resp, nil
 }
 
-func (s *Server) SetPushDetails(_ context.Context, req *proto.SetPushDetailsRequest) (*emptypb.Empty, error) {
+func (s *Server) SetPushDetails(ctx context.Context, req *proto.SetPushDetailsRequest) (*emptypb.Empty, error) {
+	userID, err := grpcsrv.ParseUUID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := s.sp.GetPushDetails(userID)
+	if err != nil {
+		return nil, errorMapper.Error(err)
+	}
+
+	// missing settings reset all push settings to defaults
+	dao := req.GetDao()
+	if dao == nil {
+		dao = &proto.PushSettingsDao{}
+	}
+
 	details := PushSettingsDetails{
-		NewProposalCreated: req.GetDao().NewProposalCreated,
-		QuorumReached:      req.GetDao().QuorumReached,
-		VoteFinishesSoon:   req.GetDao().VoteFinishesSoon,
-		VoteFinished:       req.GetDao().VoteFinished,
+		NewProposalCreated: dao.NewProposalCreated,
+		QuorumReached:      dao.QuorumReached,
+		VoteFinishesSoon:   dao.VoteFinishesSoon,
+		VoteFinished:       dao.VoteFinished,
+		// the request has no opt-in settings, so the stored value is kept
+		AchievementUnlocked: before.AchievementUnlocked,
+	}
+
+	if err = s.sp.StorePushDetails(userID, details); err != nil {
+		return nil, errorMapper.Error(err)
 	}
 
-	err := s.sp.StorePushDetails(uuid.MustParse(req.GetUserId()), details)
+	after, err := s.sp.GetPushDetails(userID)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		log.Error().Err(err).Msgf("get settings for audit: %s", userID)
 	}
 
+	s.auditor.Record(ctx, userID, audit.OperationSetPushDetails, before, after)
+
 	return &emptypb.Empty{}, nil
 }
 
 func (s *Server) GetPushDetails(_ context.Context, req *proto.GetPushDetailsRequest) (*proto.GetPushDetailsResponse, error) {
-	details, err := s.sp.GetPushDetails(uuid.MustParse(req.GetUserId()))
+	userID, err := grpcsrv.ParseUUID("user_id", req.GetUserId())
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, err
+	}
+
+	details, err := s.sp.GetPushDetails(userID)
+	if err != nil {
+		return nil, errorMapper.Error(err)
 	}
 
 	return &proto.GetPushDetailsResponse{
@@ -182,24 +237,51 @@ func (s *Server) GetPushDetails(_ context.Context, req *proto.GetPushDetailsRequ
 	}, nil
 }
 
-func (s *Server) SetFeedSettings(_ context.Context, req *proto.SetFeedSettingsRequest) (*emptypb.Empty, error) {
+func (s *Server) SetFeedSettings(ctx context.Context, req *proto.SetFeedSettingsRequest) (*emptypb.Empty, error) {
+	userID, err := grpcsrv.ParseUUID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	// missing settings reset all feed settings to defaults
+	fs := req.GetFeedSettings()
+	if fs == nil {
+		fs = &proto.FeedSettings{}
+	}
+
 	details := FeedSettings{
-		ArchiveProposalAfterVote: req.GetFeedSettings().ArchiveProposalAfterVote,
-		AutoarchiveAfterDuration: req.GetFeedSettings().AutoarchiveAfterDuration,
+		ArchiveProposalAfterVote: fs.ArchiveProposalAfterVote,
+		AutoarchiveAfterDuration: fs.AutoarchiveAfterDuration,
 	}
 
-	err := s.sp.StoreFeedSettings(uuid.MustParse(req.GetUserId()), details)
+	before, err := s.sp.GetFeedSettings(userID)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, errorMapper.Error(err)
 	}
 
+	if err = s.sp.StoreFeedSettings(userID, details); err != nil {
+		return nil, errorMapper.Error(err)
+	}
+
+	after, err := s.sp.GetFeedSettings(userID)
+	if err != nil {
+		log.Error().Err(err).Msgf("get settings for audit: %s", userID)
+	}
+
+	s.auditor.Record(ctx, userID, audit.OperationSetFeedSettings, before, after)
+
 	return &emptypb.Empty{}, nil
 }
 
 func (s *Server) GetFeedSettings(_ context.Context, req *proto.GetFeedSettingsRequest) (*proto.GetFeedSettingsResponse, error) {
-	fs, err := s.sp.GetFeedSettings(uuid.MustParse(req.GetUserId()))
+	userID, err := grpcsrv.ParseUUID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	fs, err := s.sp.GetFeedSettings(userID)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, errorMapper.Error(err)
 	}
 
 	return &proto.GetFeedSettingsResponse{
@@ -210,3 +292,17 @@ func (s *Server) GetFeedSettings(_ context.Context, req *proto.GetFeedSettingsRe
 		},
 	}, nil
 }
+
+// getUserID parses user id and checks that the user exists
+func (s *Server) getUserID(id string) (uuid.UUID, error) {
+	userID, err := grpcsrv.ParseUUID("user_id", id)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if _, err = s.users.GetByID(userID); err != nil {
+		return uuid.Nil, errorMapper.Error(err)
+	}
+
+	return userID, nil
+}
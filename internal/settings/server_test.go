@@ -0,0 +1,583 @@
+package settings
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	proto "github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
+	"github.com/stretchr/testify/require"
+	"go.openly.dev/pointy"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-inbox-storage/internal/audit"
+	"github.com/goverland-labs/goverland-inbox-storage/internal/user"
+	"github.com/goverland-labs/goverland-inbox-storage/pkg/grpcsrv"
+)
+
+var errBackend = errors.New("backend is down")
+
+type tokenKey struct {
+	userID     string
+	deviceUUID string
+}
+
+type fakeTokens struct {
+	tokens map[tokenKey]string
+	err    error
+}
+
+func (f *fakeTokens) GetByUserID(userID string) (string, error) {
+	return f.GetByUserAndDevice(userID, defaultDeviceUUID)
+}
+
+func (f *fakeTokens) GetByUserAndDevice(userID, deviceUUID string) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+
+	token, ok := f.tokens[tokenKey{userID: userID, deviceUUID: deviceUUID}]
+	if !ok {
+		return "", ErrTokenNotFound
+	}
+
+	return token, nil
+}
+
+func (f *fakeTokens) GetListByUserID(userID string) ([]PushDetails, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	var list []PushDetails
+	for key, token := range f.tokens {
+		if key.userID == userID {
+			list = append(list, PushDetails{DeviceUUID: key.deviceUUID, Token: token})
+		}
+	}
+
+	return list, nil
+}
+
+func (f *fakeTokens) Save(userID, deviceUUID, token string) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	f.tokens[tokenKey{userID: userID, deviceUUID: deviceUUID}] = token
+
+	return nil
+}
+
+func (f *fakeTokens) Delete(userID, deviceUUID string) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	delete(f.tokens, tokenKey{userID: userID, deviceUUID: deviceUUID})
+
+	return nil
+}
+
+type fakeMetadata struct{}
+
+func (fakeMetadata) Upsert(*PushTokenMetadata) error                      { return nil }
+func (fakeMetadata) GetByUser(string) ([]PushTokenMetadata, error)        { return nil, nil }
+func (fakeMetadata) GetByUsers([]string) ([]PushTokenMetadata, error)     { return nil, nil }
+func (fakeMetadata) MarkInvalidated(string, string, string) error         { return nil }
+func (fakeMetadata) Delete(string, string) error                          { return nil }
+func (fakeMetadata) GetStale(time.Time, int) ([]PushTokenMetadata, error) { return nil, nil }
+
+type fakeDetails struct {
+	values map[DetailsType]*Details
+	err    error
+}
+
+func (f *fakeDetails) GetByUserAndType(_ uuid.UUID, dt DetailsType) (*Details, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	if info, ok := f.values[dt]; ok {
+		return info, nil
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (f *fakeDetails) GetByUser(uuid.UUID) ([]Details, error) { return nil, f.err }
+
+func (f *fakeDetails) GetByUsersAndType([]uuid.UUID, DetailsType) ([]Details, error) {
+	return nil, f.err
+}
+
+func (f *fakeDetails) StoreDetails(info *Details, _ func(info *Details) (*OutboxMessage, error)) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	if f.values != nil {
+		f.values[info.Type] = info
+	}
+
+	return nil
+}
+
+func (f *fakeDetails) StoreDetailsList([]*Details, func(info *Details) (*OutboxMessage, error)) error {
+	return f.err
+}
+
+func (f *fakeDetails) GetUserIDsByEnabledFlag(DetailsType, string, int, int) ([]uuid.UUID, error) {
+	return nil, f.err
+}
+
+type fakeUsers struct {
+	known uuid.UUID
+}
+
+func (f *fakeUsers) GetByID(id uuid.UUID) (*user.User, error) {
+	if id != f.known {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return &user.User{ID: id}, nil
+}
+
+func (f *fakeUsers) GetLastSessionByDevice(uuid.UUID, string) (*user.Session, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+type fakePublisher struct{}
+
+func (fakePublisher) PublishJSON(context.Context, string, any) error { return nil }
+
+type fakeAuditor struct{}
+
+func (fakeAuditor) Record(context.Context, uuid.UUID, audit.Operation, any, any) {}
+
+func newTestServer(userID uuid.UUID, tokens *fakeTokens, details *fakeDetails) *Server {
+	service := NewService(tokens, fakeMetadata{}, details, nil, fakePublisher{})
+
+	return NewServer(service, &fakeUsers{known: userID}, fakeAuditor{})
+}
+
+func TestUnitServerAddPushToken(t *testing.T) {
+	userID := uuid.New()
+
+	for name, tc := range map[string]struct {
+		req    *proto.AddPushTokenRequest
+		err    error
+		code   codes.Code
+		reason string
+		field  string
+	}{
+		"new token": {
+			req: &proto.AddPushTokenRequest{UserId: userID.String(), Token: "new-token", DeviceUuid: "device"},
+		},
+		"empty token": {
+			req:   &proto.AddPushTokenRequest{UserId: userID.String(), DeviceUuid: "device"},
+			code:  codes.InvalidArgument,
+			field: "token",
+		},
+		"empty device uuid": {
+			req:   &proto.AddPushTokenRequest{UserId: userID.String(), Token: "new-token"},
+			code:  codes.InvalidArgument,
+			field: "device_uuid",
+		},
+		"invalid user id": {
+			req:   &proto.AddPushTokenRequest{UserId: "wrong", Token: "new-token", DeviceUuid: "device"},
+			code:  codes.InvalidArgument,
+			field: "user_id",
+		},
+		"unknown user": {
+			req:    &proto.AddPushTokenRequest{UserId: uuid.NewString(), Token: "new-token", DeviceUuid: "device"},
+			code:   codes.NotFound,
+			reason: "NOT_FOUND",
+		},
+		"backend error": {
+			req:  &proto.AddPushTokenRequest{UserId: userID.String(), Token: "new-token", DeviceUuid: "device"},
+			err:  errBackend,
+			code: codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tokens := &fakeTokens{tokens: map[tokenKey]string{}, err: tc.err}
+
+			_, err := newTestServer(userID, tokens, &fakeDetails{}).AddPushToken(context.Background(), tc.req)
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.reason, grpcsrv.ErrorReason(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.code == codes.OK {
+				require.Equal(t, map[tokenKey]string{{userID: userID.String(), deviceUUID: "device"}: "new-token"}, tokens.tokens)
+			} else {
+				require.Empty(t, tokens.tokens)
+			}
+		})
+	}
+}
+
+func TestUnitServerRemovePushToken(t *testing.T) {
+	userID := uuid.New()
+
+	for name, tc := range map[string]struct {
+		userID string
+		err    error
+		code   codes.Code
+		reason string
+		field  string
+	}{
+		"removed": {
+			userID: userID.String(),
+		},
+		"empty user id": {
+			userID: "",
+			code:   codes.InvalidArgument,
+			field:  "user_id",
+		},
+		"unknown user": {
+			userID: uuid.NewString(),
+			code:   codes.NotFound,
+			reason: "NOT_FOUND",
+		},
+		"backend error": {
+			userID: userID.String(),
+			err:    errBackend,
+			code:   codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tokens := &fakeTokens{
+				tokens: map[tokenKey]string{{userID: userID.String(), deviceUUID: "device"}: "token"},
+				err:    tc.err,
+			}
+
+			_, err := newTestServer(userID, tokens, &fakeDetails{}).RemovePushToken(context.Background(), &proto.RemovePushTokenRequest{
+				UserId:     tc.userID,
+				DeviceUuid: "device",
+			})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.reason, grpcsrv.ErrorReason(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			require.Equal(t, tc.code == codes.OK, len(tokens.tokens) == 0)
+		})
+	}
+}
+
+func TestUnitServerGetPushToken(t *testing.T) {
+	userID := uuid.New()
+
+	for name, tc := range map[string]struct {
+		userID     string
+		deviceUUID string
+		err        error
+		expected   string
+		code       codes.Code
+		reason     string
+		field      string
+	}{
+		"token": {
+			userID:     userID.String(),
+			deviceUUID: "device",
+			expected:   "token",
+		},
+		"unknown device": {
+			userID:     userID.String(),
+			deviceUUID: "unknown",
+			code:       codes.NotFound,
+			reason:     "TOKEN_NOT_FOUND",
+		},
+		"invalid user id": {
+			userID:     "wrong",
+			deviceUUID: "device",
+			code:       codes.InvalidArgument,
+			field:      "user_id",
+		},
+		"unknown user": {
+			userID:     uuid.NewString(),
+			deviceUUID: "device",
+			code:       codes.NotFound,
+			reason:     "NOT_FOUND",
+		},
+		"backend error": {
+			userID:     userID.String(),
+			deviceUUID: "device",
+			err:        errBackend,
+			code:       codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tokens := &fakeTokens{
+				tokens: map[tokenKey]string{{userID: userID.String(), deviceUUID: "device"}: "token"},
+				err:    tc.err,
+			}
+
+			res, err := newTestServer(userID, tokens, &fakeDetails{}).GetPushToken(context.Background(), &proto.GetPushTokenRequest{
+				UserId:     tc.userID,
+				DeviceUuid: tc.deviceUUID,
+			})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.reason, grpcsrv.ErrorReason(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			require.Equal(t, tc.expected, res.GetToken())
+		})
+	}
+}
+
+func TestUnitServerPushTokenExists(t *testing.T) {
+	userID := uuid.New()
+
+	for name, tc := range map[string]struct {
+		userID     string
+		deviceUUID string
+		err        error
+		expected   bool
+		code       codes.Code
+		reason     string
+		field      string
+	}{
+		"exists": {
+			userID:     userID.String(),
+			deviceUUID: "device",
+			expected:   true,
+		},
+		"unknown device": {
+			userID:     userID.String(),
+			deviceUUID: "unknown",
+			expected:   false,
+		},
+		"invalid user id": {
+			userID:     "wrong",
+			deviceUUID: "device",
+			code:       codes.InvalidArgument,
+			field:      "user_id",
+		},
+		"unknown user": {
+			userID:     uuid.NewString(),
+			deviceUUID: "device",
+			code:       codes.NotFound,
+			reason:     "NOT_FOUND",
+		},
+		"backend error": {
+			userID:     userID.String(),
+			deviceUUID: "device",
+			err:        errBackend,
+			code:       codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tokens := &fakeTokens{
+				tokens: map[tokenKey]string{{userID: userID.String(), deviceUUID: "device"}: "token"},
+				err:    tc.err,
+			}
+
+			res, err := newTestServer(userID, tokens, &fakeDetails{}).PushTokenExists(context.Background(), &proto.PushTokenExistsRequest{
+				UserId:     tc.userID,
+				DeviceUuid: tc.deviceUUID,
+			})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.reason, grpcsrv.ErrorReason(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			require.Equal(t, tc.expected, res.GetExists())
+		})
+	}
+}
+
+func TestUnitServerGetPushTokenList(t *testing.T) {
+	userID := uuid.New()
+
+	for name, tc := range map[string]struct {
+		userID   string
+		err      error
+		expected []*proto.PushTokenDetails
+		code     codes.Code
+		field    string
+	}{
+		"tokens": {
+			userID:   userID.String(),
+			expected: []*proto.PushTokenDetails{{Token: "token", DeviceUuid: "device"}},
+		},
+		"invalid user id": {
+			userID: "wrong",
+			code:   codes.InvalidArgument,
+			field:  "user_id",
+		},
+		"backend error": {
+			userID: userID.String(),
+			err:    errBackend,
+			code:   codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tokens := &fakeTokens{
+				tokens: map[tokenKey]string{{userID: userID.String(), deviceUUID: "device"}: "token"},
+				err:    tc.err,
+			}
+
+			res, err := newTestServer(userID, tokens, &fakeDetails{}).GetPushTokenList(context.Background(), &proto.GetPushTokenListRequest{
+				UserId: tc.userID,
+			})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			require.Len(t, res.GetTokens(), len(tc.expected))
+			for i, details := range res.GetTokens() {
+				require.Equal(t, tc.expected[i].GetToken(), details.GetToken())
+				require.Equal(t, tc.expected[i].GetDeviceUuid(), details.GetDeviceUuid())
+			}
+		})
+	}
+}
+
+func TestUnitServerPushDetails(t *testing.T) {
+	userID := uuid.New()
+
+	for name, tc := range map[string]struct {
+		userID   string
+		dao      *proto.PushSettingsDao
+		err      error
+		expected *proto.PushSettingsDao
+		code     codes.Code
+		field    string
+	}{
+		"stored": {
+			userID: userID.String(),
+			dao:    &proto.PushSettingsDao{QuorumReached: pointy.Bool(false), VoteFinished: pointy.Bool(false)},
+			expected: &proto.PushSettingsDao{
+				NewProposalCreated: pointy.Bool(true),
+				QuorumReached:      pointy.Bool(false),
+				VoteFinishesSoon:   pointy.Bool(true),
+				VoteFinished:       pointy.Bool(false),
+			},
+		},
+		"empty settings are defaults": {
+			userID: userID.String(),
+			expected: &proto.PushSettingsDao{
+				NewProposalCreated: pointy.Bool(true),
+				QuorumReached:      pointy.Bool(true),
+				VoteFinishesSoon:   pointy.Bool(true),
+				VoteFinished:       pointy.Bool(true),
+			},
+		},
+		"invalid user id": {
+			userID: "wrong",
+			code:   codes.InvalidArgument,
+			field:  "user_id",
+		},
+		"backend error": {
+			userID: userID.String(),
+			err:    errBackend,
+			code:   codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := newTestServer(userID, &fakeTokens{}, &fakeDetails{values: map[DetailsType]*Details{}, err: tc.err})
+
+			_, err := server.SetPushDetails(context.Background(), &proto.SetPushDetailsRequest{UserId: tc.userID, Dao: tc.dao})
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+
+			res, err := server.GetPushDetails(context.Background(), &proto.GetPushDetailsRequest{UserId: tc.userID})
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.code != codes.OK {
+				return
+			}
+
+			require.Equal(t, tc.userID, res.GetUserId())
+			require.Equal(t, tc.expected.NewProposalCreated, res.GetDao().NewProposalCreated)
+			require.Equal(t, tc.expected.QuorumReached, res.GetDao().QuorumReached)
+			require.Equal(t, tc.expected.VoteFinishesSoon, res.GetDao().VoteFinishesSoon)
+			require.Equal(t, tc.expected.VoteFinished, res.GetDao().VoteFinished)
+		})
+	}
+}
+
+func TestUnitServerFeedSettings(t *testing.T) {
+	userID := uuid.New()
+
+	for name, tc := range map[string]struct {
+		userID   string
+		settings *proto.FeedSettings
+		err      error
+		expected *proto.FeedSettings
+		code     codes.Code
+		reason   string
+		field    string
+	}{
+		"stored": {
+			userID:   userID.String(),
+			settings: &proto.FeedSettings{ArchiveProposalAfterVote: pointy.Bool(false), AutoarchiveAfterDuration: pointy.String("7d")},
+			expected: &proto.FeedSettings{ArchiveProposalAfterVote: pointy.Bool(false), AutoarchiveAfterDuration: pointy.String("7d")},
+		},
+		"unsupported autoarchive duration": {
+			userID:   userID.String(),
+			settings: &proto.FeedSettings{AutoarchiveAfterDuration: pointy.String("1w")},
+			code:     codes.InvalidArgument,
+			reason:   "INVALID_SETTINGS",
+		},
+		"invalid user id": {
+			userID: "wrong",
+			code:   codes.InvalidArgument,
+			field:  "user_id",
+		},
+		"backend error": {
+			userID: userID.String(),
+			err:    errBackend,
+			code:   codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := newTestServer(userID, &fakeTokens{}, &fakeDetails{values: map[DetailsType]*Details{}, err: tc.err})
+
+			_, err := server.SetFeedSettings(context.Background(), &proto.SetFeedSettingsRequest{UserId: tc.userID, FeedSettings: tc.settings})
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.reason, grpcsrv.ErrorReason(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.expected == nil {
+				return
+			}
+
+			res, err := server.GetFeedSettings(context.Background(), &proto.GetFeedSettingsRequest{UserId: tc.userID})
+			require.NoError(t, err)
+			require.Equal(t, tc.userID, res.GetUserId())
+			require.Equal(t, tc.expected.ArchiveProposalAfterVote, res.GetFeedSettings().ArchiveProposalAfterVote)
+			require.Equal(t, tc.expected.AutoarchiveAfterDuration, res.GetFeedSettings().AutoarchiveAfterDuration)
+		})
+	}
+}
+
+func TestUnitSetPushDetailsKeepsAchievementUnlocked(t *testing.T) {
+	userID := uuid.New()
+	details := &fakeDetails{values: map[DetailsType]*Details{}}
+	service := NewService(&fakeTokens{}, fakeMetadata{}, details, nil, fakePublisher{})
+	server := NewServer(service, &fakeUsers{known: userID}, fakeAuditor{})
+
+	require.NoError(t, service.SetAchievementUnlockedPush(userID, true))
+
+	_, err := server.SetPushDetails(context.Background(), &proto.SetPushDetailsRequest{
+		UserId: userID.String(),
+		Dao:    &proto.PushSettingsDao{QuorumReached: pointy.Bool(false)},
+	})
+	require.NoError(t, err)
+
+	psd, err := service.GetPushDetails(userID)
+	require.NoError(t, err)
+	require.Equal(t, pointy.Bool(true), psd.AchievementUnlocked)
+	require.Equal(t, pointy.Bool(false), psd.QuorumReached)
+	require.Equal(t, pointy.Bool(true), psd.VoteFinished)
+
+	require.NoError(t, service.SetAchievementUnlockedPush(userID, false))
+
+	psd, err = service.GetPushDetails(userID)
+	require.NoError(t, err)
+	require.Equal(t, pointy.Bool(false), psd.AchievementUnlocked)
+	require.Equal(t, pointy.Bool(false), psd.QuorumReached)
+}
@@ -5,26 +5,23 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
-	"github.com/goverland-labs/goverland-platform-events/events/inbox"
 	"github.com/rs/zerolog/log"
-	"go.openly.dev/pointy"
 	"gorm.io/gorm"
-)
 
-var (
-	availableAutoArchiveDurations = map[string]int{
-		"1d":  1,
-		"3d":  3,
-		"7d":  7,
-		"30d": 30,
-	}
+	"github.com/goverland-labs/goverland-inbox-storage/internal/achievements/recalc"
+	"github.com/goverland-labs/goverland-inbox-storage/internal/subscription"
 )
 
 type DetailsManipulator interface {
 	GetByUserAndType(userID uuid.UUID, dt DetailsType) (*Details, error)
-	StoreDetails(info *Details) error
+	GetByUser(userID uuid.UUID) ([]Details, error)
+	GetByUsersAndType(userIDs []uuid.UUID, dt DetailsType) ([]Details, error)
+	StoreDetails(info *Details, event func(info *Details) (*OutboxMessage, error)) error
+	StoreDetailsList(list []*Details, event func(info *Details) (*OutboxMessage, error)) error
+	GetUserIDsByEnabledFlag(dt DetailsType, flag string, limit, offset int) ([]uuid.UUID, error)
 }
 
 type TokenProvider interface {
@@ -35,21 +32,41 @@ type TokenProvider interface {
 	Delete(userID, deviceUUID string) error
 }
 
-type Publisher interface {
-	PublishJSON(ctx context.Context, subject string, obj any) error
+// BulkTokenProvider is implemented by storages which are able to read tokens of many users at once.
+// Postgres reads them in one query, Vault requests users in parallel.
+type BulkTokenProvider interface {
+	GetListByUserIDs(userIDs []string) (map[string][]PushDetails, error)
+}
+
+type MetadataManipulator interface {
+	Upsert(info *PushTokenMetadata) error
+	GetByUser(userID string) ([]PushTokenMetadata, error)
+	GetByUsers(userIDs []string) ([]PushTokenMetadata, error)
+	MarkInvalidated(userID, deviceUUID, reason string) error
+	Delete(userID, deviceUUID string) error
+	GetStale(before time.Time, limit int) ([]PushTokenMetadata, error)
+}
+
+type SubscriptionProvider interface {
+	GetBySubscriberAndDaoID(subscriberID, daoID uuid.UUID) (*subscription.UserSubscription, error)
+	GetByFilters(filters []subscription.Filter) (subscription.UserSubscriptionList, error)
 }
 
 type Service struct {
-	tokens    TokenProvider
-	details   DetailsManipulator
-	publisher Publisher
+	tokens        TokenProvider
+	metadata      MetadataManipulator
+	details       DetailsManipulator
+	subscriptions SubscriptionProvider
+	publisher     recalc.Publisher
 }
 
-func NewService(t TokenProvider, dm DetailsManipulator, publisher Publisher) *Service {
+func NewService(t TokenProvider, mm MetadataManipulator, dm DetailsManipulator, sp SubscriptionProvider, pb recalc.Publisher) *Service {
 	return &Service{
-		tokens:    t,
-		details:   dm,
-		publisher: publisher,
+		tokens:        t,
+		metadata:      mm,
+		details:       dm,
+		subscriptions: sp,
+		publisher:     pb,
 	}
 }
 
@@ -58,174 +75,353 @@ func (s *Service) GetByUserAndDevice(userID, deviceUUID string) (string, error)
 }
 
 func (s *Service) DeleteByUserID(userID, deviceUUID string) error {
-	return s.tokens.Delete(userID, deviceUUID)
+	if err := s.tokens.Delete(userID, deviceUUID); err != nil {
+		return err
+	}
+
+	if err := s.metadata.Delete(userID, deviceUUID); err != nil {
+		return fmt.Errorf("delete token metadata: %s: %w", userID, err)
+	}
+
+	return nil
 }
 
-func (s *Service) Upsert(userID, deviceUUID, token string) error {
+func (s *Service) Upsert(userID, deviceUUID, token string, meta TokenMetadata) error {
 	if err := s.tokens.Save(userID, deviceUUID, token); err != nil {
 		return fmt.Errorf("save token: %s: %w", userID, err)
 	}
 
+	if meta.Provider == "" {
+		meta.Provider = ProviderByPlatform(meta.Platform)
+	}
+
+	now := time.Now()
+	err := s.metadata.Upsert(&PushTokenMetadata{
+		UserID:      userID,
+		DeviceUUID:  deviceUUID,
+		Provider:    meta.Provider,
+		Platform:    meta.Platform,
+		AppVersion:  meta.AppVersion,
+		CreatedAt:   now,
+		RefreshedAt: now,
+	})
+	if err != nil {
+		return fmt.Errorf("save token metadata: %s: %w", userID, err)
+	}
+
+	if id, err := uuid.Parse(userID); err == nil {
+		recalc.Request(context.TODO(), s.publisher, id, recalc.TypePushEnabled)
+	}
+
 	return nil
 }
 
+// CountPushTokens returns the number of devices with push notifications enabled
+func (s *Service) CountPushTokens(userID uuid.UUID) (int, error) {
+	list, err := s.tokens.GetListByUserID(userID.String())
+	if err != nil {
+		return 0, fmt.Errorf("get token list: %w", err)
+	}
+
+	return len(list), nil
+}
+
 func (s *Service) GetListByUserID(userID string) ([]PushDetails, error) {
 	list, err := s.tokens.GetListByUserID(userID)
 	if err != nil {
 		return nil, fmt.Errorf("get token list: %w", err)
 	}
 
+	metadata, err := s.metadata.GetByUser(userID)
+	if err != nil {
+		return nil, fmt.Errorf("get token metadata: %w", err)
+	}
+
+	byDevice := make(map[string]PushTokenMetadata, len(metadata))
+	for _, info := range metadata {
+		byDevice[info.DeviceUUID] = info
+	}
+
+	for i := range list {
+		info, ok := byDevice[list[i].DeviceUUID]
+		if !ok {
+			continue
+		}
+
+		list[i].Metadata = &info
+	}
+
 	return list, nil
 }
 
-func (s *Service) GetPushDetails(userID uuid.UUID) (*PushSettingsDetails, error) {
-	details, err := s.details.GetByUserAndType(userID, DetailsTypePushConfig)
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		// default logic for getting pushes
-		return getPushDefaultSettings(), nil
+// InvalidateToken removes the token reported by the sender as rejected by the push provider.
+// The token is kept if the device has already registered another one.
+func (s *Service) InvalidateToken(userID, deviceUUID, token, reason string) error {
+	stored, err := s.tokens.GetByUserAndDevice(userID, deviceUUID)
+	if errors.Is(err, ErrTokenNotFound) {
+		return nil
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("get push details: %w", err)
+		return fmt.Errorf("get token: %s: %w", userID, err)
+	}
+
+	if token != "" && stored != token {
+		log.Info().Msgf("skip invalidation of refreshed token: %s/%s", userID, deviceUUID)
+
+		return nil
+	}
+
+	if err = s.tokens.Delete(userID, deviceUUID); err != nil {
+		return fmt.Errorf("delete token: %s: %w", userID, err)
 	}
 
-	var psd PushSettingsDetails
-	if err = json.Unmarshal(details.Value, &psd); err != nil {
-		return nil, fmt.Errorf("unmarshal push details: %w", err)
+	if err = s.metadata.MarkInvalidated(userID, deviceUUID, reason); err != nil {
+		return fmt.Errorf("mark token invalidated: %s: %w", userID, err)
 	}
 
-	return &psd, nil
+	return nil
+}
+
+// CleanupStale drops tokens which were not refreshed or were invalidated before the provided date
+func (s *Service) CleanupStale(ctx context.Context, before time.Time, batchSize int) (int, error) {
+	removed := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return removed, err
+		}
+
+		list, err := s.metadata.GetStale(before, batchSize)
+		if err != nil {
+			return removed, fmt.Errorf("get stale tokens: %w", err)
+		}
+
+		for _, info := range list {
+			if err = s.DeleteByUserID(info.UserID, info.DeviceUUID); err != nil {
+				return removed, fmt.Errorf("delete stale token: %s/%s: %w", info.UserID, info.DeviceUUID, err)
+			}
+
+			removed++
+		}
+
+		if len(list) < batchSize {
+			return removed, nil
+		}
+	}
+}
+
+func (s *Service) GetPushDetails(userID uuid.UUID) (*PushSettingsDetails, error) {
+	return getDetails(s, pushSchema, userID)
 }
 
 func (s *Service) StorePushDetails(userID uuid.UUID, req PushSettingsDetails) error {
-	details, err := s.details.GetByUserAndType(userID, DetailsTypePushConfig)
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		return fmt.Errorf("get push details: %w", err)
+	_, err := storeDetails(s, pushSchema, userID, req)
+
+	return err
+}
+
+// SetAchievementUnlockedPush enables or disables opt-in pushes about unlocked achievements, other push settings are kept
+func (s *Service) SetAchievementUnlockedPush(userID uuid.UUID, enabled bool) error {
+	return s.StorePushDetails(userID, PushSettingsDetails{AchievementUnlocked: &enabled})
+}
+
+// GetEffectivePushDetails returns user push settings with applied overrides from the dao subscription
+func (s *Service) GetEffectivePushDetails(userID, daoID uuid.UUID) (*PushSettingsDetails, error) {
+	psd, err := s.GetPushDetails(userID)
+	if err != nil {
+		return nil, err
 	}
 
-	psd := getPushDefaultSettings()
+	sub, err := s.subscriptions.GetBySubscriberAndDaoID(userID, daoID)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
-		details = &Details{
-			UserID: userID,
-			Type:   DetailsTypePushConfig,
-		}
-	} else {
-		if err = json.Unmarshal(details.Value, psd); err != nil {
-			return fmt.Errorf("unmarshal push details: %w", err)
-		}
+		return psd, nil
 	}
 
-	if req.NewProposalCreated != nil {
-		psd.NewProposalCreated = req.NewProposalCreated
+	if err != nil {
+		return nil, fmt.Errorf("get subscription: %w", err)
 	}
 
-	if req.VoteFinishesSoon != nil {
-		psd.VoteFinishesSoon = req.VoteFinishesSoon
+	return applyPushOverrides(psd, sub.PushSettings), nil
+}
+
+func applyPushOverrides(psd *PushSettingsDetails, overrides *subscription.PushSettings) *PushSettingsDetails {
+	if overrides.IsEmpty() {
+		return psd
 	}
 
-	if req.VoteFinished != nil {
-		psd.VoteFinished = req.VoteFinished
+	if overrides.NewProposalCreated != nil {
+		psd.NewProposalCreated = overrides.NewProposalCreated
 	}
 
-	if req.QuorumReached != nil {
-		psd.QuorumReached = req.QuorumReached
+	if overrides.QuorumReached != nil {
+		psd.QuorumReached = overrides.QuorumReached
 	}
 
-	raw, err := json.Marshal(psd)
-	if err != nil {
-		return fmt.Errorf("marshal push details: %w", err)
+	if overrides.VoteFinishesSoon != nil {
+		psd.VoteFinishesSoon = overrides.VoteFinishesSoon
 	}
 
-	details.Value = raw
+	if overrides.VoteFinished != nil {
+		psd.VoteFinished = overrides.VoteFinished
+	}
 
-	return s.details.StoreDetails(details)
+	return psd
 }
 
-func getPushDefaultSettings() *PushSettingsDetails {
-	return &PushSettingsDetails{
-		NewProposalCreated: pointy.Bool(true),
-		QuorumReached:      pointy.Bool(true),
-		VoteFinishesSoon:   pointy.Bool(true),
-		VoteFinished:       pointy.Bool(true),
+// ResolvePushSetting returns if push with provided event type allowed for user by dao
+func (s *Service) ResolvePushSetting(userID, daoID uuid.UUID, et PushEventType) (bool, error) {
+	psd, err := s.GetEffectivePushDetails(userID, daoID)
+	if err != nil {
+		return false, fmt.Errorf("get effective push details: %w", err)
+	}
+
+	enabled, err := psd.Enabled(et)
+	if err != nil {
+		return false, err
 	}
+
+	return enabled, nil
 }
 
 func (s *Service) GetFeedSettings(userID uuid.UUID) (*FeedSettings, error) {
-	details, err := s.details.GetByUserAndType(userID, DetailsTypeFeedConfig)
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		// default logic for getting config
-		return getDefaultFeedSettings(), nil
+	fs, err := getDetails(s, feedSchema, userID)
+	if err != nil {
+		return nil, err
 	}
 
+	fs.MutedDaos = activeMutes(fs.MutedDaos, time.Now())
+
+	return fs, nil
+}
+
+func (s *Service) StoreFeedSettings(userID uuid.UUID, req FeedSettings) error {
+	// muted daos are managed by MuteDao and UnmuteDao
+	req.MutedDaos = nil
+
+	_, err := storeDetails(s, feedSchema, userID, req)
+
+	return err
+}
+
+func (s *Service) GetAutoFollowSettings(userID uuid.UUID) (*AutoFollowSettings, error) {
+	return getDetails(s, autoFollowSchema, userID)
+}
+
+func (s *Service) StoreAutoFollowSettings(userID uuid.UUID, req AutoFollowSettings) error {
+	_, err := storeDetails(s, autoFollowSchema, userID, req)
+
+	return err
+}
+
+// GetAutoFollowUserIDs returns users which enabled wallet driven auto subscriptions
+func (s *Service) GetAutoFollowUserIDs(limit, offset int) ([]uuid.UUID, error) {
+	return s.details.GetUserIDsByEnabledFlag(DetailsTypeAutoFollowConfig, "enabled", limit, offset)
+}
+
+func (s *Service) GetAchievementsSettings(userID uuid.UUID) (*AchievementsSettings, error) {
+	return getDetails(s, achievementsSchema, userID)
+}
+
+func (s *Service) StoreAchievementsSettings(userID uuid.UUID, req AchievementsSettings) error {
+	_, err := storeDetails(s, achievementsSchema, userID, req)
+
+	return err
+}
+
+// StoreRawDetails validates the raw value of any registered settings type and stores it in the actual version
+func (s *Service) StoreRawDetails(userID uuid.UUID, dt DetailsType, raw json.RawMessage, version int) error {
+	sc, err := getSchema(dt)
 	if err != nil {
-		return nil, fmt.Errorf("get push details: %w", err)
+		return err
 	}
 
-	var fsd FeedSettings
-	if err = json.Unmarshal(details.Value, &fsd); err != nil {
-		return nil, fmt.Errorf("unmarshal push details: %w", err)
+	value, err := sc.normalize(raw, version)
+	if err != nil {
+		return err
 	}
 
-	return &fsd, nil
+	return s.storeNormalized(userID, dt, sc, value)
 }
 
-func (s *Service) StoreFeedSettings(userID uuid.UUID, req FeedSettings) error {
-	details, err := s.details.GetByUserAndType(userID, DetailsTypeFeedConfig)
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		return fmt.Errorf("get push details: %w", err)
+func (s *Service) storeNormalized(userID uuid.UUID, dt DetailsType, sc schema, value json.RawMessage) error {
+	err := s.details.StoreDetails(&Details{
+		UserID:  userID,
+		Type:    dt,
+		Version: sc.version(),
+		Value:   value,
+	}, detailsMessage)
+	if err != nil {
+		return fmt.Errorf("store %s: %w", dt, err)
 	}
 
-	fsd := getDefaultFeedSettings()
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		details = &Details{
-			UserID: userID,
-			Type:   DetailsTypeFeedConfig,
-		}
-	} else {
-		if err = json.Unmarshal(details.Value, fsd); err != nil {
-			return fmt.Errorf("unmarshal push details: %w", err)
-		}
-	}
+	return nil
+}
 
-	if req.ArchiveProposalAfterVote != nil {
-		fsd.ArchiveProposalAfterVote = req.ArchiveProposalAfterVote
+// detailsMessage builds the settings changed event by the schema of stored details
+func detailsMessage(info *Details) (*OutboxMessage, error) {
+	sc, err := getSchema(info.Type)
+	if err != nil {
+		return nil, err
 	}
 
-	if req.AutoarchiveAfterDuration != nil {
-		fsd.AutoarchiveAfterDuration = req.AutoarchiveAfterDuration
+	return sc.message(info.UserID, info.Value, info.Revision)
+}
+
+// getDetails returns stored settings upgraded to the actual version or defaults if nothing is stored
+func getDetails[T any](s *Service, sc *Schema[T], userID uuid.UUID) (*T, error) {
+	details, err := s.details.GetByUserAndType(userID, sc.Type)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return sc.Defaults(), nil
 	}
 
-	raw, err := json.Marshal(fsd)
 	if err != nil {
-		return fmt.Errorf("marshal push details: %w", err)
+		return nil, fmt.Errorf("get %s: %w", sc.Type, err)
 	}
 
-	details.Value = raw
+	return sc.decode(details.Value, details.Version, false)
+}
 
-	if err = s.details.StoreDetails(details); err != nil {
-		return fmt.Errorf("store push details: %w", err)
+// storeDetails applies set request fields on top of stored settings, validates and stores the result
+func storeDetails[T any](s *Service, sc *Schema[T], userID uuid.UUID, req T) (*T, error) {
+	current, err := getDetails(s, sc, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	go func() {
-		days, ok := availableAutoArchiveDurations[pointy.StringValue(fsd.AutoarchiveAfterDuration, "1d")]
-		if !ok {
-			days = 1
-		}
+	value, err := sc.merge(current, req)
+	if err != nil {
+		return nil, err
+	}
 
-		if err = s.publisher.PublishJSON(context.TODO(), inbox.SubjectFeedSettingsUpdated, inbox.FeedSettingsPayload{
-			SubscriberID:         details.UserID,
-			AutoarchiveAfterDays: days,
-		}); err != nil {
-			log.Err(err).Msg("publish feed settings update")
-		}
-	}()
+	if err = replaceDetails(s, sc, userID, value); err != nil {
+		return nil, err
+	}
 
-	return nil
+	return value, nil
 }
 
-func getDefaultFeedSettings() *FeedSettings {
-	return &FeedSettings{
-		ArchiveProposalAfterVote: pointy.Bool(true),
-		AutoarchiveAfterDuration: pointy.String("1d"),
+// replaceDetails validates and stores the whole settings value
+func replaceDetails[T any](s *Service, sc *Schema[T], userID uuid.UUID, value *T) error {
+	if err := sc.validate(value); err != nil {
+		return err
 	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", sc.Type, err)
+	}
+
+	err = s.details.StoreDetails(&Details{
+		UserID:  userID,
+		Type:    sc.Type,
+		Version: sc.Version,
+		Value:   raw,
+	}, func(info *Details) (*OutboxMessage, error) {
+		return sc.message(userID, info.Value, info.Revision)
+	})
+	if err != nil {
+		return fmt.Errorf("store %s: %w", sc.Type, err)
+	}
+
+	return nil
 }
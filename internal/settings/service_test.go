@@ -0,0 +1,125 @@
+package settings
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.openly.dev/pointy"
+	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-inbox-storage/internal/subscription"
+)
+
+type fakeSubscriptions struct {
+	sub *subscription.UserSubscription
+	err error
+}
+
+func (f *fakeSubscriptions) GetBySubscriberAndDaoID(uuid.UUID, uuid.UUID) (*subscription.UserSubscription, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	if f.sub == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return f.sub, nil
+}
+
+func (f *fakeSubscriptions) GetByFilters([]subscription.Filter) (subscription.UserSubscriptionList, error) {
+	return subscription.UserSubscriptionList{}, f.err
+}
+
+func TestUnitResolvePushSetting(t *testing.T) {
+	for name, tc := range map[string]struct {
+		global    *PushSettingsDetails
+		overrides *subscription.PushSettings
+		// subscribed is false when the user doesn't follow the dao
+		subscribed bool
+		subErr     error
+		eventType  PushEventType
+		expected   bool
+		err        error
+	}{
+		"defaults": {
+			eventType: PushEventTypeQuorumReached,
+			expected:  true,
+		},
+		"achievement unlocked is opt-in": {
+			eventType: PushEventTypeAchievementUnlocked,
+			expected:  false,
+		},
+		"global disabled without subscription": {
+			global:    &PushSettingsDetails{VoteFinished: pointy.Bool(false)},
+			eventType: PushEventTypeVoteFinished,
+			expected:  false,
+		},
+		"global disabled without overrides": {
+			global:     &PushSettingsDetails{VoteFinished: pointy.Bool(false)},
+			subscribed: true,
+			eventType:  PushEventTypeVoteFinished,
+			expected:   false,
+		},
+		"override enables": {
+			global:     &PushSettingsDetails{VoteFinished: pointy.Bool(false)},
+			overrides:  &subscription.PushSettings{VoteFinished: pointy.Bool(true)},
+			subscribed: true,
+			eventType:  PushEventTypeVoteFinished,
+			expected:   true,
+		},
+		"override disables": {
+			overrides:  &subscription.PushSettings{NewProposalCreated: pointy.Bool(false)},
+			subscribed: true,
+			eventType:  PushEventTypeNewProposalCreated,
+			expected:   false,
+		},
+		"override of another event falls back to global": {
+			global:     &PushSettingsDetails{VoteFinishesSoon: pointy.Bool(false)},
+			overrides:  &subscription.PushSettings{VoteFinished: pointy.Bool(true)},
+			subscribed: true,
+			eventType:  PushEventTypeVoteFinishesSoon,
+			expected:   false,
+		},
+		"empty overrides fall back to global": {
+			global:     &PushSettingsDetails{QuorumReached: pointy.Bool(false)},
+			overrides:  &subscription.PushSettings{},
+			subscribed: true,
+			eventType:  PushEventTypeQuorumReached,
+			expected:   false,
+		},
+		"unknown event type": {
+			eventType: "proposal_liked",
+			err:       ErrUnknownPushEventType,
+		},
+		"subscription error": {
+			subErr:    errBackend,
+			eventType: PushEventTypeVoteFinished,
+			err:       errBackend,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			userID, daoID := uuid.New(), uuid.New()
+			subs := &fakeSubscriptions{err: tc.subErr}
+			if tc.subscribed {
+				subs.sub = &subscription.UserSubscription{UserID: userID, DaoID: daoID, PushSettings: tc.overrides}
+			}
+
+			service := NewService(&fakeTokens{}, fakeMetadata{}, &fakeDetails{values: map[DetailsType]*Details{}}, subs, fakePublisher{})
+			if tc.global != nil {
+				require.NoError(t, service.StorePushDetails(userID, *tc.global))
+			}
+
+			enabled, err := service.ResolvePushSetting(userID, daoID, tc.eventType)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, enabled)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// DetailsSnapshot is the stored settings value used for exporting and importing settings
+type DetailsSnapshot struct {
+	Type    DetailsType     `json:"type"`
+	Version int             `json:"version"`
+	Value   json.RawMessage `json:"value"`
+}
+
+// ExportDetails returns all stored settings of registered types
+func (s *Service) ExportDetails(userID uuid.UUID) ([]DetailsSnapshot, error) {
+	list, err := s.details.GetByUser(userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user details: %w", err)
+	}
+
+	result := make([]DetailsSnapshot, 0, len(list))
+	for _, info := range list {
+		if _, err = getSchema(info.Type); err != nil {
+			log.Warn().Err(err).Msgf("skip exporting settings: %s", userID)
+
+			continue
+		}
+
+		result = append(result, DetailsSnapshot{
+			Type:    info.Type,
+			Version: info.Version,
+			Value:   info.Value,
+		})
+	}
+
+	return result, nil
+}
+
+// ImportDetails validates all provided settings before storing any of them. In the replace mode
+// settings types which are not in the snapshot are reset to defaults, otherwise they are kept.
+// All settings are stored in one transaction, so a failed import does not change any settings.
+func (s *Service) ImportDetails(userID uuid.UUID, items []DetailsSnapshot, replace bool) error {
+	values := make(map[DetailsType]json.RawMessage, len(items))
+	for _, item := range items {
+		if _, ok := values[item.Type]; ok {
+			return fmt.Errorf("%w: duplicated settings type %s", ErrInvalidSettings, item.Type)
+		}
+
+		sc, err := getSchema(item.Type)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidSettings, err)
+		}
+
+		value, err := sc.normalize(item.Value, item.Version)
+		if errors.Is(err, ErrUnsupportedVersion) {
+			return fmt.Errorf("%w: %w", ErrInvalidSettings, err)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		values[item.Type] = value
+	}
+
+	list := make([]*Details, 0, len(values))
+	for _, dt := range registeredTypes() {
+		sc, _ := getSchema(dt)
+		value, ok := values[dt]
+		if !ok && !replace {
+			continue
+		}
+
+		if !ok {
+			var err error
+			if value, err = sc.defaults(); err != nil {
+				return fmt.Errorf("marshal %s defaults: %w", dt, err)
+			}
+		}
+
+		list = append(list, &Details{
+			UserID:  userID,
+			Type:    dt,
+			Version: sc.version(),
+			Value:   value,
+		})
+	}
+
+	if err := s.details.StoreDetailsList(list, detailsMessage); err != nil {
+		return fmt.Errorf("store details: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,247 @@
+package settings
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/goverland-labs/goverland-inbox-storage/internal/audit"
+	"github.com/goverland-labs/goverland-inbox-storage/pkg/grpcsrv"
+	storagepb "github.com/goverland-labs/goverland-inbox-storage/protobuf/inboxstorage"
+)
+
+// StorageServer implements settings methods of the storage protocol
+type StorageServer struct {
+	storagepb.UnimplementedSettingsStorageServer
+
+	sp      *Service
+	auditor Auditor
+}
+
+func NewStorageServer(s *Service, a Auditor) *StorageServer {
+	return &StorageServer{
+		sp:      s,
+		auditor: a,
+	}
+}
+
+func (s *StorageServer) GetEffectivePushSettings(_ context.Context, req *storagepb.GetEffectivePushSettingsRequest) (*storagepb.EffectivePushSettings, error) {
+	userID, daoID, err := parseUserAndDao(req.GetUserId(), req.GetDaoId())
+	if err != nil {
+		return nil, err
+	}
+
+	psd, err := s.sp.GetEffectivePushDetails(userID, daoID)
+	if err != nil {
+		return nil, errorMapper.Error(err)
+	}
+
+	res := &storagepb.EffectivePushSettings{}
+	for et, value := range map[PushEventType]*bool{
+		PushEventTypeNewProposalCreated:  &res.NewProposalCreated,
+		PushEventTypeQuorumReached:       &res.QuorumReached,
+		PushEventTypeVoteFinishesSoon:    &res.VoteFinishesSoon,
+		PushEventTypeVoteFinished:        &res.VoteFinished,
+		PushEventTypeAchievementUnlocked: &res.AchievementUnlocked,
+	} {
+		if *value, err = psd.Enabled(et); err != nil {
+			return nil, errorMapper.Error(err)
+		}
+	}
+
+	return res, nil
+}
+
+func (s *StorageServer) ResolvePushSetting(_ context.Context, req *storagepb.ResolvePushSettingRequest) (*storagepb.ResolvePushSettingResponse, error) {
+	userID, daoID, err := parseUserAndDao(req.GetUserId(), req.GetDaoId())
+	if err != nil {
+		return nil, err
+	}
+
+	enabled, err := s.sp.ResolvePushSetting(userID, daoID, PushEventType(req.GetEventType()))
+	if err != nil {
+		return nil, errorMapper.Error(err)
+	}
+
+	return &storagepb.ResolvePushSettingResponse{Enabled: enabled}, nil
+}
+
+func (s *StorageServer) SetAchievementUnlockedPush(ctx context.Context, req *storagepb.SetAchievementUnlockedPushRequest) (*emptypb.Empty, error) {
+	userID, err := grpcsrv.ParseUUID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := s.sp.GetPushDetails(userID)
+	if err != nil {
+		return nil, errorMapper.Error(err)
+	}
+
+	if err = s.sp.SetAchievementUnlockedPush(userID, req.GetEnabled()); err != nil {
+		return nil, errorMapper.Error(err)
+	}
+
+	after, err := s.sp.GetPushDetails(userID)
+	if err != nil {
+		log.Error().Err(err).Msgf("get settings for audit: %s", userID)
+	}
+
+	s.auditor.Record(ctx, userID, audit.OperationSetPushDetails, before, after)
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *StorageServer) GetAchievementsSettings(_ context.Context, req *storagepb.GetAchievementsSettingsRequest) (*storagepb.AchievementsSettings, error) {
+	userID, err := grpcsrv.ParseUUID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	details, err := s.sp.GetAchievementsSettings(userID)
+	if err != nil {
+		return nil, errorMapper.Error(err)
+	}
+
+	return &storagepb.AchievementsSettings{
+		HideFromLeaderboard: details.HideFromLeaderboard != nil && *details.HideFromLeaderboard,
+	}, nil
+}
+
+func (s *StorageServer) SetAchievementsSettings(ctx context.Context, req *storagepb.SetAchievementsSettingsRequest) (*emptypb.Empty, error) {
+	userID, err := grpcsrv.ParseUUID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	// missing settings reset all achievements settings to defaults
+	settings := req.GetSettings()
+	if settings == nil {
+		settings = &storagepb.AchievementsSettings{}
+	}
+
+	before, err := s.sp.GetAchievementsSettings(userID)
+	if err != nil {
+		return nil, errorMapper.Error(err)
+	}
+
+	hide := settings.GetHideFromLeaderboard()
+	if err = s.sp.StoreAchievementsSettings(userID, AchievementsSettings{HideFromLeaderboard: &hide}); err != nil {
+		return nil, errorMapper.Error(err)
+	}
+
+	after, err := s.sp.GetAchievementsSettings(userID)
+	if err != nil {
+		log.Error().Err(err).Msgf("get settings for audit: %s", userID)
+	}
+
+	s.auditor.Record(ctx, userID, audit.OperationSetAchievementsSettings, before, after)
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *StorageServer) GetPushTokensForUsers(_ context.Context, req *storagepb.GetPushTokensForUsersRequest) (*storagepb.GetPushTokensForUsersResponse, error) {
+	request, err := convertPushTokensRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.sp.GetPushTokensForUsers(request)
+	if err != nil {
+		return nil, errorMapper.Error(err)
+	}
+
+	return convertUserPushTokens(list), nil
+}
+
+func (s *StorageServer) StreamPushTokensForUsers(req *storagepb.GetPushTokensForUsersRequest, stream grpc.ServerStreamingServer[storagepb.GetPushTokensForUsersResponse]) error {
+	request, err := convertPushTokensRequest(req)
+	if err != nil {
+		return err
+	}
+
+	err = s.sp.StreamPushTokensForUsers(stream.Context(), request, func(list []UserPushTokens) error {
+		return stream.Send(convertUserPushTokens(list))
+	})
+	if err != nil {
+		return errorMapper.Error(err)
+	}
+
+	return nil
+}
+
+func convertPushTokensRequest(req *storagepb.GetPushTokensForUsersRequest) (PushTokensRequest, error) {
+	request := PushTokensRequest{
+		UserIDs:   make([]uuid.UUID, 0, len(req.GetUserIds())),
+		EventType: PushEventType(req.GetEventType()),
+	}
+
+	for _, value := range req.GetUserIds() {
+		id, err := grpcsrv.ParseUUID("user_ids", value)
+		if err != nil {
+			return PushTokensRequest{}, err
+		}
+
+		request.UserIDs = append(request.UserIDs, id)
+	}
+
+	if req.GetDaoId() != "" {
+		id, err := grpcsrv.ParseUUID("dao_id", req.GetDaoId())
+		if err != nil {
+			return PushTokensRequest{}, err
+		}
+
+		request.DaoID = id
+	}
+
+	return request, nil
+}
+
+func convertUserPushTokens(list []UserPushTokens) *storagepb.GetPushTokensForUsersResponse {
+	res := &storagepb.GetPushTokensForUsersResponse{
+		Users: make([]*storagepb.UserPushTokens, 0, len(list)),
+	}
+
+	for _, info := range list {
+		tokens := make([]*storagepb.PushToken, 0, len(info.Tokens))
+		for _, details := range info.Tokens {
+			token := &storagepb.PushToken{
+				DeviceUuid: details.DeviceUUID,
+				Token:      details.Token,
+			}
+
+			if meta := details.Metadata; meta != nil {
+				token.Provider = string(meta.Provider)
+				token.Platform = meta.Platform
+				token.AppVersion = meta.AppVersion
+				token.RefreshedAt = timestamppb.New(meta.RefreshedAt)
+			}
+
+			tokens = append(tokens, token)
+		}
+
+		res.Users = append(res.Users, &storagepb.UserPushTokens{
+			UserId: info.UserID.String(),
+			Tokens: tokens,
+		})
+	}
+
+	return res
+}
+
+func parseUserAndDao(userID, daoID string) (uuid.UUID, uuid.UUID, error) {
+	uid, err := grpcsrv.ParseUUID("user_id", userID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	did, err := grpcsrv.ParseUUID("dao_id", daoID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	return uid, did, nil
+}
@@ -0,0 +1,244 @@
+package settings
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.openly.dev/pointy"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/goverland-labs/goverland-inbox-storage/pkg/grpcsrv"
+	storagepb "github.com/goverland-labs/goverland-inbox-storage/protobuf/inboxstorage"
+)
+
+type fakeTokensStream struct {
+	grpc.ServerStream
+
+	sent []*storagepb.GetPushTokensForUsersResponse
+}
+
+func (f *fakeTokensStream) Context() context.Context {
+	return context.Background()
+}
+
+func (f *fakeTokensStream) Send(res *storagepb.GetPushTokensForUsersResponse) error {
+	f.sent = append(f.sent, res)
+
+	return nil
+}
+
+func newTestStorageServer(tokens map[tokenKey]string) *StorageServer {
+	return NewStorageServer(NewService(&fakeTokens{tokens: tokens}, fakeMetadata{}, &fakeDetails{}, &fakeSubscriptions{}, fakePublisher{}), fakeAuditor{})
+}
+
+func TestUnitStorageServerGetPushTokensForUsers(t *testing.T) {
+	withToken, withoutToken := uuid.New(), uuid.New()
+	tooMany := make([]string, MaxPushTokensUsers+1)
+	for i := range tooMany {
+		tooMany[i] = uuid.NewString()
+	}
+
+	for name, tc := range map[string]struct {
+		req      *storagepb.GetPushTokensForUsersRequest
+		expected []string
+		code     codes.Code
+		reason   string
+		field    string
+	}{
+		"users with tokens": {
+			req:      &storagepb.GetPushTokensForUsersRequest{UserIds: []string{withToken.String(), withoutToken.String()}},
+			expected: []string{withToken.String()},
+		},
+		"filtered by event type": {
+			req: &storagepb.GetPushTokensForUsersRequest{
+				UserIds:   []string{withToken.String()},
+				DaoId:     uuid.NewString(),
+				EventType: string(PushEventTypeVoteFinished),
+			},
+			expected: []string{withToken.String()},
+		},
+		"invalid user": {
+			req:   &storagepb.GetPushTokensForUsersRequest{UserIds: []string{withToken.String(), "wrong"}},
+			code:  codes.InvalidArgument,
+			field: "user_ids",
+		},
+		"invalid dao": {
+			req:   &storagepb.GetPushTokensForUsersRequest{UserIds: []string{withToken.String()}, DaoId: "wrong"},
+			code:  codes.InvalidArgument,
+			field: "dao_id",
+		},
+		"unknown event type": {
+			req:    &storagepb.GetPushTokensForUsersRequest{UserIds: []string{withToken.String()}, EventType: "unknown"},
+			code:   codes.InvalidArgument,
+			reason: "UNKNOWN_PUSH_EVENT_TYPE",
+		},
+		"too many users": {
+			req:    &storagepb.GetPushTokensForUsersRequest{UserIds: tooMany},
+			code:   codes.ResourceExhausted,
+			reason: "TOO_MANY_USERS",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := newTestStorageServer(map[tokenKey]string{
+				{userID: withToken.String(), deviceUUID: "device"}: "token",
+			})
+
+			res, err := server.GetPushTokensForUsers(context.Background(), tc.req)
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.reason, grpcsrv.ErrorReason(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.code != codes.OK {
+				return
+			}
+
+			require.Len(t, res.GetUsers(), len(tc.expected))
+			for i, info := range res.GetUsers() {
+				require.Equal(t, tc.expected[i], info.GetUserId())
+				require.Len(t, info.GetTokens(), 1)
+				require.Equal(t, "device", info.GetTokens()[0].GetDeviceUuid())
+				require.Equal(t, "token", info.GetTokens()[0].GetToken())
+			}
+		})
+	}
+}
+
+func TestUnitStorageServerStreamPushTokensForUsers(t *testing.T) {
+	userIDs := make([]string, MaxPushTokensUsers+1)
+	for i := range userIDs {
+		userIDs[i] = uuid.NewString()
+	}
+
+	first, last := userIDs[0], userIDs[len(userIDs)-1]
+	server := newTestStorageServer(map[tokenKey]string{
+		{userID: first, deviceUUID: "device"}: "first",
+		{userID: last, deviceUUID: "device"}:  "last",
+	})
+
+	stream := &fakeTokensStream{}
+	err := server.StreamPushTokensForUsers(&storagepb.GetPushTokensForUsersRequest{UserIds: userIDs}, stream)
+	require.NoError(t, err)
+	require.Len(t, stream.sent, 2)
+	require.Equal(t, first, stream.sent[0].GetUsers()[0].GetUserId())
+	require.Equal(t, last, stream.sent[1].GetUsers()[0].GetUserId())
+
+	err = server.StreamPushTokensForUsers(&storagepb.GetPushTokensForUsersRequest{UserIds: []string{"wrong"}}, &fakeTokensStream{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Equal(t, "user_ids", grpcsrv.ViolatedField(err))
+}
+
+func TestUnitStorageServerSetAchievementUnlockedPush(t *testing.T) {
+	userID := uuid.New()
+
+	for name, tc := range map[string]struct {
+		userID  string
+		enabled bool
+		err     error
+		code    codes.Code
+		field   string
+	}{
+		"enabled": {
+			userID:  userID.String(),
+			enabled: true,
+		},
+		"disabled": {
+			userID: userID.String(),
+		},
+		"invalid user": {
+			userID: "wrong",
+			code:   codes.InvalidArgument,
+			field:  "user_id",
+		},
+		"backend error": {
+			userID: userID.String(),
+			err:    errBackend,
+			code:   codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			details := &fakeDetails{values: make(map[DetailsType]*Details), err: tc.err}
+			service := NewService(&fakeTokens{}, fakeMetadata{}, details, &fakeSubscriptions{}, fakePublisher{})
+
+			_, err := NewStorageServer(service, fakeAuditor{}).SetAchievementUnlockedPush(context.Background(), &storagepb.SetAchievementUnlockedPushRequest{
+				UserId:  tc.userID,
+				Enabled: tc.enabled,
+			})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.code != codes.OK {
+				return
+			}
+
+			psd, err := service.GetPushDetails(userID)
+			require.NoError(t, err)
+			enabled, err := psd.Enabled(PushEventTypeAchievementUnlocked)
+			require.NoError(t, err)
+			require.Equal(t, tc.enabled, enabled)
+		})
+	}
+}
+
+func TestUnitStorageServerAchievementsSettings(t *testing.T) {
+	userID := uuid.New()
+
+	for name, tc := range map[string]struct {
+		userID   string
+		settings *storagepb.AchievementsSettings
+		err      error
+		expected bool
+		code     codes.Code
+		field    string
+	}{
+		"hidden from leaderboard": {
+			userID:   userID.String(),
+			settings: &storagepb.AchievementsSettings{HideFromLeaderboard: true},
+			expected: true,
+		},
+		"displayed in leaderboard": {
+			userID:   userID.String(),
+			settings: &storagepb.AchievementsSettings{},
+		},
+		"missing settings are reset": {
+			userID: userID.String(),
+		},
+		"invalid user": {
+			userID: "wrong",
+			code:   codes.InvalidArgument,
+			field:  "user_id",
+		},
+		"backend error": {
+			userID: userID.String(),
+			err:    errBackend,
+			code:   codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			details := &fakeDetails{values: make(map[DetailsType]*Details)}
+			service := NewService(&fakeTokens{}, fakeMetadata{}, details, &fakeSubscriptions{}, fakePublisher{})
+			server := NewStorageServer(service, fakeAuditor{})
+
+			// the stored opt-out is replaced by the request
+			require.NoError(t, service.StoreAchievementsSettings(userID, AchievementsSettings{HideFromLeaderboard: pointy.Bool(!tc.expected)}))
+			details.err = tc.err
+
+			_, err := server.SetAchievementsSettings(context.Background(), &storagepb.SetAchievementsSettingsRequest{
+				UserId:   tc.userID,
+				Settings: tc.settings,
+			})
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+
+			res, err := server.GetAchievementsSettings(context.Background(), &storagepb.GetAchievementsSettingsRequest{
+				UserId: tc.userID,
+			})
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			require.Equal(t, tc.expected, res.GetHideFromLeaderboard())
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/goverland-labs/goverland-inbox-storage/internal/settings"
+	"github.com/goverland-labs/goverland-inbox-storage/internal/subscription"
+)
+
+const (
+	// Version is increased on incompatible changes of the snapshot format
+	Version = 1
+
+	maxBlobSize = 1 << 20
+)
+
+type Mode string
+
+const (
+	// ModeMerge adds snapshot subscriptions and settings keeping the rest
+	ModeMerge Mode = "merge"
+	// ModeReplace makes subscriptions and settings equal to the snapshot
+	ModeReplace Mode = "replace"
+)
+
+var (
+	ErrInvalidSnapshot    = errors.New("invalid snapshot")
+	ErrUnsupportedVersion = errors.New("unsupported snapshot version")
+	ErrUnknownMode        = errors.New("unknown import mode")
+)
+
+// Snapshot contains user settings, including the push settings, and subscriptions with per dao push settings.
+// Push tokens and their metadata are left out: a token belongs to the app installation, restoring it on another
+// device or account would send pushes to the old device, and the new device registers its own token on login.
+type Snapshot struct {
+	Version       int                        `json:"version"`
+	CreatedAt     time.Time                  `json:"created_at"`
+	Settings      []settings.DetailsSnapshot `json:"settings"`
+	Subscriptions []Subscription             `json:"subscriptions"`
+}
+
+type Subscription struct {
+	DaoID        uuid.UUID                  `json:"dao_id"`
+	PushSettings *subscription.PushSettings `json:"push_settings,omitempty"`
+}
+
+type SettingsManager interface {
+	ExportDetails(userID uuid.UUID) ([]settings.DetailsSnapshot, error)
+	ImportDetails(userID uuid.UUID, items []settings.DetailsSnapshot, replace bool) error
+}
+
+type Subscriber interface {
+	GetByFilters(filters []subscription.Filter) (subscription.UserSubscriptionList, error)
+	Subscribe(ctx context.Context, info subscription.UserSubscription) (*subscription.UserSubscription, error)
+	Unsubscribe(ctx context.Context, id uuid.UUID, source subscription.Source) error
+	SetPushSettings(id uuid.UUID, ps subscription.PushSettings) error
+}
+
+// Service exports user settings and subscriptions into the signed blob and restores them
+// for the same or another user
+type Service struct {
+	settings SettingsManager
+	subs     Subscriber
+	signer   *Signer
+}
+
+func NewService(sm SettingsManager, s Subscriber, signer *Signer) *Service {
+	return &Service{
+		settings: sm,
+		subs:     s,
+		signer:   signer,
+	}
+}
+
+func (s *Service) Export(userID uuid.UUID) (string, error) {
+	details, err := s.settings.ExportDetails(userID)
+	if err != nil {
+		return "", fmt.Errorf("export settings: %w", err)
+	}
+
+	subs, err := s.getSubscriptions(userID)
+	if err != nil {
+		return "", err
+	}
+
+	snapshot := Snapshot{
+		Version:       Version,
+		CreatedAt:     time.Now(),
+		Settings:      details,
+		Subscriptions: make([]Subscription, 0, len(subs)),
+	}
+	for _, sub := range subs {
+		snapshot.Subscriptions = append(snapshot.Subscriptions, Subscription{
+			DaoID:        sub.DaoID,
+			PushSettings: sub.PushSettings,
+		})
+	}
+
+	payload, err := json.Marshal(snapshot)
+	if err != nil {
+		return "", fmt.Errorf("marshal snapshot: %w", err)
+	}
+
+	return s.signer.Sign(payload)
+}
+
+// Import restores settings and subscriptions from the blob. The whole snapshot is validated before
+// changing anything and settings are stored in one transaction. Subscriptions are changed one by one,
+// because following the dao also notifies the core and the feed, which can't be rolled back. Every step
+// is idempotent, so an import failed on subscriptions is completed by importing the same blob again.
+func (s *Service) Import(ctx context.Context, userID uuid.UUID, blob string, mode Mode) error {
+	if mode != ModeMerge && mode != ModeReplace {
+		return fmt.Errorf("%w: %s", ErrUnknownMode, mode)
+	}
+
+	snapshot, err := s.decode(blob)
+	if err != nil {
+		return err
+	}
+
+	if err = s.settings.ImportDetails(userID, snapshot.Settings, mode == ModeReplace); err != nil {
+		return fmt.Errorf("import settings: %w", err)
+	}
+
+	if err = s.importSubscriptions(ctx, userID, snapshot.Subscriptions, mode); err != nil {
+		return fmt.Errorf("import subscriptions: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) decode(blob string) (*Snapshot, error) {
+	if len(blob) > maxBlobSize {
+		return nil, fmt.Errorf("%w: blob is too large", ErrInvalidSnapshot)
+	}
+
+	payload, err := s.signer.Verify(blob)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot Snapshot
+	if err = json.Unmarshal(payload, &snapshot); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+
+	if snapshot.Version != Version {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, snapshot.Version)
+	}
+
+	for _, sub := range snapshot.Subscriptions {
+		if sub.DaoID == uuid.Nil {
+			return nil, fmt.Errorf("%w: empty dao id", ErrInvalidSnapshot)
+		}
+	}
+
+	return &snapshot, nil
+}
+
+func (s *Service) importSubscriptions(ctx context.Context, userID uuid.UUID, list []Subscription, mode Mode) error {
+	current, err := s.getSubscriptions(userID)
+	if err != nil {
+		return err
+	}
+
+	inSnapshot := make(map[uuid.UUID]struct{}, len(list))
+	for _, item := range list {
+		inSnapshot[item.DaoID] = struct{}{}
+
+		sub, err := s.subs.Subscribe(ctx, subscription.UserSubscription{
+			UserID: userID,
+			DaoID:  item.DaoID,
+			Source: subscription.SourceImport,
+		})
+		if err != nil {
+			return fmt.Errorf("subscribe: %s: %w", item.DaoID, err)
+		}
+
+		// in the merge mode overrides are updated only if the snapshot has them
+		if item.PushSettings.IsEmpty() && (mode == ModeMerge || sub.PushSettings.IsEmpty()) {
+			continue
+		}
+
+		var ps subscription.PushSettings
+		if item.PushSettings != nil {
+			ps = *item.PushSettings
+		}
+
+		if err = s.subs.SetPushSettings(sub.ID, ps); err != nil {
+			return fmt.Errorf("set push settings: %s: %w", item.DaoID, err)
+		}
+	}
+
+	if mode != ModeReplace {
+		return nil
+	}
+
+	for _, sub := range current {
+		if _, ok := inSnapshot[sub.DaoID]; ok {
+			continue
+		}
+
+		if err = s.subs.Unsubscribe(ctx, sub.ID, subscription.SourceImport); err != nil {
+			return fmt.Errorf("unsubscribe: %s: %w", sub.DaoID, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) getSubscriptions(userID uuid.UUID) ([]subscription.UserSubscription, error) {
+	list, err := s.subs.GetByFilters([]subscription.Filter{
+		subscription.UserIDFilter{ID: userID.String()},
+		subscription.CreatedAtOrderFilter{},
+		subscription.WithoutTotalFilter{},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get subscriptions: %w", err)
+	}
+
+	return list.Subscriptions, nil
+}
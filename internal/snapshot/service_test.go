@@ -0,0 +1,172 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.openly.dev/pointy"
+
+	"github.com/goverland-labs/goverland-inbox-storage/internal/settings"
+	"github.com/goverland-labs/goverland-inbox-storage/internal/subscription"
+)
+
+type fakeSettings struct {
+	details []settings.DetailsSnapshot
+	replace bool
+	err     error
+}
+
+func (f *fakeSettings) ExportDetails(uuid.UUID) ([]settings.DetailsSnapshot, error) {
+	return f.details, nil
+}
+
+func (f *fakeSettings) ImportDetails(_ uuid.UUID, items []settings.DetailsSnapshot, replace bool) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	f.details = items
+	f.replace = replace
+
+	return nil
+}
+
+type fakeSubscriber struct {
+	subs map[uuid.UUID]subscription.UserSubscription
+}
+
+func (f *fakeSubscriber) GetByFilters([]subscription.Filter) (subscription.UserSubscriptionList, error) {
+	var list []subscription.UserSubscription
+	for _, sub := range f.subs {
+		list = append(list, sub)
+	}
+
+	return subscription.UserSubscriptionList{Subscriptions: list}, nil
+}
+
+func (f *fakeSubscriber) Subscribe(_ context.Context, info subscription.UserSubscription) (*subscription.UserSubscription, error) {
+	for _, sub := range f.subs {
+		if sub.DaoID == info.DaoID {
+			return &sub, nil
+		}
+	}
+
+	info.ID = uuid.New()
+	f.subs[info.ID] = info
+
+	return &info, nil
+}
+
+func (f *fakeSubscriber) Unsubscribe(_ context.Context, id uuid.UUID, _ subscription.Source) error {
+	delete(f.subs, id)
+
+	return nil
+}
+
+func (f *fakeSubscriber) SetPushSettings(id uuid.UUID, ps subscription.PushSettings) error {
+	sub := f.subs[id]
+	sub.PushSettings = nil
+	if !ps.IsEmpty() {
+		sub.PushSettings = &ps
+	}
+	f.subs[id] = sub
+
+	return nil
+}
+
+func (f *fakeSubscriber) byDao() map[uuid.UUID]*subscription.PushSettings {
+	result := make(map[uuid.UUID]*subscription.PushSettings, len(f.subs))
+	for _, sub := range f.subs {
+		result[sub.DaoID] = sub.PushSettings
+	}
+
+	return result
+}
+
+func newSubscriber(daos map[uuid.UUID]*subscription.PushSettings) *fakeSubscriber {
+	f := &fakeSubscriber{subs: map[uuid.UUID]subscription.UserSubscription{}}
+	for daoID, ps := range daos {
+		id := uuid.New()
+		f.subs[id] = subscription.UserSubscription{ID: id, DaoID: daoID, PushSettings: ps}
+	}
+
+	return f
+}
+
+func TestUnitImport(t *testing.T) {
+	var (
+		userID   = uuid.New()
+		both     = uuid.New()
+		onlyBlob = uuid.New()
+		onlyUser = uuid.New()
+
+		blobPush = &subscription.PushSettings{VoteFinished: pointy.Bool(false)}
+		userPush = &subscription.PushSettings{QuorumReached: pointy.Bool(false)}
+		details  = []settings.DetailsSnapshot{{Type: settings.DetailsTypeFeedConfig, Version: 2, Value: json.RawMessage(`{}`)}}
+	)
+
+	signer := NewSigner("secret")
+	blob, err := NewService(
+		&fakeSettings{details: details},
+		newSubscriber(map[uuid.UUID]*subscription.PushSettings{both: nil, onlyBlob: blobPush}),
+		signer,
+	).Export(userID)
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		mode     Mode
+		expected map[uuid.UUID]*subscription.PushSettings
+	}{
+		"merge keeps subscriptions and overrides": {
+			mode:     ModeMerge,
+			expected: map[uuid.UUID]*subscription.PushSettings{both: userPush, onlyBlob: blobPush, onlyUser: nil},
+		},
+		"replace makes subscriptions equal to the snapshot": {
+			mode:     ModeReplace,
+			expected: map[uuid.UUID]*subscription.PushSettings{both: nil, onlyBlob: blobPush},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sm := &fakeSettings{}
+			subs := newSubscriber(map[uuid.UUID]*subscription.PushSettings{both: userPush, onlyUser: nil})
+			service := NewService(sm, subs, signer)
+
+			require.NoError(t, service.Import(context.Background(), userID, blob, tc.mode))
+			require.Equal(t, details, sm.details)
+			require.Equal(t, tc.mode == ModeReplace, sm.replace)
+			require.Equal(t, tc.expected, subs.byDao())
+
+			// repeated import changes nothing
+			require.NoError(t, service.Import(context.Background(), userID, blob, tc.mode))
+			require.Equal(t, tc.expected, subs.byDao())
+		})
+	}
+
+	t.Run("invalid blob changes nothing", func(t *testing.T) {
+		sm := &fakeSettings{}
+		subs := newSubscriber(map[uuid.UUID]*subscription.PushSettings{onlyUser: nil})
+
+		err := NewService(sm, subs, NewSigner("another")).Import(context.Background(), userID, blob, ModeReplace)
+		require.ErrorIs(t, err, ErrInvalidSignature)
+		require.Nil(t, sm.details)
+		require.Len(t, subs.subs, 1)
+	})
+
+	t.Run("settings error skips subscriptions", func(t *testing.T) {
+		sm := &fakeSettings{err: errors.New("invalid settings")}
+		subs := newSubscriber(map[uuid.UUID]*subscription.PushSettings{onlyUser: nil})
+
+		err := NewService(sm, subs, signer).Import(context.Background(), userID, blob, ModeReplace)
+		require.ErrorIs(t, err, sm.err)
+		require.Equal(t, map[uuid.UUID]*subscription.PushSettings{onlyUser: nil}, subs.byDao())
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		err := NewService(&fakeSettings{}, newSubscriber(nil), signer).Import(context.Background(), userID, blob, "unknown")
+		require.ErrorIs(t, err, ErrUnknownMode)
+	})
+}
@@ -0,0 +1,72 @@
+package snapshot
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+var (
+	ErrSigningKeyNotSet = errors.New("snapshot signing key is not set")
+	ErrInvalidSignature = errors.New("invalid snapshot signature")
+)
+
+// Signer signs the snapshot payload with HMAC-SHA256, the blob is "<payload>.<signature>" in base64url
+type Signer struct {
+	key []byte
+}
+
+func NewSigner(key string) *Signer {
+	return &Signer{
+		key: []byte(key),
+	}
+}
+
+func (s *Signer) Sign(payload []byte) (string, error) {
+	if len(s.key) == 0 {
+		return "", ErrSigningKeyNotSet
+	}
+
+	return encode(payload) + "." + encode(s.mac(payload)), nil
+}
+
+// Verify checks the blob signature and returns the payload
+func (s *Signer) Verify(blob string) ([]byte, error) {
+	if len(s.key) == 0 {
+		return nil, ErrSigningKeyNotSet
+	}
+
+	encoded, signature, ok := strings.Cut(blob, ".")
+	if !ok {
+		return nil, ErrInvalidSignature
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+
+	if !hmac.Equal(mac, s.mac(payload)) {
+		return nil, ErrInvalidSignature
+	}
+
+	return payload, nil
+}
+
+func (s *Signer) mac(payload []byte) []byte {
+	h := hmac.New(sha256.New, s.key)
+	h.Write(payload)
+
+	return h.Sum(nil)
+}
+
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
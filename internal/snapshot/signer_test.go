@@ -0,0 +1,37 @@
+package snapshot
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitSigner(t *testing.T) {
+	signer := NewSigner("secret")
+
+	blob, err := signer.Sign([]byte(`{"version":1}`))
+	require.NoError(t, err)
+
+	t.Run("valid blob", func(t *testing.T) {
+		payload, err := signer.Verify(blob)
+		require.NoError(t, err)
+		require.Equal(t, `{"version":1}`, string(payload))
+	})
+
+	t.Run("another key", func(t *testing.T) {
+		_, err := NewSigner("another").Verify(blob)
+		require.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("changed payload", func(t *testing.T) {
+		_, sig, _ := strings.Cut(blob, ".")
+		_, err := signer.Verify(encode([]byte(`{"version":2}`)) + "." + sig)
+		require.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("empty key", func(t *testing.T) {
+		_, err := NewSigner("").Sign([]byte(`{}`))
+		require.ErrorIs(t, err, ErrSigningKeyNotSet)
+	})
+}
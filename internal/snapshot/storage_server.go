@@ -0,0 +1,71 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/goverland-labs/goverland-inbox-storage/internal/settings"
+	"github.com/goverland-labs/goverland-inbox-storage/pkg/grpcsrv"
+	storagepb "github.com/goverland-labs/goverland-inbox-storage/protobuf/inboxstorage"
+)
+
+var errorMapper = grpcsrv.NewErrorMapper(
+	grpcsrv.ErrorRule{Err: ErrInvalidSnapshot, Code: codes.InvalidArgument, Reason: "INVALID_SNAPSHOT"},
+	grpcsrv.ErrorRule{Err: ErrInvalidSignature, Code: codes.InvalidArgument, Reason: "INVALID_SNAPSHOT_SIGNATURE"},
+	grpcsrv.ErrorRule{Err: ErrUnsupportedVersion, Code: codes.FailedPrecondition, Reason: "UNSUPPORTED_SNAPSHOT_VERSION"},
+	grpcsrv.ErrorRule{Err: ErrUnknownMode, Code: codes.InvalidArgument, Reason: "UNKNOWN_IMPORT_MODE"},
+	grpcsrv.ErrorRule{Err: settings.ErrInvalidSettings, Code: codes.InvalidArgument, Reason: "INVALID_SETTINGS"},
+)
+
+type ServiceProvider interface {
+	Export(userID uuid.UUID) (string, error)
+	Import(ctx context.Context, userID uuid.UUID, blob string, mode Mode) error
+}
+
+// StorageServer implements snapshot methods of the storage protocol
+type StorageServer struct {
+	storagepb.UnimplementedSnapshotStorageServer
+
+	sp ServiceProvider
+}
+
+func NewStorageServer(s ServiceProvider) *StorageServer {
+	return &StorageServer{
+		sp: s,
+	}
+}
+
+func (s *StorageServer) ExportSettings(_ context.Context, req *storagepb.ExportSettingsRequest) (*storagepb.ExportSettingsResponse, error) {
+	userID, err := grpcsrv.ParseUUID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	blob, err := s.sp.Export(userID)
+	if err != nil {
+		return nil, errorMapper.Error(fmt.Errorf("export settings: %s: %w", req.GetUserId(), err))
+	}
+
+	return &storagepb.ExportSettingsResponse{Blob: blob}, nil
+}
+
+func (s *StorageServer) ImportSettings(ctx context.Context, req *storagepb.ImportSettingsRequest) (*emptypb.Empty, error) {
+	userID, err := grpcsrv.ParseUUID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetBlob() == "" {
+		return nil, grpcsrv.InvalidArgument("blob", "must not be empty")
+	}
+
+	if err = s.sp.Import(ctx, userID, req.GetBlob(), Mode(req.GetMode())); err != nil {
+		return nil, errorMapper.Error(fmt.Errorf("import settings: %s: %w", req.GetUserId(), err))
+	}
+
+	return &emptypb.Empty{}, nil
+}
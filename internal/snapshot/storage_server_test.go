@@ -0,0 +1,93 @@
+package snapshot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/goverland-labs/goverland-inbox-storage/internal/subscription"
+	"github.com/goverland-labs/goverland-inbox-storage/pkg/grpcsrv"
+	storagepb "github.com/goverland-labs/goverland-inbox-storage/protobuf/inboxstorage"
+)
+
+func TestUnitStorageServerImportSettings(t *testing.T) {
+	userID := uuid.New()
+	signer := NewSigner("secret")
+	other := NewSigner("other")
+	blob := func(s *Signer, payload string) string {
+		b, err := s.Sign([]byte(payload))
+		require.NoError(t, err)
+
+		return b
+	}
+
+	for name, tc := range map[string]struct {
+		req    *storagepb.ImportSettingsRequest
+		code   codes.Code
+		reason string
+		field  string
+	}{
+		"merge": {
+			req: &storagepb.ImportSettingsRequest{UserId: userID.String(), Blob: blob(signer, `{"version":1}`), Mode: "merge"},
+		},
+		"invalid user": {
+			req:   &storagepb.ImportSettingsRequest{UserId: "wrong"},
+			code:  codes.InvalidArgument,
+			field: "user_id",
+		},
+		"empty blob": {
+			req:   &storagepb.ImportSettingsRequest{UserId: userID.String(), Mode: "merge"},
+			code:  codes.InvalidArgument,
+			field: "blob",
+		},
+		"unknown mode": {
+			req:    &storagepb.ImportSettingsRequest{UserId: userID.String(), Blob: blob(signer, `{"version":1}`), Mode: "append"},
+			code:   codes.InvalidArgument,
+			reason: "UNKNOWN_IMPORT_MODE",
+		},
+		"foreign signature": {
+			req:    &storagepb.ImportSettingsRequest{UserId: userID.String(), Blob: blob(other, `{"version":1}`), Mode: "replace"},
+			code:   codes.InvalidArgument,
+			reason: "INVALID_SNAPSHOT_SIGNATURE",
+		},
+		"unsupported version": {
+			req:    &storagepb.ImportSettingsRequest{UserId: userID.String(), Blob: blob(signer, `{"version":2}`), Mode: "replace"},
+			code:   codes.FailedPrecondition,
+			reason: "UNSUPPORTED_SNAPSHOT_VERSION",
+		},
+		"invalid payload": {
+			req:    &storagepb.ImportSettingsRequest{UserId: userID.String(), Blob: blob(signer, `[]`), Mode: "replace"},
+			code:   codes.InvalidArgument,
+			reason: "INVALID_SNAPSHOT",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := NewStorageServer(NewService(&fakeSettings{}, &fakeSubscriber{subs: map[uuid.UUID]subscription.UserSubscription{}}, signer))
+
+			_, err := server.ImportSettings(context.Background(), tc.req)
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.reason, grpcsrv.ErrorReason(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+		})
+	}
+}
+
+func TestUnitStorageServerExportSettings(t *testing.T) {
+	server := NewStorageServer(NewService(&fakeSettings{}, &fakeSubscriber{subs: map[uuid.UUID]subscription.UserSubscription{}}, NewSigner("secret")))
+
+	res, err := server.ExportSettings(context.Background(), &storagepb.ExportSettingsRequest{UserId: uuid.NewString()})
+	require.NoError(t, err)
+	require.NotEmpty(t, res.GetBlob())
+
+	_, err = server.ImportSettings(context.Background(), &storagepb.ImportSettingsRequest{UserId: uuid.NewString(), Blob: res.GetBlob(), Mode: "replace"})
+	require.NoError(t, err)
+
+	_, err = server.ExportSettings(context.Background(), &storagepb.ExportSettingsRequest{UserId: "wrong"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Equal(t, "user_id", grpcsrv.ViolatedField(err))
+}
@@ -0,0 +1,144 @@
+package subscription
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxTopDaosLimit     = 100
+	maxTrendPeriodRange = 366 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidTrendInterval = errors.New("invalid trend interval")
+	ErrInvalidPeriod        = errors.New("invalid period")
+)
+
+// GetFollowerCounts returns the number of followers for each provided dao, daos without followers have zero value
+func (s *Service) GetFollowerCounts(daoIDs []uuid.UUID) ([]FollowerCount, error) {
+	if len(daoIDs) == 0 {
+		return nil, nil
+	}
+
+	list, err := s.eventRepo.GetFollowerCounts(daoIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uuid.UUID]int64, len(list))
+	for _, info := range list {
+		counts[info.DaoID] = info.Count
+	}
+
+	result := make([]FollowerCount, 0, len(daoIDs))
+	for _, id := range daoIDs {
+		result = append(result, FollowerCount{
+			DaoID: id,
+			Count: counts[id],
+		})
+	}
+
+	return result, nil
+}
+
+// GetFollowTrend returns follows and unfollows of the dao in the period grouped by interval,
+// periods without events are filled with zero values
+func (s *Service) GetFollowTrend(daoID uuid.UUID, interval TrendInterval, from, to time.Time) ([]TrendPoint, error) {
+	switch interval {
+	case TrendIntervalDay, TrendIntervalWeek, TrendIntervalMonth:
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTrendInterval, interval)
+	}
+
+	if err := validatePeriod(from, to); err != nil {
+		return nil, err
+	}
+
+	list, err := s.eventRepo.GetTrend(daoID, interval, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return fillTrend(list, interval, from, to), nil
+}
+
+// fillTrend returns continuous series of periods between from and to using stored points where they exist
+func fillTrend(list []TrendPoint, interval TrendInterval, from, to time.Time) []TrendPoint {
+	points := make(map[time.Time]TrendPoint, len(list))
+	for _, point := range list {
+		points[point.Period.UTC()] = point
+	}
+
+	var result []TrendPoint
+	for period := truncatePeriod(from, interval); period.Before(to); period = nextPeriod(period, interval) {
+		point, ok := points[period]
+		if !ok {
+			point = TrendPoint{Period: period}
+		}
+
+		result = append(result, point)
+	}
+
+	return result
+}
+
+// truncatePeriod works like postgres date_trunc for supported intervals in UTC
+func truncatePeriod(t time.Time, interval TrendInterval) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch interval {
+	case TrendIntervalWeek:
+		// weeks start on monday
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case TrendIntervalMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+func nextPeriod(t time.Time, interval TrendInterval) time.Time {
+	switch interval {
+	case TrendIntervalWeek:
+		return t.AddDate(0, 0, 7)
+	case TrendIntervalMonth:
+		return t.AddDate(0, 1, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+// GetTopDaos returns daos ranked by net growth of followers in the period
+func (s *Service) GetTopDaos(from, to time.Time, limit int) ([]DaoGrowth, error) {
+	if err := validatePeriod(from, to); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 || limit > maxTopDaosLimit {
+		limit = maxTopDaosLimit
+	}
+
+	list, err := s.eventRepo.GetTopByGrowth(from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func validatePeriod(from, to time.Time) error {
+	if from.IsZero() || to.IsZero() || !from.Before(to) {
+		return fmt.Errorf("%w: %s - %s", ErrInvalidPeriod, from, to)
+	}
+
+	if to.Sub(from) > maxTrendPeriodRange {
+		return fmt.Errorf("%w: period is longer than %s", ErrInvalidPeriod, maxTrendPeriodRange)
+	}
+
+	return nil
+}
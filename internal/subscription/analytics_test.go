@@ -0,0 +1,39 @@
+package subscription
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitFillTrend(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, time.March, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	t.Run("fill missed days", func(t *testing.T) {
+		list := []TrendPoint{{Period: day(2), Follows: 3, Unfollows: 1}}
+
+		actual := fillTrend(list, TrendIntervalDay, day(1).Add(5*time.Hour), day(4))
+		require.Equal(t, []TrendPoint{
+			{Period: day(1)},
+			{Period: day(2), Follows: 3, Unfollows: 1},
+			{Period: day(3)},
+		}, actual)
+	})
+
+	t.Run("weeks start on monday", func(t *testing.T) {
+		// 2024-03-06 is wednesday
+		actual := fillTrend(nil, TrendIntervalWeek, day(6), day(12))
+		require.Equal(t, []TrendPoint{{Period: day(4)}, {Period: day(11)}}, actual)
+	})
+
+	t.Run("months", func(t *testing.T) {
+		actual := fillTrend(nil, TrendIntervalMonth, day(15), day(15).AddDate(0, 1, 0))
+		require.Equal(t, []TrendPoint{
+			{Period: day(1)},
+			{Period: day(1).AddDate(0, 1, 0)},
+		}, actual)
+	})
+}
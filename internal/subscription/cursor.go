@@ -0,0 +1,42 @@
+package subscription
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// cursor points to the last returned subscription by the sorting key
+type cursor struct {
+	Value string    `json:"v"`
+	ID    uuid.UUID `json:"id"`
+}
+
+func (c cursor) encode() string {
+	raw, _ := json.Marshal(c)
+
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(data string) (cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(data)
+	if err != nil {
+		return cursor{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	var c cursor
+	if err = json.Unmarshal(raw, &c); err != nil {
+		return cursor{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	if c.ID == uuid.Nil {
+		return cursor{}, ErrInvalidCursor
+	}
+
+	return c, nil
+}
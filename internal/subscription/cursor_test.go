@@ -0,0 +1,31 @@
+package subscription
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitCursor(t *testing.T) {
+	t.Run("encode and decode", func(t *testing.T) {
+		c := cursor{Value: "Aave", ID: uuid.New()}
+
+		actual, err := decodeCursor(c.encode())
+		require.NoError(t, err)
+		require.Equal(t, c, actual)
+	})
+
+	for name, data := range map[string]string{
+		"empty":      "",
+		"not base64": "!!!",
+		"not json":   "bm90IGpzb24",
+		"without id": cursor{Value: "Aave"}.encode(),
+		"invalid id": "eyJ2IjoiQWF2ZSIsImlkIjoiMTIzIn0",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := decodeCursor(data)
+			require.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
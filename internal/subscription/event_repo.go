@@ -0,0 +1,160 @@
+package subscription
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type EventRepo struct {
+	db *gorm.DB
+}
+
+func NewEventRepo(db *gorm.DB) *EventRepo {
+	return &EventRepo{db: db}
+}
+
+// GetLastByUser returns the latest subscription event of the user made from one of the sources
+func (r *EventRepo) GetLastByUser(userID uuid.UUID, sources []Source) (*Event, error) {
+	var ev Event
+	err := r.db.
+		Where("user_id = ? and source in ?", userID, sources).
+		Order("id desc").
+		First(&ev).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &ev, nil
+}
+
+// ExistsBySource returns true if there is an event of the user by dao with provided type and source not from exclude list
+func (r *EventRepo) ExistsBySource(userID, daoID uuid.UUID, et EventType, exclude []Source) (bool, error) {
+	var cnt int64
+	err := r.db.
+		Model(&Event{}).
+		Where("user_id = ? and dao_id = ? and type = ?", userID, daoID, et).
+		Where("source not in ?", exclude).
+		Limit(1).
+		Count(&cnt).
+		Error
+	if err != nil {
+		return false, fmt.Errorf("count events: %w", err)
+	}
+
+	return cnt > 0, nil
+}
+
+// GetByFilters returns subscription events from the newest to the oldest
+func (r *EventRepo) GetByFilters(filters []Filter) (EventList, error) {
+	db := r.db.Model(&Event{})
+	for _, f := range filters {
+		if _, ok := f.(PageFilter); ok {
+			continue
+		}
+		db = f.Apply(db)
+	}
+
+	var cnt int64
+	err := db.Count(&cnt).Error
+	if err != nil {
+		return EventList{}, err
+	}
+
+	for _, f := range filters {
+		if _, ok := f.(PageFilter); ok {
+			db = f.Apply(db)
+		}
+	}
+
+	var list []Event
+	err = db.Order("id desc").Find(&list).Error
+	if err != nil {
+		return EventList{}, err
+	}
+
+	return EventList{
+		Events:     list,
+		TotalCount: cnt,
+	}, nil
+}
+
+// GetFollowerCounts returns the number of active subscriptions by provided daos
+func (r *EventRepo) GetFollowerCounts(daoIDs []uuid.UUID) ([]FollowerCount, error) {
+	var list []FollowerCount
+	err := r.db.
+		Model(&UserSubscription{}).
+		Select("dao_id, count(*) count").
+		Where("dao_id in ?", daoIDs).
+		Group("dao_id").
+		Scan(&list).
+		Error
+	if err != nil {
+		return nil, fmt.Errorf("get follower counts: %w", err)
+	}
+
+	return list, nil
+}
+
+// GetTrend returns follows and unfollows of the dao grouped by interval
+func (r *EventRepo) GetTrend(daoID uuid.UUID, interval TrendInterval, from, to time.Time) ([]TrendPoint, error) {
+	query := `
+select
+    date_trunc(?, created_at at time zone 'UTC') period,
+    count(*) filter (where type = ?) follows,
+    count(*) filter (where type = ?) unfollows
+from user_subscription_events
+where dao_id = ?
+  and created_at >= ?
+  and created_at < ?
+group by 1
+order by 1`
+
+	var list []TrendPoint
+	err := r.db.
+		Raw(query, interval, EventTypeSubscribed, EventTypeUnsubscribed, daoID, from, to).
+		Scan(&list).
+		Error
+	if err != nil {
+		return nil, fmt.Errorf("get trend: %w", err)
+	}
+
+	return list, nil
+}
+
+// GetTopByGrowth returns daos ordered by net growth of followers in the period
+func (r *EventRepo) GetTopByGrowth(from, to time.Time, limit int) ([]DaoGrowth, error) {
+	query := `
+select
+    dao_id,
+    count(*) filter (where type = @subscribed) follows,
+    count(*) filter (where type = @unsubscribed) unfollows,
+    count(*) filter (where type = @subscribed) - count(*) filter (where type = @unsubscribed) net_growth
+from user_subscription_events
+where created_at >= @from
+  and created_at < @to
+group by dao_id
+order by net_growth desc, follows desc
+limit @limit`
+
+	var list []DaoGrowth
+	err := r.db.
+		Raw(query,
+			sql.Named("subscribed", EventTypeSubscribed),
+			sql.Named("unsubscribed", EventTypeUnsubscribed),
+			sql.Named("from", from),
+			sql.Named("to", to),
+			sql.Named("limit", limit),
+		).
+		Scan(&list).
+		Error
+	if err != nil {
+		return nil, fmt.Errorf("get top by growth: %w", err)
+	}
+
+	return list, nil
+}
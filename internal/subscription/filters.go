@@ -1,6 +1,10 @@
 package subscription
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -24,3 +28,72 @@ type UserIDFilter struct {
 func (f UserIDFilter) Apply(db *gorm.DB) *gorm.DB {
 	return db.Where("user_id = ?", f.ID)
 }
+
+type UserIDsFilter struct {
+	IDs []uuid.UUID
+}
+
+func (f UserIDsFilter) Apply(db *gorm.DB) *gorm.DB {
+	return db.Where("user_id in ?", f.IDs)
+}
+
+type DaoIDsFilter struct {
+	IDs []uuid.UUID
+}
+
+func (f DaoIDsFilter) Apply(db *gorm.DB) *gorm.DB {
+	return db.Where("dao_id in ?", f.IDs)
+}
+
+type CreatedAtFilter struct {
+	From time.Time
+	To   time.Time
+}
+
+func (f CreatedAtFilter) Apply(db *gorm.DB) *gorm.DB {
+	if !f.From.IsZero() {
+		db = db.Where("created_at >= ?", f.From)
+	}
+
+	if !f.To.IsZero() {
+		db = db.Where("created_at < ?", f.To)
+	}
+
+	return db
+}
+
+// CreatedAtOrderFilter orders subscriptions by creation date with id as a tiebreaker
+type CreatedAtOrderFilter struct {
+	Desc bool
+}
+
+func (f CreatedAtOrderFilter) Apply(db *gorm.DB) *gorm.DB {
+	if f.Desc {
+		return db.Order("created_at desc, id desc")
+	}
+
+	return db.Order("created_at asc, id asc")
+}
+
+// CreatedAtCursorFilter returns subscriptions after the provided one in CreatedAtOrderFilter order
+type CreatedAtCursorFilter struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+	Desc      bool
+}
+
+func (f CreatedAtCursorFilter) Apply(db *gorm.DB) *gorm.DB {
+	op := ">"
+	if f.Desc {
+		op = "<"
+	}
+
+	return db.Where(fmt.Sprintf("(created_at, id) %s (?, ?)", op), f.CreatedAt, f.ID)
+}
+
+// WithoutTotalFilter disables counting total number of rows
+type WithoutTotalFilter struct{}
+
+func (f WithoutTotalFilter) Apply(db *gorm.DB) *gorm.DB {
+	return db
+}
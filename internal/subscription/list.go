@@ -0,0 +1,221 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	coresdk "github.com/goverland-labs/goverland-core-sdk-go"
+)
+
+type SortField string
+
+const (
+	SortByCreatedAt SortField = "created_at"
+	SortByDaoName   SortField = "dao_name"
+)
+
+const (
+	defaultListLimit = 50
+	daoListChunkSize = 50
+	cursorTimeLayout = time.RFC3339Nano
+)
+
+var ErrInvalidSortField = errors.New("invalid sort field")
+
+type ListParams struct {
+	UserID      uuid.UUID
+	DaoIDs      []uuid.UUID
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	SortBy      SortField
+	Desc        bool
+	Limit       int
+	Offset      int
+	// Cursor is the value of ListResult.NextCursor from the previous page, offset is applied after it
+	Cursor string
+	// WithTotal enables counting the total number of subscriptions by filters
+	WithTotal bool
+}
+
+type ListResult struct {
+	Subscriptions []UserSubscription
+	TotalCount    int64
+	NextCursor    string
+}
+
+// List returns user subscriptions by provided filters with sorting and keyset pagination
+func (s *Service) List(ctx context.Context, params ListParams) (ListResult, error) {
+	if params.Limit <= 0 {
+		params.Limit = defaultListLimit
+	}
+
+	if params.SortBy == "" {
+		params.SortBy = SortByCreatedAt
+	}
+
+	var c *cursor
+	if params.Cursor != "" {
+		decoded, err := decodeCursor(params.Cursor)
+		if err != nil {
+			return ListResult{}, err
+		}
+
+		c = &decoded
+	}
+
+	filters := []Filter{
+		UserIDFilter{ID: params.UserID.String()},
+	}
+	if len(params.DaoIDs) > 0 {
+		filters = append(filters, DaoIDsFilter{IDs: params.DaoIDs})
+	}
+	if !params.CreatedFrom.IsZero() || !params.CreatedTo.IsZero() {
+		filters = append(filters, CreatedAtFilter{From: params.CreatedFrom, To: params.CreatedTo})
+	}
+
+	switch params.SortBy {
+	case SortByCreatedAt:
+		return s.listByCreatedAt(params, filters, c)
+	case SortByDaoName:
+		return s.listByDaoName(ctx, params, filters, c)
+	default:
+		return ListResult{}, fmt.Errorf("%w: %s", ErrInvalidSortField, params.SortBy)
+	}
+}
+
+func (s *Service) listByCreatedAt(params ListParams, filters []Filter, c *cursor) (ListResult, error) {
+	filters = append(filters,
+		CreatedAtOrderFilter{Desc: params.Desc},
+		PageFilter{Limit: params.Limit, Offset: params.Offset},
+	)
+
+	if !params.WithTotal {
+		filters = append(filters, WithoutTotalFilter{})
+	}
+
+	if c != nil {
+		createdAt, err := time.Parse(cursorTimeLayout, c.Value)
+		if err != nil {
+			return ListResult{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+		}
+
+		filters = append(filters, CreatedAtCursorFilter{CreatedAt: createdAt, ID: c.ID, Desc: params.Desc})
+	}
+
+	list, err := s.repo.GetByFilters(filters)
+	if err != nil {
+		return ListResult{}, fmt.Errorf("get by filters: %w", err)
+	}
+
+	result := ListResult{
+		Subscriptions: list.Subscriptions,
+		TotalCount:    list.TotalCount,
+	}
+
+	if len(list.Subscriptions) == params.Limit {
+		last := list.Subscriptions[len(list.Subscriptions)-1]
+		result.NextCursor = cursor{Value: last.CreatedAt.Format(cursorTimeLayout), ID: last.ID}.encode()
+	}
+
+	return result, nil
+}
+
+// listByDaoName sorts subscriptions in memory due to dao names are stored in the core
+func (s *Service) listByDaoName(ctx context.Context, params ListParams, filters []Filter, c *cursor) (ListResult, error) {
+	list, err := s.repo.GetByFilters(append(filters, WithoutTotalFilter{}))
+	if err != nil {
+		return ListResult{}, fmt.Errorf("get by filters: %w", err)
+	}
+
+	names, err := s.getDaoNames(ctx, list.Subscriptions)
+	if err != nil {
+		return ListResult{}, err
+	}
+
+	// compare returns the position of the first key relative to the second one in requested order
+	compare := func(a, b nameKey) int {
+		if params.Desc {
+			return b.compare(a)
+		}
+
+		return a.compare(b)
+	}
+
+	subs := list.Subscriptions
+	sort.SliceStable(subs, func(i, j int) bool {
+		return compare(newNameKey(names[subs[i].DaoID], subs[i].ID), newNameKey(names[subs[j].DaoID], subs[j].ID)) < 0
+	})
+
+	result := ListResult{
+		TotalCount: int64(len(subs)),
+	}
+
+	if c != nil {
+		pivot := newNameKey(c.Value, c.ID)
+		idx := sort.Search(len(subs), func(i int) bool {
+			return compare(newNameKey(names[subs[i].DaoID], subs[i].ID), pivot) > 0
+		})
+		subs = subs[idx:]
+	}
+
+	subs = subs[min(params.Offset, len(subs)):]
+	subs = subs[:min(params.Limit, len(subs))]
+
+	result.Subscriptions = subs
+	if len(subs) == params.Limit {
+		last := subs[len(subs)-1]
+		result.NextCursor = cursor{Value: names[last.DaoID], ID: last.ID}.encode()
+	}
+
+	return result, nil
+}
+
+func (s *Service) getDaoNames(ctx context.Context, subs []UserSubscription) (map[uuid.UUID]string, error) {
+	ids := make([]string, 0, len(subs))
+	for _, sub := range subs {
+		ids = append(ids, sub.DaoID.String())
+	}
+
+	names := make(map[uuid.UUID]string, len(ids))
+	for start := 0; start < len(ids); start += daoListChunkSize {
+		chunk := ids[start:min(start+daoListChunkSize, len(ids))]
+		list, err := s.daos.GetDaoList(ctx, coresdk.GetDaoListRequest{
+			Limit:  len(chunk),
+			DaoIDS: chunk,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("get dao list: %w", err)
+		}
+
+		for _, info := range list.Items {
+			names[info.ID] = info.Name
+		}
+	}
+
+	return names, nil
+}
+
+type nameKey struct {
+	name string
+	id   string
+}
+
+func newNameKey(name string, id uuid.UUID) nameKey {
+	return nameKey{
+		name: strings.ToLower(name),
+		id:   id.String(),
+	}
+}
+
+func (k nameKey) compare(other nameKey) int {
+	if k.name != other.name {
+		return strings.Compare(k.name, other.name)
+	}
+
+	return strings.Compare(k.id, other.id)
+}
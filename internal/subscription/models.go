@@ -14,6 +14,27 @@ type UserSubscription struct {
 	DeletedAt gorm.DeletedAt `gorm:"index"`
 	UserID    uuid.UUID
 	DaoID     uuid.UUID
+	Source    Source
+	// PushSettings overrides user push settings for the dao, nil means using the global ones
+	PushSettings *PushSettings `gorm:"type:jsonb;serializer:json"`
+}
+
+// PushSettings describes per dao overrides of the user push settings.
+// Nil fields are falling back to the global user settings.
+type PushSettings struct {
+	NewProposalCreated *bool `json:"new_proposal_created,omitempty"`
+	QuorumReached      *bool `json:"quorum_reached,omitempty"`
+	VoteFinishesSoon   *bool `json:"vote_finishes_soon,omitempty"`
+	VoteFinished       *bool `json:"vote_finished,omitempty"`
+}
+
+// IsEmpty returns true if there are no overrides
+func (ps *PushSettings) IsEmpty() bool {
+	return ps == nil ||
+		ps.NewProposalCreated == nil &&
+			ps.QuorumReached == nil &&
+			ps.VoteFinishesSoon == nil &&
+			ps.VoteFinished == nil
 }
 
 type GlobalSubscription struct {
@@ -29,3 +50,73 @@ type UserSubscriptionList struct {
 	Subscriptions []UserSubscription
 	TotalCount    int64
 }
+
+type Source string
+
+const (
+	SourceApp                  Source = "app"
+	SourceOnboarding           Source = "onboarding"
+	SourceWalletRecommendation Source = "wallet_recommendation"
+	SourceAutoFollow           Source = "auto_follow"
+	SourceUndo                 Source = "undo"
+	SourceImport               Source = "import"
+)
+
+// clientSources could be provided by the api calling subscription rpcs
+var clientSources = []Source{SourceApp, SourceOnboarding, SourceWalletRecommendation}
+
+// userSources are changes made by the user in the app, other sources are automated
+var userSources = []Source{SourceApp, SourceOnboarding, SourceWalletRecommendation, SourceUndo}
+
+type EventType string
+
+const (
+	EventTypeSubscribed   EventType = "subscribed"
+	EventTypeUnsubscribed EventType = "unsubscribed"
+)
+
+// Event describes the history of user subscriptions changes
+type Event struct {
+	ID             uint64 `gorm:"primarykey"`
+	CreatedAt      time.Time
+	SubscriptionID uuid.UUID
+	UserID         uuid.UUID
+	DaoID          uuid.UUID
+	Type           EventType
+	Source         Source
+}
+
+func (Event) TableName() string {
+	return "user_subscription_events"
+}
+
+type EventList struct {
+	Events     []Event
+	TotalCount int64
+}
+
+type TrendInterval string
+
+const (
+	TrendIntervalDay   TrendInterval = "day"
+	TrendIntervalWeek  TrendInterval = "week"
+	TrendIntervalMonth TrendInterval = "month"
+)
+
+type FollowerCount struct {
+	DaoID uuid.UUID
+	Count int64
+}
+
+type TrendPoint struct {
+	Period    time.Time
+	Follows   int64
+	Unfollows int64
+}
+
+type DaoGrowth struct {
+	DaoID     uuid.UUID
+	Follows   int64
+	Unfollows int64
+	NetGrowth int64
+}
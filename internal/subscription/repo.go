@@ -2,6 +2,7 @@ package subscription
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -15,12 +16,69 @@ func NewRepo(db *gorm.DB) *Repo {
 	return &Repo{db: db}
 }
 
+// Create stores subscription with the subscribed event in one transaction
 func (r *Repo) Create(item UserSubscription) error {
-	return r.db.Create(&item).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&item).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(newEvent(item, EventTypeSubscribed, item.Source)).Error
+	})
+}
+
+// Delete removes subscription with storing the unsubscribed event in one transaction
+func (r *Repo) Delete(item UserSubscription, source Source) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&item).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(newEvent(item, EventTypeUnsubscribed, source)).Error
+	})
+}
+
+// Restore brings back deleted subscription with storing the subscribed event in one transaction.
+// The source of the subscription is replaced, so restored subscription is not treated as automated one.
+func (r *Repo) Restore(item UserSubscription, source Source) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Unscoped().
+			Model(&UserSubscription{ID: item.ID}).
+			Updates(map[string]any{
+				"deleted_at": nil,
+				"source":     source,
+				"updated_at": time.Now(),
+			}).
+			Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(newEvent(item, EventTypeSubscribed, source)).Error
+	})
+}
+
+func newEvent(item UserSubscription, et EventType, source Source) *Event {
+	return &Event{
+		CreatedAt:      time.Now(),
+		SubscriptionID: item.ID,
+		UserID:         item.UserID,
+		DaoID:          item.DaoID,
+		Type:           et,
+		Source:         source,
+	}
 }
 
-func (r *Repo) Delete(item UserSubscription) error {
-	return r.db.Delete(&item).Error
+func (r *Repo) UpdatePushSettings(id uuid.UUID, ps *PushSettings) error {
+	return r.db.
+		Model(&UserSubscription{ID: id}).
+		Select("push_settings", "updated_at").
+		Updates(UserSubscription{
+			UpdatedAt:    time.Now(),
+			PushSettings: ps,
+		}).
+		Error
 }
 
 func (r *Repo) GetBySubscriberAndDaoID(subscriberID, daoID uuid.UUID) (UserSubscription, error) {
@@ -36,6 +94,24 @@ func (r *Repo) GetBySubscriberAndDaoID(subscriberID, daoID uuid.UUID) (UserSubsc
 	return res, err
 }
 
+// CountByUser counts active subscriptions created in the optional period
+func (r *Repo) CountByUser(userID uuid.UUID, from, to *time.Time) (int64, error) {
+	query := r.db.
+		Model(&UserSubscription{}).
+		Where("user_id = ?", userID)
+	if from != nil {
+		query = query.Where("created_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("created_at < ?", *to)
+	}
+
+	var count int64
+	err := query.Count(&count).Error
+
+	return count, err
+}
+
 func (r *Repo) GetByID(id uuid.UUID) (*UserSubscription, error) {
 	us := UserSubscription{ID: id}
 	request := r.db.Take(&us)
@@ -46,6 +122,20 @@ func (r *Repo) GetByID(id uuid.UUID) (*UserSubscription, error) {
 	return &us, nil
 }
 
+// GetDeletedByID returns deleted subscription by id
+func (r *Repo) GetDeletedByID(id uuid.UUID) (*UserSubscription, error) {
+	us := UserSubscription{ID: id}
+	request := r.db.
+		Unscoped().
+		Where("deleted_at is not null").
+		Take(&us)
+	if err := request.Error; err != nil {
+		return nil, fmt.Errorf("get deleted user subscription by id #%s: %w", id, err)
+	}
+
+	return &us, nil
+}
+
 // todo: think about getting this elements by chunks
 func (r *Repo) GetSubscribers(daoID uuid.UUID) ([]UserSubscription, error) {
 	var res []UserSubscription
@@ -61,27 +151,34 @@ func (r *Repo) GetSubscribers(daoID uuid.UUID) ([]UserSubscription, error) {
 
 func (r *Repo) GetByFilters(filters []Filter) (UserSubscriptionList, error) {
 	db := r.db.Model(&UserSubscription{})
+	withTotal := true
 	for _, f := range filters {
-		if _, ok := f.(PageFilter); ok {
+		switch f.(type) {
+		case PageFilter, CreatedAtCursorFilter, CreatedAtOrderFilter:
 			continue
+		case WithoutTotalFilter:
+			withTotal = false
 		}
 		db = f.Apply(db)
 	}
 
 	var cnt int64
-	err := db.Count(&cnt).Error
-	if err != nil {
-		return UserSubscriptionList{}, err
+	if withTotal {
+		err := db.Count(&cnt).Error
+		if err != nil {
+			return UserSubscriptionList{}, err
+		}
 	}
 
 	for _, f := range filters {
-		if _, ok := f.(PageFilter); ok {
+		switch f.(type) {
+		case PageFilter, CreatedAtCursorFilter, CreatedAtOrderFilter:
 			db = f.Apply(db)
 		}
 	}
 
 	var list []UserSubscription
-	err = db.Find(&list).Error
+	err := db.Find(&list).Error
 	if err != nil {
 		return UserSubscriptionList{}, err
 	}
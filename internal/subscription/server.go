@@ -2,16 +2,15 @@ package subscription
 
 import (
 	"context"
-	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
 	proto "github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
-	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
-	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-inbox-storage/pkg/grpcsrv"
 )
 
 const (
@@ -19,70 +18,72 @@ const (
 	defaultOffset = 0
 )
 
+var errorMapper = grpcsrv.NewErrorMapper(
+	grpcsrv.ErrorRule{Err: ErrInvalidTrendInterval, Code: codes.InvalidArgument, Reason: "INVALID_TREND_INTERVAL"},
+	grpcsrv.ErrorRule{Err: ErrInvalidPeriod, Code: codes.InvalidArgument, Reason: "INVALID_PERIOD"},
+	grpcsrv.ErrorRule{Err: ErrNothingToUndo, Code: codes.FailedPrecondition, Reason: "NOTHING_TO_UNDO"},
+)
+
+type ServiceProvider interface {
+	Subscribe(ctx context.Context, info UserSubscription) (*UserSubscription, error)
+	Unsubscribe(ctx context.Context, id uuid.UUID, source Source) error
+	List(ctx context.Context, params ListParams) (ListResult, error)
+	GetByID(id uuid.UUID) (*UserSubscription, error)
+	GetSubscribers(ctx context.Context, daoID uuid.UUID) ([]uuid.UUID, error)
+}
+
 type Server struct {
 	proto.UnimplementedSubscriptionServer
 
-	sp *Service
+	sp ServiceProvider
 }
 
-func NewServer(s *Service) *Server {
+func NewServer(s ServiceProvider) *Server {
 	return &Server{
 		sp: s,
 	}
 }
 
 func (s *Server) Subscribe(ctx context.Context, req *proto.SubscribeRequest) (*proto.SubscriptionInfo, error) {
-	if req.GetDaoId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "invalid dao id")
-	}
-
-	if req.GetSubscriberId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "invalid subscriber id")
-	}
-
-	subscriberID, err := uuid.Parse(req.GetSubscriberId())
+	subscriberID, err := grpcsrv.ParseUUID("subscriber_id", req.GetSubscriberId())
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid subscriber id")
+		return nil, err
 	}
 
-	daoID, err := uuid.Parse(req.GetDaoId())
+	daoID, err := grpcsrv.ParseUUID("dao_id", req.GetDaoId())
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid dao id")
+		return nil, err
 	}
 
 	sub, err := s.sp.Subscribe(ctx, UserSubscription{
 		UserID: subscriberID,
 		DaoID:  daoID,
+		Source: sourceFromContext(ctx),
 	})
 	if err != nil {
-		log.Error().Err(err).Msgf("subscribe: %s", req.GetDaoId())
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, errorMapper.Error(fmt.Errorf("subscribe: %s: %w", req.GetDaoId(), err))
 	}
 
 	return convertSubscriptionToProto(sub), nil
 }
 
 func (s *Server) Unsubscribe(ctx context.Context, req *proto.UnsubscribeRequest) (*emptypb.Empty, error) {
-	if req.GetSubscriptionId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "invalid subscription ID")
-	}
-
-	id, err := uuid.Parse(req.GetSubscriptionId())
+	id, err := grpcsrv.ParseUUID("subscription_id", req.GetSubscriptionId())
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid subscription id")
+		return nil, err
 	}
 
-	if err := s.sp.Unsubscribe(ctx, id); err != nil {
-		log.Error().Err(err).Msgf("unsubscribe: %s", req.GetSubscriptionId())
-		return nil, status.Error(codes.Internal, "internal error")
+	if err := s.sp.Unsubscribe(ctx, id, sourceFromContext(ctx)); err != nil {
+		return nil, errorMapper.Error(fmt.Errorf("unsubscribe: %s: %w", req.GetSubscriptionId(), err))
 	}
 
 	return &emptypb.Empty{}, nil
 }
 
-func (s *Server) ListSubscriptions(_ context.Context, req *proto.ListSubscriptionRequest) (*proto.ListSubscriptionResponse, error) {
-	if req.GetSubscriberId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "invalid subscriber ID")
+func (s *Server) ListSubscriptions(ctx context.Context, req *proto.ListSubscriptionRequest) (*proto.ListSubscriptionResponse, error) {
+	subscriberID, err := grpcsrv.ParseUUID("subscriber_id", req.GetSubscriberId())
+	if err != nil {
+		return nil, err
 	}
 
 	limit, offset := defaultLimit, defaultOffset
@@ -92,15 +93,16 @@ func (s *Server) ListSubscriptions(_ context.Context, req *proto.ListSubscriptio
 	if req.GetOffset() > 0 {
 		offset = int(req.GetOffset())
 	}
-	filters := []Filter{
-		PageFilter{Limit: limit, Offset: offset},
-		UserIDFilter{ID: req.GetSubscriberId()},
-	}
 
-	list, err := s.sp.GetByFilters(filters)
+	list, err := s.sp.List(ctx, ListParams{
+		UserID:    subscriberID,
+		SortBy:    SortByCreatedAt,
+		Limit:     limit,
+		Offset:    offset,
+		WithTotal: true,
+	})
 	if err != nil {
-		log.Error().Err(err).Msgf("get user subscriptions by filter: %+v", req)
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, errorMapper.Error(fmt.Errorf("get user subscriptions by filter: %+v: %w", req, err))
 	}
 
 	res := &proto.ListSubscriptionResponse{
@@ -116,37 +118,28 @@ func (s *Server) ListSubscriptions(_ context.Context, req *proto.ListSubscriptio
 }
 
 func (s *Server) GetSubscription(_ context.Context, req *proto.GetSubscriptionRequest) (*proto.SubscriptionInfo, error) {
-	if req.GetSubscriptionId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "invalid subscription ID")
-	}
-
-	id, err := uuid.Parse(req.GetSubscriptionId())
+	id, err := grpcsrv.ParseUUID("subscription_id", req.GetSubscriptionId())
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+		return nil, err
 	}
 
 	sub, err := s.sp.GetByID(id)
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, status.Error(codes.InvalidArgument, "invalid user id")
-	}
-
 	if err != nil {
-		log.Error().Err(err).Msgf("get subscription by id: %s", req.GetSubscriptionId())
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, errorMapper.Error(fmt.Errorf("get subscription by id: %s: %w", req.GetSubscriptionId(), err))
 	}
 
 	return convertSubscriptionToProto(sub), nil
 }
 
 func (s *Server) FindSubscribers(ctx context.Context, req *proto.FindSubscribersRequest) (*proto.UserList, error) {
-	id, err := uuid.Parse(req.GetDaoId())
+	id, err := grpcsrv.ParseUUID("dao_id", req.GetDaoId())
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid dao id")
+		return nil, err
 	}
 
 	subscribers, err := s.sp.GetSubscribers(ctx, id)
 	if err != nil {
-		return nil, status.Error(codes.Internal, "unable to get subscribers")
+		return nil, errorMapper.Error(fmt.Errorf("get subscribers: %w", err))
 	}
 
 	response := &proto.UserList{
@@ -0,0 +1,357 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	proto "github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
+	"github.com/stretchr/testify/require"
+	"go.openly.dev/pointy"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-inbox-storage/pkg/grpcsrv"
+)
+
+var errBackend = errors.New("backend is down")
+
+type fakeSubscriptions struct {
+	subs        map[uuid.UUID]UserSubscription
+	subscribers []uuid.UUID
+	params      ListParams
+	source      Source
+	err         error
+}
+
+func (f *fakeSubscriptions) Subscribe(_ context.Context, info UserSubscription) (*UserSubscription, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	info.ID = uuid.New()
+	info.CreatedAt = time.Now()
+	f.subs[info.ID] = info
+
+	return &info, nil
+}
+
+func (f *fakeSubscriptions) Unsubscribe(_ context.Context, id uuid.UUID, source Source) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	if _, ok := f.subs[id]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+
+	delete(f.subs, id)
+	f.source = source
+
+	return nil
+}
+
+func (f *fakeSubscriptions) List(_ context.Context, params ListParams) (ListResult, error) {
+	if f.err != nil {
+		return ListResult{}, f.err
+	}
+
+	f.params = params
+
+	var list []UserSubscription
+	for _, sub := range f.subs {
+		list = append(list, sub)
+	}
+
+	return ListResult{Subscriptions: list, TotalCount: 10}, nil
+}
+
+func (f *fakeSubscriptions) GetByID(id uuid.UUID) (*UserSubscription, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	sub, ok := f.subs[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return &sub, nil
+}
+
+func (f *fakeSubscriptions) GetSubscribers(context.Context, uuid.UUID) ([]uuid.UUID, error) {
+	return f.subscribers, f.err
+}
+
+func TestUnitServerSubscribe(t *testing.T) {
+	userID, daoID := uuid.New(), uuid.New()
+
+	for name, tc := range map[string]struct {
+		ctx          context.Context
+		subscriberID string
+		daoID        string
+		err          error
+		source       Source
+		code         codes.Code
+		field        string
+	}{
+		"app by default": {
+			ctx:          context.Background(),
+			subscriberID: userID.String(),
+			daoID:        daoID.String(),
+			source:       SourceApp,
+		},
+		"source from metadata": {
+			ctx:          metadata.NewIncomingContext(context.Background(), metadata.Pairs(SourceMetadataKey, "onboarding")),
+			subscriberID: userID.String(),
+			daoID:        daoID.String(),
+			source:       SourceOnboarding,
+		},
+		"automated source from metadata": {
+			ctx:          metadata.NewIncomingContext(context.Background(), metadata.Pairs(SourceMetadataKey, "import")),
+			subscriberID: userID.String(),
+			daoID:        daoID.String(),
+			source:       SourceApp,
+		},
+		"invalid subscriber id": {
+			ctx:          context.Background(),
+			subscriberID: "wrong",
+			daoID:        daoID.String(),
+			code:         codes.InvalidArgument,
+			field:        "subscriber_id",
+		},
+		"invalid dao id": {
+			ctx:          context.Background(),
+			subscriberID: userID.String(),
+			daoID:        "wrong",
+			code:         codes.InvalidArgument,
+			field:        "dao_id",
+		},
+		"backend error": {
+			ctx:          context.Background(),
+			subscriberID: userID.String(),
+			daoID:        daoID.String(),
+			err:          errBackend,
+			code:         codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sp := &fakeSubscriptions{subs: map[uuid.UUID]UserSubscription{}, err: tc.err}
+
+			res, err := NewServer(sp).Subscribe(tc.ctx, &proto.SubscribeRequest{
+				SubscriberId: tc.subscriberID,
+				DaoId:        tc.daoID,
+			})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.code != codes.OK {
+				require.Empty(t, sp.subs)
+
+				return
+			}
+
+			sub := sp.subs[uuid.MustParse(res.GetSubscriptionId())]
+			require.Equal(t, userID, sub.UserID)
+			require.Equal(t, daoID, sub.DaoID)
+			require.Equal(t, tc.source, sub.Source)
+			require.Equal(t, userID.String(), res.GetSubscriberId())
+			require.Equal(t, daoID.String(), res.GetDaoId())
+			require.Equal(t, sub.CreatedAt.UTC(), res.GetCreatedAt().AsTime())
+		})
+	}
+}
+
+func TestUnitServerUnsubscribe(t *testing.T) {
+	id := uuid.New()
+
+	for name, tc := range map[string]struct {
+		id     string
+		err    error
+		code   codes.Code
+		reason string
+		field  string
+	}{
+		"unsubscribed": {
+			id: id.String(),
+		},
+		"invalid subscription id": {
+			id:    "wrong",
+			code:  codes.InvalidArgument,
+			field: "subscription_id",
+		},
+		"unknown subscription": {
+			id:     uuid.NewString(),
+			code:   codes.NotFound,
+			reason: "NOT_FOUND",
+		},
+		"backend error": {
+			id:   id.String(),
+			err:  errBackend,
+			code: codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sp := &fakeSubscriptions{subs: map[uuid.UUID]UserSubscription{id: {ID: id}}, err: tc.err}
+
+			_, err := NewServer(sp).Unsubscribe(context.Background(), &proto.UnsubscribeRequest{SubscriptionId: tc.id})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.reason, grpcsrv.ErrorReason(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.code == codes.OK {
+				require.NotContains(t, sp.subs, id)
+				require.Equal(t, SourceApp, sp.source)
+			}
+		})
+	}
+}
+
+func TestUnitServerListSubscriptions(t *testing.T) {
+	userID := uuid.New()
+	sub := UserSubscription{
+		ID:        uuid.New(),
+		CreatedAt: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+		UserID:    userID,
+		DaoID:     uuid.New(),
+	}
+
+	for name, tc := range map[string]struct {
+		req    *proto.ListSubscriptionRequest
+		err    error
+		params ListParams
+		code   codes.Code
+		field  string
+	}{
+		"default page": {
+			req:    &proto.ListSubscriptionRequest{SubscriberId: userID.String()},
+			params: ListParams{UserID: userID, SortBy: SortByCreatedAt, Limit: defaultLimit, WithTotal: true},
+		},
+		"requested page": {
+			req:    &proto.ListSubscriptionRequest{SubscriberId: userID.String(), Limit: pointy.Uint64(5), Offset: pointy.Uint64(10)},
+			params: ListParams{UserID: userID, SortBy: SortByCreatedAt, Limit: 5, Offset: 10, WithTotal: true},
+		},
+		"invalid subscriber id": {
+			req:   &proto.ListSubscriptionRequest{SubscriberId: "wrong"},
+			code:  codes.InvalidArgument,
+			field: "subscriber_id",
+		},
+		"backend error": {
+			req:  &proto.ListSubscriptionRequest{SubscriberId: userID.String()},
+			err:  errBackend,
+			code: codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sp := &fakeSubscriptions{subs: map[uuid.UUID]UserSubscription{sub.ID: sub}, err: tc.err}
+
+			res, err := NewServer(sp).ListSubscriptions(context.Background(), tc.req)
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.code != codes.OK {
+				return
+			}
+
+			require.Equal(t, tc.params, sp.params)
+			require.EqualValues(t, 10, res.GetTotalCount())
+			require.Len(t, res.GetItems(), 1)
+			require.Equal(t, sub.ID.String(), res.GetItems()[0].GetSubscriptionId())
+			require.Equal(t, userID.String(), res.GetItems()[0].GetSubscriberId())
+			require.Equal(t, sub.DaoID.String(), res.GetItems()[0].GetDaoId())
+			require.Equal(t, sub.CreatedAt, res.GetItems()[0].GetCreatedAt().AsTime())
+		})
+	}
+}
+
+func TestUnitServerGetSubscription(t *testing.T) {
+	sub := UserSubscription{ID: uuid.New(), UserID: uuid.New(), DaoID: uuid.New()}
+
+	for name, tc := range map[string]struct {
+		id     string
+		err    error
+		code   codes.Code
+		reason string
+		field  string
+	}{
+		"subscription": {
+			id: sub.ID.String(),
+		},
+		"invalid subscription id": {
+			id:    "wrong",
+			code:  codes.InvalidArgument,
+			field: "subscription_id",
+		},
+		"unknown subscription": {
+			id:     uuid.NewString(),
+			code:   codes.NotFound,
+			reason: "NOT_FOUND",
+		},
+		"backend error": {
+			id:   sub.ID.String(),
+			err:  errBackend,
+			code: codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := NewServer(&fakeSubscriptions{subs: map[uuid.UUID]UserSubscription{sub.ID: sub}, err: tc.err})
+
+			res, err := server.GetSubscription(context.Background(), &proto.GetSubscriptionRequest{SubscriptionId: tc.id})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.reason, grpcsrv.ErrorReason(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.code == codes.OK {
+				require.Equal(t, sub.ID.String(), res.GetSubscriptionId())
+				require.Equal(t, sub.UserID.String(), res.GetSubscriberId())
+				require.Equal(t, sub.DaoID.String(), res.GetDaoId())
+			}
+		})
+	}
+}
+
+func TestUnitServerFindSubscribers(t *testing.T) {
+	subscribers := []uuid.UUID{uuid.New(), uuid.New()}
+
+	for name, tc := range map[string]struct {
+		daoID    string
+		err      error
+		expected []string
+		code     codes.Code
+		field    string
+	}{
+		"subscribers": {
+			daoID:    uuid.NewString(),
+			expected: []string{subscribers[0].String(), subscribers[1].String()},
+		},
+		"invalid dao id": {
+			daoID: "wrong",
+			code:  codes.InvalidArgument,
+			field: "dao_id",
+		},
+		"backend error": {
+			daoID: uuid.NewString(),
+			err:   errBackend,
+			code:  codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := NewServer(&fakeSubscriptions{subscribers: subscribers, err: tc.err})
+
+			res, err := server.FindSubscribers(context.Background(), &proto.FindSubscribersRequest{DaoId: tc.daoID})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+
+			var ids []string
+			for _, info := range res.GetUsers() {
+				ids = append(ids, info.GetUserId())
+			}
+			require.Equal(t, tc.expected, ids)
+		})
+	}
+}
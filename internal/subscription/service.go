@@ -7,13 +7,19 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	coresdk "github.com/goverland-labs/goverland-core-sdk-go"
+	coresdkdao "github.com/goverland-labs/goverland-core-sdk-go/dao"
 	"github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-inbox-storage/internal/achievements/recalc"
 )
 
+var ErrNothingToUndo = errors.New("nothing to undo")
+
 type Cacher interface {
 	AddItems(string, ...uuid.UUID)
 	RemoveItem(string, uuid.UUID)
@@ -25,27 +31,41 @@ type CoreSubscriber interface {
 	SubscribeOnDao(ctx context.Context, subscriberID, daoID uuid.UUID) error
 }
 
+type DaoProvider interface {
+	GetDaoList(ctx context.Context, params coresdk.GetDaoListRequest) (*coresdkdao.List, error)
+}
+
 type FeedClient interface {
 	UserSubscribe(context.Context, *inboxapi.UserSubscribeRequest, ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
+type Publisher interface {
+	PublishJSON(ctx context.Context, subject string, obj any) error
+}
+
 type Service struct {
 	repo       *Repo
 	globalRepo *GlobalRepo
+	eventRepo  *EventRepo
 	cache      Cacher
 	subID      uuid.UUID
 	core       CoreSubscriber
+	daos       DaoProvider
 	feed       FeedClient
+	publisher  Publisher
 }
 
-func NewService(r *Repo, gr *GlobalRepo, c Cacher, subID uuid.UUID, cs CoreSubscriber, fc FeedClient) (*Service, error) {
+func NewService(r *Repo, gr *GlobalRepo, er *EventRepo, c Cacher, subID uuid.UUID, cs CoreSubscriber, dp DaoProvider, fc FeedClient, pb Publisher) (*Service, error) {
 	return &Service{
 		repo:       r,
 		globalRepo: gr,
+		eventRepo:  er,
 		cache:      c,
 		subID:      subID,
 		core:       cs,
+		daos:       dp,
 		feed:       fc,
+		publisher:  pb,
 	}, nil
 }
 
@@ -69,6 +89,10 @@ func (s *Service) Subscribe(ctx context.Context, info UserSubscription) (*UserSu
 		return nil, err
 	}
 
+	if info.Source == "" {
+		info.Source = SourceApp
+	}
+
 	info.ID = id
 	info.CreatedAt = time.Now()
 	err = s.repo.Create(info)
@@ -76,6 +100,12 @@ func (s *Service) Subscribe(ctx context.Context, info UserSubscription) (*UserSu
 		return nil, fmt.Errorf("create subscription: %w", err)
 	}
 
+	s.afterSubscribe(ctx, info)
+
+	return &info, err
+}
+
+func (s *Service) afterSubscribe(ctx context.Context, info UserSubscription) {
 	go func(userID, daoID string) {
 		if _, err := s.feed.UserSubscribe(context.WithoutCancel(ctx), &inboxapi.UserSubscribeRequest{
 			SubscriberId: userID,
@@ -87,25 +117,87 @@ func (s *Service) Subscribe(ctx context.Context, info UserSubscription) (*UserSu
 
 	go s.cache.AddItems(info.DaoID.String(), info.UserID)
 
-	return &info, err
+	recalc.Request(context.WithoutCancel(ctx), s.publisher, info.UserID, recalc.TypeSubscriptions)
 }
 
-func (s *Service) Unsubscribe(_ context.Context, id uuid.UUID) error {
+func (s *Service) Unsubscribe(ctx context.Context, id uuid.UUID, source Source) error {
 	sub, err := s.repo.GetByID(id)
 	if err != nil {
 		return fmt.Errorf("get subscription: %w", err)
 	}
 
-	err = s.repo.Delete(*sub)
+	err = s.repo.Delete(*sub, source)
 	if err != nil {
 		return fmt.Errorf("delete scubscription: %s: %w", id, err)
 	}
 
 	go s.cache.RemoveItem(sub.DaoID.String(), sub.UserID)
 
+	recalc.Request(context.WithoutCancel(ctx), s.publisher, sub.UserID, recalc.TypeSubscriptions)
+
 	return nil
 }
 
+// UndoLastUnsubscribe restores the subscription removed by the latest user action,
+// automated changes like auto follow or import are not undone
+func (s *Service) UndoLastUnsubscribe(ctx context.Context, userID uuid.UUID) (*UserSubscription, error) {
+	ev, err := s.eventRepo.GetLastByUser(userID, userSources)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNothingToUndo
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get last event: %w", err)
+	}
+
+	if ev.Type != EventTypeUnsubscribed {
+		return nil, ErrNothingToUndo
+	}
+
+	_, err = s.repo.GetBySubscriberAndDaoID(ev.UserID, ev.DaoID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("get subscription: %w", err)
+	}
+
+	if err == nil {
+		return nil, ErrNothingToUndo
+	}
+
+	sub, err := s.repo.GetDeletedByID(ev.SubscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("get deleted subscription: %w", err)
+	}
+
+	if err = s.makeGlobalSubscription(ctx, sub.DaoID); err != nil {
+		return nil, err
+	}
+
+	if err = s.repo.Restore(*sub, SourceUndo); err != nil {
+		return nil, fmt.Errorf("restore subscription: %s: %w", sub.ID, err)
+	}
+
+	sub.DeletedAt = gorm.DeletedAt{}
+	sub.Source = SourceUndo
+	s.afterSubscribe(ctx, *sub)
+
+	return sub, nil
+}
+
+// WasUnsubscribedByUser returns true if the user has manually unsubscribed from the dao
+func (s *Service) WasUnsubscribedByUser(userID, daoID uuid.UUID) (bool, error) {
+	return s.eventRepo.ExistsBySource(userID, daoID, EventTypeUnsubscribed, []Source{SourceAutoFollow})
+}
+
+// GetHistory returns subscription events by filters from the newest to the oldest
+func (s *Service) GetHistory(filters []Filter) (EventList, error) {
+	list, err := s.eventRepo.GetByFilters(filters)
+	if err != nil {
+		return EventList{}, fmt.Errorf("get events by filters: %w", err)
+	}
+
+	return list, nil
+}
+
 func (s *Service) GetSubscribers(_ context.Context, daoID uuid.UUID) ([]uuid.UUID, error) {
 	if list, ok := s.cache.GetItems(daoID.String()); ok {
 		return list, nil
@@ -132,7 +224,9 @@ func (s *Service) InitSubscribers() error {
 	subscribersByDao := make(map[string][]uuid.UUID)
 	for {
 		data, err := s.repo.GetByFilters([]Filter{
+			CreatedAtOrderFilter{},
 			PageFilter{Limit: limit, Offset: offset},
+			WithoutTotalFilter{},
 		})
 		if err != nil {
 			return fmt.Errorf("get subscribers [%d/%d]: %w", limit, offset, err)
@@ -207,6 +301,53 @@ func (s *Service) makeGlobalSubscription(ctx context.Context, daoID uuid.UUID) e
 	return nil
 }
 
+// GetPushSettings returns per dao push settings overrides, nil means there are no overrides
+func (s *Service) GetPushSettings(id uuid.UUID) (*PushSettings, error) {
+	sub, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("get subscription: %w", err)
+	}
+
+	return sub.PushSettings, nil
+}
+
+// SetPushSettings replaces per dao push settings overrides, empty settings reset overrides to the global ones
+func (s *Service) SetPushSettings(id uuid.UUID, ps PushSettings) error {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return fmt.Errorf("get subscription: %w", err)
+	}
+
+	var overrides *PushSettings
+	if !ps.IsEmpty() {
+		overrides = &ps
+	}
+
+	if err := s.repo.UpdatePushSettings(id, overrides); err != nil {
+		return fmt.Errorf("update push settings: %s: %w", id, err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetBySubscriberAndDaoID(subscriberID, daoID uuid.UUID) (*UserSubscription, error) {
+	sub, err := s.repo.GetBySubscriberAndDaoID(subscriberID, daoID)
+	if err != nil {
+		return nil, fmt.Errorf("get subscription by subscriber and dao: %w", err)
+	}
+
+	return &sub, nil
+}
+
+// CountByUser returns the number of active user subscriptions created in the optional period
+func (s *Service) CountByUser(userID uuid.UUID, from, to *time.Time) (int, error) {
+	count, err := s.repo.CountByUser(userID, from, to)
+	if err != nil {
+		return 0, fmt.Errorf("count subscriptions: %w", err)
+	}
+
+	return int(count), nil
+}
+
 func (s *Service) GetByID(id uuid.UUID) (*UserSubscription, error) {
 	return s.repo.GetByID(id)
 }
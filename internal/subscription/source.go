@@ -0,0 +1,31 @@
+package subscription
+
+import (
+	"context"
+	"slices"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// SourceMetadataKey is set by the api to describe where the user changed subscriptions
+const SourceMetadataKey = "x-subscription-source"
+
+// sourceFromContext returns the source provided by the caller, unknown and automated sources fall back to the app
+func sourceFromContext(ctx context.Context) Source {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return SourceApp
+	}
+
+	values := md.Get(SourceMetadataKey)
+	if len(values) == 0 {
+		return SourceApp
+	}
+
+	source := Source(values[0])
+	if !slices.Contains(clientSources, source) {
+		return SourceApp
+	}
+
+	return source
+}
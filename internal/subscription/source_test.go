@@ -0,0 +1,31 @@
+package subscription
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestUnitSourceFromContext(t *testing.T) {
+	for name, tc := range map[string]struct {
+		md       metadata.MD
+		expected Source
+	}{
+		"without metadata":      {expected: SourceApp},
+		"onboarding":            {md: metadata.Pairs(SourceMetadataKey, "onboarding"), expected: SourceOnboarding},
+		"wallet recommendation": {md: metadata.Pairs(SourceMetadataKey, "wallet_recommendation"), expected: SourceWalletRecommendation},
+		"automated source":      {md: metadata.Pairs(SourceMetadataKey, "auto_follow"), expected: SourceApp},
+		"unknown source":        {md: metadata.Pairs(SourceMetadataKey, "unknown"), expected: SourceApp},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tc.md)
+			}
+
+			require.Equal(t, tc.expected, sourceFromContext(ctx))
+		})
+	}
+}
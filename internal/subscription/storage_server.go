@@ -0,0 +1,245 @@
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/goverland-labs/goverland-inbox-storage/pkg/grpcsrv"
+	storagepb "github.com/goverland-labs/goverland-inbox-storage/protobuf/inboxstorage"
+)
+
+type StorageServiceProvider interface {
+	GetPushSettings(id uuid.UUID) (*PushSettings, error)
+	SetPushSettings(id uuid.UUID, ps PushSettings) error
+	GetFollowerCounts(daoIDs []uuid.UUID) ([]FollowerCount, error)
+	GetFollowTrend(daoID uuid.UUID, interval TrendInterval, from, to time.Time) ([]TrendPoint, error)
+	GetTopDaos(from, to time.Time, limit int) ([]DaoGrowth, error)
+	GetHistory(filters []Filter) (EventList, error)
+	UndoLastUnsubscribe(ctx context.Context, userID uuid.UUID) (*UserSubscription, error)
+}
+
+// StorageServer implements subscription methods of the storage protocol
+type StorageServer struct {
+	storagepb.UnimplementedSubscriptionStorageServer
+
+	sp StorageServiceProvider
+}
+
+func NewStorageServer(s StorageServiceProvider) *StorageServer {
+	return &StorageServer{
+		sp: s,
+	}
+}
+
+func (s *StorageServer) GetPushSettings(_ context.Context, req *storagepb.GetSubscriptionPushSettingsRequest) (*storagepb.SubscriptionPushSettings, error) {
+	id, err := grpcsrv.ParseUUID("subscription_id", req.GetSubscriptionId())
+	if err != nil {
+		return nil, err
+	}
+
+	ps, err := s.sp.GetPushSettings(id)
+	if err != nil {
+		return nil, errorMapper.Error(fmt.Errorf("get push settings: %s: %w", req.GetSubscriptionId(), err))
+	}
+
+	if ps == nil {
+		return &storagepb.SubscriptionPushSettings{}, nil
+	}
+
+	return &storagepb.SubscriptionPushSettings{
+		NewProposalCreated: ps.NewProposalCreated,
+		QuorumReached:      ps.QuorumReached,
+		VoteFinishesSoon:   ps.VoteFinishesSoon,
+		VoteFinished:       ps.VoteFinished,
+	}, nil
+}
+
+func (s *StorageServer) SetPushSettings(_ context.Context, req *storagepb.SetSubscriptionPushSettingsRequest) (*emptypb.Empty, error) {
+	id, err := grpcsrv.ParseUUID("subscription_id", req.GetSubscriptionId())
+	if err != nil {
+		return nil, err
+	}
+
+	// missing settings reset all overrides of the subscription
+	var ps PushSettings
+	if settings := req.GetSettings(); settings != nil {
+		ps = PushSettings{
+			NewProposalCreated: settings.NewProposalCreated,
+			QuorumReached:      settings.QuorumReached,
+			VoteFinishesSoon:   settings.VoteFinishesSoon,
+			VoteFinished:       settings.VoteFinished,
+		}
+	}
+
+	if err = s.sp.SetPushSettings(id, ps); err != nil {
+		return nil, errorMapper.Error(fmt.Errorf("set push settings: %s: %w", req.GetSubscriptionId(), err))
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *StorageServer) GetFollowerCounts(_ context.Context, req *storagepb.GetFollowerCountsRequest) (*storagepb.GetFollowerCountsResponse, error) {
+	daoIDs := make([]uuid.UUID, 0, len(req.GetDaoIds()))
+	for _, value := range req.GetDaoIds() {
+		id, err := grpcsrv.ParseUUID("dao_ids", value)
+		if err != nil {
+			return nil, err
+		}
+
+		daoIDs = append(daoIDs, id)
+	}
+
+	list, err := s.sp.GetFollowerCounts(daoIDs)
+	if err != nil {
+		return nil, errorMapper.Error(fmt.Errorf("get follower counts: %w", err))
+	}
+
+	res := &storagepb.GetFollowerCountsResponse{
+		Counts: make([]*storagepb.FollowerCount, 0, len(list)),
+	}
+	for _, info := range list {
+		res.Counts = append(res.Counts, &storagepb.FollowerCount{
+			DaoId: info.DaoID.String(),
+			Count: info.Count,
+		})
+	}
+
+	return res, nil
+}
+
+func (s *StorageServer) GetFollowTrend(_ context.Context, req *storagepb.GetFollowTrendRequest) (*storagepb.GetFollowTrendResponse, error) {
+	daoID, err := grpcsrv.ParseUUID("dao_id", req.GetDaoId())
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.sp.GetFollowTrend(daoID, TrendInterval(req.GetInterval()), convertTime(req.GetFrom()), convertTime(req.GetTo()))
+	if err != nil {
+		return nil, errorMapper.Error(fmt.Errorf("get follow trend: %s: %w", req.GetDaoId(), err))
+	}
+
+	res := &storagepb.GetFollowTrendResponse{
+		Points: make([]*storagepb.TrendPoint, 0, len(list)),
+	}
+	for _, point := range list {
+		res.Points = append(res.Points, &storagepb.TrendPoint{
+			Period:    timestamppb.New(point.Period),
+			Follows:   point.Follows,
+			Unfollows: point.Unfollows,
+		})
+	}
+
+	return res, nil
+}
+
+func (s *StorageServer) GetTopDaos(_ context.Context, req *storagepb.GetTopDaosRequest) (*storagepb.GetTopDaosResponse, error) {
+	list, err := s.sp.GetTopDaos(convertTime(req.GetFrom()), convertTime(req.GetTo()), int(req.GetLimit()))
+	if err != nil {
+		return nil, errorMapper.Error(fmt.Errorf("get top daos: %w", err))
+	}
+
+	res := &storagepb.GetTopDaosResponse{
+		Daos: make([]*storagepb.DaoGrowth, 0, len(list)),
+	}
+	for _, info := range list {
+		res.Daos = append(res.Daos, &storagepb.DaoGrowth{
+			DaoId:     info.DaoID.String(),
+			Follows:   info.Follows,
+			Unfollows: info.Unfollows,
+			NetGrowth: info.NetGrowth,
+		})
+	}
+
+	return res, nil
+}
+
+func (s *StorageServer) ListSubscriptionHistory(_ context.Context, req *storagepb.ListSubscriptionHistoryRequest) (*storagepb.ListSubscriptionHistoryResponse, error) {
+	userID, err := grpcsrv.ParseUUID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	filters := []Filter{
+		UserIDFilter{ID: userID.String()},
+		CreatedAtFilter{From: convertTime(req.GetFrom()), To: convertTime(req.GetTo())},
+	}
+
+	if len(req.GetDaoIds()) > 0 {
+		daoIDs := make([]uuid.UUID, 0, len(req.GetDaoIds()))
+		for _, value := range req.GetDaoIds() {
+			id, err := grpcsrv.ParseUUID("dao_ids", value)
+			if err != nil {
+				return nil, err
+			}
+
+			daoIDs = append(daoIDs, id)
+		}
+
+		filters = append(filters, DaoIDsFilter{IDs: daoIDs})
+	}
+
+	limit, offset := defaultLimit, defaultOffset
+	if req.GetLimit() > 0 {
+		limit = int(req.GetLimit())
+	}
+	if req.GetOffset() > 0 {
+		offset = int(req.GetOffset())
+	}
+	filters = append(filters, PageFilter{Limit: limit, Offset: offset})
+
+	list, err := s.sp.GetHistory(filters)
+	if err != nil {
+		return nil, errorMapper.Error(fmt.Errorf("get subscription history: %s: %w", req.GetUserId(), err))
+	}
+
+	res := &storagepb.ListSubscriptionHistoryResponse{
+		Events:     make([]*storagepb.SubscriptionEvent, 0, len(list.Events)),
+		TotalCount: uint64(list.TotalCount),
+	}
+	for _, ev := range list.Events {
+		res.Events = append(res.Events, &storagepb.SubscriptionEvent{
+			Id:             ev.ID,
+			CreatedAt:      timestamppb.New(ev.CreatedAt),
+			SubscriptionId: ev.SubscriptionID.String(),
+			UserId:         ev.UserID.String(),
+			DaoId:          ev.DaoID.String(),
+			Type:           string(ev.Type),
+			Source:         string(ev.Source),
+		})
+	}
+
+	return res, nil
+}
+
+func (s *StorageServer) UndoLastUnsubscribe(ctx context.Context, req *storagepb.UndoLastUnsubscribeRequest) (*storagepb.RestoredSubscription, error) {
+	userID, err := grpcsrv.ParseUUID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := s.sp.UndoLastUnsubscribe(ctx, userID)
+	if err != nil {
+		return nil, errorMapper.Error(fmt.Errorf("undo last unsubscribe: %s: %w", req.GetUserId(), err))
+	}
+
+	return &storagepb.RestoredSubscription{
+		SubscriptionId: sub.ID.String(),
+		SubscriberId:   sub.UserID.String(),
+		DaoId:          sub.DaoID.String(),
+		CreatedAt:      timestamppb.New(sub.CreatedAt),
+	}, nil
+}
+
+// convertTime returns zero time for missing timestamps to reject them by the period validation
+func convertTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+
+	return ts.AsTime()
+}
@@ -0,0 +1,325 @@
+package subscription
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.openly.dev/pointy"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-inbox-storage/pkg/grpcsrv"
+	storagepb "github.com/goverland-labs/goverland-inbox-storage/protobuf/inboxstorage"
+)
+
+type fakeStorageService struct {
+	settings map[uuid.UUID]*PushSettings
+	trend    []TrendPoint
+	events   []Event
+	restored *UserSubscription
+	filters  []Filter
+	err      error
+}
+
+func (f *fakeStorageService) GetPushSettings(id uuid.UUID) (*PushSettings, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	ps, ok := f.settings[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return ps, nil
+}
+
+func (f *fakeStorageService) SetPushSettings(id uuid.UUID, ps PushSettings) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	if _, ok := f.settings[id]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+
+	f.settings[id] = &ps
+
+	return nil
+}
+
+func (f *fakeStorageService) GetFollowerCounts(daoIDs []uuid.UUID) ([]FollowerCount, error) {
+	list := make([]FollowerCount, 0, len(daoIDs))
+	for i, id := range daoIDs {
+		list = append(list, FollowerCount{DaoID: id, Count: int64(i)})
+	}
+
+	return list, f.err
+}
+
+func (f *fakeStorageService) GetFollowTrend(_ uuid.UUID, interval TrendInterval, from, to time.Time) ([]TrendPoint, error) {
+	if interval != TrendIntervalDay {
+		return nil, ErrInvalidTrendInterval
+	}
+
+	if from.IsZero() || to.IsZero() {
+		return nil, ErrInvalidPeriod
+	}
+
+	return f.trend, f.err
+}
+
+func (f *fakeStorageService) GetTopDaos(_, _ time.Time, _ int) ([]DaoGrowth, error) {
+	return nil, f.err
+}
+
+func (f *fakeStorageService) GetHistory(filters []Filter) (EventList, error) {
+	f.filters = filters
+
+	return EventList{Events: f.events, TotalCount: int64(len(f.events))}, f.err
+}
+
+func (f *fakeStorageService) UndoLastUnsubscribe(_ context.Context, _ uuid.UUID) (*UserSubscription, error) {
+	if f.restored == nil {
+		return nil, ErrNothingToUndo
+	}
+
+	return f.restored, f.err
+}
+
+func TestUnitStorageServerPushSettings(t *testing.T) {
+	withOverrides, withoutOverrides := uuid.New(), uuid.New()
+
+	for name, tc := range map[string]struct {
+		id       string
+		err      error
+		expected *storagepb.SubscriptionPushSettings
+		code     codes.Code
+		reason   string
+		field    string
+	}{
+		"overrides": {
+			id:       withOverrides.String(),
+			expected: &storagepb.SubscriptionPushSettings{QuorumReached: pointy.Bool(false)},
+		},
+		"no overrides": {
+			id:       withoutOverrides.String(),
+			expected: &storagepb.SubscriptionPushSettings{},
+		},
+		"invalid id": {
+			id:    "wrong",
+			code:  codes.InvalidArgument,
+			field: "subscription_id",
+		},
+		"unknown subscription": {
+			id:     uuid.NewString(),
+			code:   codes.NotFound,
+			reason: "NOT_FOUND",
+		},
+		"backend error": {
+			id:   withOverrides.String(),
+			err:  errBackend,
+			code: codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := NewStorageServer(&fakeStorageService{
+				settings: map[uuid.UUID]*PushSettings{
+					withOverrides:    {QuorumReached: pointy.Bool(false)},
+					withoutOverrides: nil,
+				},
+				err: tc.err,
+			})
+
+			res, err := server.GetPushSettings(context.Background(), &storagepb.GetSubscriptionPushSettingsRequest{SubscriptionId: tc.id})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.reason, grpcsrv.ErrorReason(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.expected != nil {
+				require.Equal(t, tc.expected.NewProposalCreated, res.NewProposalCreated)
+				require.Equal(t, tc.expected.QuorumReached, res.QuorumReached)
+				require.Equal(t, tc.expected.VoteFinishesSoon, res.VoteFinishesSoon)
+				require.Equal(t, tc.expected.VoteFinished, res.VoteFinished)
+			}
+		})
+	}
+}
+
+func TestUnitStorageServerSetPushSettings(t *testing.T) {
+	id := uuid.New()
+	service := &fakeStorageService{settings: map[uuid.UUID]*PushSettings{id: nil}}
+	server := NewStorageServer(service)
+
+	_, err := server.SetPushSettings(context.Background(), &storagepb.SetSubscriptionPushSettingsRequest{
+		SubscriptionId: id.String(),
+		Settings:       &storagepb.SubscriptionPushSettings{VoteFinished: pointy.Bool(true)},
+	})
+	require.NoError(t, err)
+	require.Equal(t, &PushSettings{VoteFinished: pointy.Bool(true)}, service.settings[id])
+
+	_, err = server.SetPushSettings(context.Background(), &storagepb.SetSubscriptionPushSettingsRequest{SubscriptionId: uuid.NewString()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestUnitStorageServerGetFollowTrend(t *testing.T) {
+	period := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		req      *storagepb.GetFollowTrendRequest
+		expected []*storagepb.TrendPoint
+		code     codes.Code
+		reason   string
+		field    string
+	}{
+		"trend": {
+			req: &storagepb.GetFollowTrendRequest{
+				DaoId:    uuid.NewString(),
+				Interval: "day",
+				From:     timestamppb.New(period),
+				To:       timestamppb.New(period.AddDate(0, 0, 1)),
+			},
+			expected: []*storagepb.TrendPoint{{Period: timestamppb.New(period), Follows: 3, Unfollows: 1}},
+		},
+		"invalid dao": {
+			req:   &storagepb.GetFollowTrendRequest{DaoId: "wrong"},
+			code:  codes.InvalidArgument,
+			field: "dao_id",
+		},
+		"invalid interval": {
+			req:    &storagepb.GetFollowTrendRequest{DaoId: uuid.NewString(), Interval: "year"},
+			code:   codes.InvalidArgument,
+			reason: "INVALID_TREND_INTERVAL",
+		},
+		"missing period": {
+			req:    &storagepb.GetFollowTrendRequest{DaoId: uuid.NewString(), Interval: "day"},
+			code:   codes.InvalidArgument,
+			reason: "INVALID_PERIOD",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := NewStorageServer(&fakeStorageService{
+				trend: []TrendPoint{{Period: period, Follows: 3, Unfollows: 1}},
+			})
+
+			res, err := server.GetFollowTrend(context.Background(), tc.req)
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.reason, grpcsrv.ErrorReason(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.expected != nil {
+				require.Len(t, res.GetPoints(), len(tc.expected))
+				for i, point := range res.GetPoints() {
+					require.Equal(t, tc.expected[i].GetPeriod().AsTime(), point.GetPeriod().AsTime())
+					require.Equal(t, tc.expected[i].GetFollows(), point.GetFollows())
+					require.Equal(t, tc.expected[i].GetUnfollows(), point.GetUnfollows())
+				}
+			}
+		})
+	}
+}
+
+func TestUnitStorageServerGetFollowerCounts(t *testing.T) {
+	server := NewStorageServer(&fakeStorageService{})
+	first, second := uuid.NewString(), uuid.NewString()
+
+	res, err := server.GetFollowerCounts(context.Background(), &storagepb.GetFollowerCountsRequest{DaoIds: []string{first, second}})
+	require.NoError(t, err)
+	require.Len(t, res.GetCounts(), 2)
+	require.Equal(t, first, res.GetCounts()[0].GetDaoId())
+	require.Equal(t, second, res.GetCounts()[1].GetDaoId())
+	require.EqualValues(t, 1, res.GetCounts()[1].GetCount())
+
+	_, err = server.GetFollowerCounts(context.Background(), &storagepb.GetFollowerCountsRequest{DaoIds: []string{first, "wrong"}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Equal(t, "dao_ids", grpcsrv.ViolatedField(err))
+}
+
+func TestUnitStorageServerListSubscriptionHistory(t *testing.T) {
+	userID, daoID := uuid.New(), uuid.New()
+	ev := Event{
+		ID:             7,
+		CreatedAt:      time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+		SubscriptionID: uuid.New(),
+		UserID:         userID,
+		DaoID:          daoID,
+		Type:           EventTypeUnsubscribed,
+		Source:         SourceOnboarding,
+	}
+	service := &fakeStorageService{events: []Event{ev}}
+	server := NewStorageServer(service)
+
+	res, err := server.ListSubscriptionHistory(context.Background(), &storagepb.ListSubscriptionHistoryRequest{
+		UserId: userID.String(),
+		DaoIds: []string{daoID.String()},
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, res.GetTotalCount())
+	require.Len(t, res.GetEvents(), 1)
+	require.EqualValues(t, 7, res.GetEvents()[0].GetId())
+	require.Equal(t, ev.CreatedAt, res.GetEvents()[0].GetCreatedAt().AsTime())
+	require.Equal(t, ev.SubscriptionID.String(), res.GetEvents()[0].GetSubscriptionId())
+	require.Equal(t, daoID.String(), res.GetEvents()[0].GetDaoId())
+	require.Equal(t, "unsubscribed", res.GetEvents()[0].GetType())
+	require.Equal(t, "onboarding", res.GetEvents()[0].GetSource())
+	require.Contains(t, service.filters, Filter(DaoIDsFilter{IDs: []uuid.UUID{daoID}}))
+	require.Contains(t, service.filters, Filter(PageFilter{Limit: 10}))
+
+	_, err = server.ListSubscriptionHistory(context.Background(), &storagepb.ListSubscriptionHistoryRequest{UserId: "wrong"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Equal(t, "user_id", grpcsrv.ViolatedField(err))
+}
+
+func TestUnitStorageServerUndoLastUnsubscribe(t *testing.T) {
+	restored := &UserSubscription{
+		ID:        uuid.New(),
+		CreatedAt: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+		UserID:    uuid.New(),
+		DaoID:     uuid.New(),
+	}
+
+	for name, tc := range map[string]struct {
+		userID   string
+		restored *UserSubscription
+		code     codes.Code
+		reason   string
+		field    string
+	}{
+		"restored": {
+			userID:   restored.UserID.String(),
+			restored: restored,
+		},
+		"nothing to undo": {
+			userID: restored.UserID.String(),
+			code:   codes.FailedPrecondition,
+			reason: "NOTHING_TO_UNDO",
+		},
+		"invalid user": {
+			userID: "wrong",
+			code:   codes.InvalidArgument,
+			field:  "user_id",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := NewStorageServer(&fakeStorageService{restored: tc.restored})
+
+			res, err := server.UndoLastUnsubscribe(context.Background(), &storagepb.UndoLastUnsubscribeRequest{UserId: tc.userID})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.reason, grpcsrv.ErrorReason(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.restored != nil {
+				require.Equal(t, tc.restored.ID.String(), res.GetSubscriptionId())
+				require.Equal(t, tc.restored.UserID.String(), res.GetSubscriberId())
+				require.Equal(t, tc.restored.DaoID.String(), res.GetDaoId())
+				require.Equal(t, tc.restored.CreatedAt, res.GetCreatedAt().AsTime())
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package user
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sort"
@@ -8,6 +9,8 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-inbox-storage/internal/achievements/recalc"
 )
 
 const (
@@ -48,12 +51,48 @@ func (s *Service) TrackActivity(userID, sessionID uuid.UUID) error {
 		return s.repo.UpdateUserActivity(activity)
 	}
 
+	last, err := s.GetLastActivity(userID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
 	activity = &Activity{
+		Model:      gorm.Model{CreatedAt: now},
 		UserID:     userID,
-		FinishedAt: time.Now(),
+		FinishedAt: now,
+	}
+
+	if err = s.repo.AddUserActivity(activity); err != nil {
+		return err
+	}
+
+	if streakDayChanged(last, now) {
+		recalc.Request(context.TODO(), s.publisher, userID, recalc.TypeActivityStreak)
 	}
 
-	return s.repo.AddUserActivity(activity)
+	return nil
+}
+
+// streakDayChanged checks if the new activity adds the day to the streak. Streak days are counted by
+// the activity creation date in UTC, so only the first activity of the day changes the streak.
+func streakDayChanged(last *Activity, now time.Time) bool {
+	if last == nil {
+		return true
+	}
+
+	return !activityDay(last.CreatedAt).Equal(activityDay(now))
+}
+
+func activityDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// GetActivityDays returns days with user activity since the date ordered from the latest one
+func (s *Service) GetActivityDays(userID uuid.UUID, since time.Time) ([]time.Time, error) {
+	return s.repo.GetActivityDays(userID, since)
 }
 
 func (s *Service) GetLastActivity(userID uuid.UUID) (*Activity, error) {
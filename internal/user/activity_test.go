@@ -0,0 +1,33 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestUnitStreakDayChanged(t *testing.T) {
+	now := time.Date(2024, 11, 5, 10, 0, 0, 0, time.UTC)
+	activity := func(createdAt time.Time) *Activity {
+		return &Activity{Model: gorm.Model{CreatedAt: createdAt}}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		last     *Activity
+		now      time.Time
+		expected bool
+	}{
+		{name: "first activity", now: now, expected: true},
+		{name: "same day", last: activity(now.Add(-9 * time.Hour)), now: now, expected: false},
+		{name: "previous day", last: activity(now.Add(-11 * time.Hour)), now: now, expected: true},
+		{name: "same utc day in other zone", last: activity(time.Date(2024, 11, 5, 5, 0, 0, 0, time.FixedZone("UTC+3", 3*3600))), now: now, expected: false},
+		{name: "previous utc day in other zone", last: activity(time.Date(2024, 11, 5, 2, 0, 0, 0, time.FixedZone("UTC+3", 3*3600))), now: now, expected: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, streakDayChanged(tc.last, tc.now))
+		})
+	}
+}
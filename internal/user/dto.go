@@ -7,6 +7,7 @@ type CreateSessionRequest struct {
 	DeviceName     string  `json:"device_name"`
 	AppVersion     string  `json:"app_version"`
 	AppPlatform    string  `json:"app_platform"`
+	Locale         string  `json:"locale"`
 	Role           Role    `json:"role"`
 }
 
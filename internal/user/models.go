@@ -58,6 +58,8 @@ type Session struct {
 	DeviceName  string
 	AppVersion  string
 	AppPlatform string
+	// Locale is requested by the app on creating the session, empty if unknown
+	Locale string
 
 	LastActivityAt time.Time
 }
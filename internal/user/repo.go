@@ -1,6 +1,7 @@
 package user
 
 import (
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -55,6 +56,42 @@ func (r *Repo) GetByAddress(address string) (*User, error) {
 	return &user, nil
 }
 
+// GetRegularByAddressOrENS looks for the regular user by the wallet address or the resolved ens name. The address
+// match is preferred, the ens name shared by several users is ambiguous.
+func (r *Repo) GetRegularByAddressOrENS(value string) (*User, error) {
+	var list []User
+	request := r.db.
+		Where("role = ?", RegularRole).
+		Where("lower(address) = lower(?)", value).
+		Limit(1).
+		Find(&list)
+	if err := request.Error; err != nil {
+		return nil, fmt.Errorf("get user by address #%s: %w", value, err)
+	}
+
+	if len(list) > 0 {
+		return &list[0], nil
+	}
+
+	request = r.db.
+		Where("role = ?", RegularRole).
+		Where("lower(ens) = lower(?)", value).
+		Limit(2).
+		Find(&list)
+	if err := request.Error; err != nil {
+		return nil, fmt.Errorf("get user by ens #%s: %w", value, err)
+	}
+
+	switch len(list) {
+	case 0:
+		return nil, fmt.Errorf("get user by address or ens #%s: %w", value, gorm.ErrRecordNotFound)
+	case 1:
+		return &list[0], nil
+	default:
+		return nil, fmt.Errorf("get user by ens #%s: %w", value, ErrAmbiguousENS)
+	}
+}
+
 // GetWithoutEnsName TODO partial optimization
 func (r *Repo) GetRegularWithoutEnsName() ([]User, error) {
 	var list []User
@@ -159,6 +196,29 @@ func (r *Repo) GetLastActivity(userID uuid.UUID) (*Activity, error) {
 	return &activity, nil
 }
 
+// GetActivityDays returns distinct days with user activity since the date ordered from the latest one
+func (r *Repo) GetActivityDays(userID uuid.UUID, since time.Time) ([]time.Time, error) {
+	var days []time.Time
+	err := r.db.
+		Raw(`
+select distinct date_trunc('day', created_at at time zone 'UTC') as day
+from user_activity
+where user_id = @user_id
+  and created_at >= @since
+  and deleted_at is null
+order by day desc`,
+			sql.Named("user_id", userID),
+			sql.Named("since", since),
+		).
+		Scan(&days).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return days, nil
+}
+
 func (r *Repo) GetByFilters(filters []Filter) ([]Activity, error) {
 	db := r.db
 	for _, f := range filters {
@@ -3,6 +3,8 @@ package user
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	proto "github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
@@ -12,21 +14,77 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-inbox-storage/internal/audit"
+	"github.com/goverland-labs/goverland-inbox-storage/pkg/grpcsrv"
+)
+
+var errorMapper = grpcsrv.NewErrorMapper(
+	grpcsrv.ErrorRule{Err: ErrUserHasNoAddress, Code: codes.FailedPrecondition, Reason: "USER_HAS_NO_ADDRESS"},
 )
 
+type Auditor interface {
+	Record(ctx context.Context, userID uuid.UUID, op audit.Operation, before, after any)
+}
+
+type ServiceProvider interface {
+	CreateSession(request CreateSessionRequest) (*Session, error)
+	GetSessionByID(id uuid.UUID) (*Session, error)
+	DeleteSession(id uuid.UUID) error
+	UseAuthNonce(address string, nonce string, expiredAt time.Time) (bool, error)
+	GetByID(id uuid.UUID) (*User, error)
+	GetByAddress(address string) (*User, error)
+	GetProfileInfo(userID uuid.UUID) (ProfileInfo, error)
+	DeleteUser(id uuid.UUID) error
+	AddView(userID uuid.UUID, vt RecentlyType, id string) error
+	LastViewed(userID uuid.UUID, limit int64) ([]RecentlyViewed, error)
+	TrackActivity(userID, sessionID uuid.UUID) error
+	GetUserCanVoteProposals(userID uuid.UUID) ([]string, error)
+	AllowSendingPush(userID uuid.UUID) (bool, error)
+	GetAvailableDaoByUser(userID uuid.UUID) ([]string, error)
+}
+
 type Server struct {
 	proto.UnimplementedUserServer
 
-	sp *Service
+	sp      ServiceProvider
+	auditor Auditor
+}
+
+// sessionAudit is the session stored in the audit log
+type sessionAudit struct {
+	SessionID  uuid.UUID `json:"session_id"`
+	DeviceUUID string    `json:"device_uuid"`
+	DeviceName string    `json:"device_name"`
+}
+
+// authNonceAudit is the used nonce stored in the audit log, the nonce is redacted
+type authNonceAudit struct {
+	Address string `json:"address"`
+	Nonce   string `json:"nonce"`
+	Valid   bool   `json:"valid"`
 }
 
-func NewServer(s *Service) *Server {
+func newSessionAudit(session *Session) *sessionAudit {
+	if session == nil {
+		return nil
+	}
+
+	return &sessionAudit{
+		SessionID:  session.ID,
+		DeviceUUID: session.DeviceUUID,
+		DeviceName: session.DeviceName,
+	}
+}
+
+func NewServer(s ServiceProvider, a Auditor) *Server {
 	return &Server{
-		sp: s,
+		sp:      s,
+		auditor: a,
 	}
 }
 
-func (s *Server) CreateSession(_ context.Context, req *proto.CreateSessionRequest) (*proto.CreateSessionResponse, error) {
+func (s *Server) CreateSession(ctx context.Context, req *proto.CreateSessionRequest) (*proto.CreateSessionResponse, error) {
 	var (
 		role           Role
 		address        *string
@@ -41,11 +99,11 @@ func (s *Server) CreateSession(_ context.Context, req *proto.CreateSessionReques
 		address = &req.GetRegular().Address
 		guestSessionID = req.GetRegular().GuestSessionId
 	default:
-		return nil, status.Error(codes.InvalidArgument, "invalid account type")
+		return nil, grpcsrv.InvalidArgument("account", "unknown account type")
 	}
 
 	if role == UnknownRole {
-		return nil, status.Error(codes.InvalidArgument, "invalid role")
+		return nil, grpcsrv.InvalidArgument("account", "unknown role")
 	}
 
 	request := CreateSessionRequest{
@@ -55,21 +113,20 @@ func (s *Server) CreateSession(_ context.Context, req *proto.CreateSessionReques
 		DeviceName:     req.DeviceName,
 		AppVersion:     req.AppVersion,
 		AppPlatform:    req.AppPlatform,
+		Locale:         grpcsrv.LocaleFromContext(ctx),
 		Role:           role,
 	}
 
 	session, err := s.sp.CreateSession(request)
 	if err != nil {
-		log.Error().Err(err).Msgf("create session")
-
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, errorMapper.Error(fmt.Errorf("create session: %w", err))
 	}
 
+	s.auditor.Record(ctx, session.UserID, audit.OperationCreateSession, nil, newSessionAudit(session))
+
 	profileInfo, err := s.sp.GetProfileInfo(session.UserID)
 	if err != nil {
-		log.Error().Err(err).Msgf("get profile info")
-
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, errorMapper.Error(fmt.Errorf("get profile info: %w", err))
 	}
 
 	return &proto.CreateSessionResponse{
@@ -80,10 +137,10 @@ func (s *Server) CreateSession(_ context.Context, req *proto.CreateSessionReques
 
 func (s *Server) UseAuthNonce(ctx context.Context, req *proto.UseAuthNonceRequest) (*proto.UseAuthNonceResponse, error) {
 	if req.GetNonce() == "" {
-		return nil, status.Error(codes.InvalidArgument, "invalid nonce")
+		return nil, grpcsrv.InvalidArgument("nonce", "must not be empty")
 	}
 	if req.GetAddress() == "" {
-		return nil, status.Error(codes.InvalidArgument, "invalid address")
+		return nil, grpcsrv.InvalidArgument("address", "must not be empty")
 	}
 
 	valid, err := s.sp.UseAuthNonce(req.GetAddress(), req.GetNonce(), req.GetExpiredAt().AsTime())
@@ -98,26 +155,32 @@ func (s *Server) UseAuthNonce(ctx context.Context, req *proto.UseAuthNonceReques
 		return nil, status.Error(codes.Unauthenticated, "cannot use nonce")
 	}
 
+	// the nonce is used before the user is created, so the record is stored without the user
+	var userID uuid.UUID
+	if u, err := s.sp.GetByAddress(req.GetAddress()); err == nil {
+		userID = u.ID
+	}
+
+	s.auditor.Record(ctx, userID, audit.OperationUseAuthNonce, nil, authNonceAudit{
+		Address: req.GetAddress(),
+		Nonce:   audit.RedactToken(req.GetNonce()),
+		Valid:   valid,
+	})
+
 	return &proto.UseAuthNonceResponse{
 		Valid: valid,
 	}, nil
 }
 
 func (s *Server) GetUserProfile(ctx context.Context, req *proto.GetUserProfileRequest) (*proto.UserProfile, error) {
-	userID, err := uuid.Parse(req.GetUserId())
+	userID, err := grpcsrv.ParseUUID("user_id", req.GetUserId())
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+		return nil, err
 	}
 
 	profileInfo, err := s.sp.GetProfileInfo(userID)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, status.Error(codes.NotFound, "user not found")
-		}
-
-		log.Error().Err(err).Msgf("get profile info")
-
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, errorMapper.Error(fmt.Errorf("get profile info: %w", err))
 	}
 
 	return s.convertProfileInfoToAPI(profileInfo), nil
@@ -126,35 +189,26 @@ func (s *Server) GetUserProfile(ctx context.Context, req *proto.GetUserProfileRe
 func (s *Server) GetUser(_ context.Context, req *proto.GetUserRequest) (*proto.UserInfo, error) {
 	user, err := s.sp.GetByAddress(req.GetAddress())
 	if err != nil {
-		log.Error().Err(err).Msgf("get user")
-
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, errorMapper.Error(fmt.Errorf("get user: %w", err))
 	}
 
 	return convertUserToAPI(user), nil
 }
 
 func (s *Server) GetSession(_ context.Context, req *proto.GetSessionRequest) (*proto.GetSessionResponse, error) {
-	sessionID, err := uuid.Parse(req.GetSessionId())
+	sessionID, err := grpcsrv.ParseUUID("session_id", req.GetSessionId())
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid session ID")
+		return nil, err
 	}
 
 	session, err := s.sp.GetSessionByID(sessionID)
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, status.Error(codes.InvalidArgument, "invalid session ID")
-	}
 	if err != nil {
-		log.Error().Err(err).Msgf("get session by id: %s", req.GetSessionId())
-
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, errorMapper.Error(fmt.Errorf("get session by id: %s: %w", req.GetSessionId(), err))
 	}
 
 	user, err := s.sp.GetByID(session.UserID)
 	if err != nil {
-		log.Error().Err(err).Msgf("get user by id: %s", session.UserID)
-
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, errorMapper.Error(fmt.Errorf("get user by id: %s: %w", session.UserID, err))
 	}
 
 	return &proto.GetSessionResponse{
@@ -163,62 +217,73 @@ func (s *Server) GetSession(_ context.Context, req *proto.GetSessionRequest) (*p
 	}, nil
 }
 
-func (s *Server) DeleteSession(_ context.Context, req *proto.DeleteSessionRequest) (*emptypb.Empty, error) {
-	sessionID, err := uuid.Parse(req.GetSessionId())
+func (s *Server) DeleteSession(ctx context.Context, req *proto.DeleteSessionRequest) (*emptypb.Empty, error) {
+	sessionID, err := grpcsrv.ParseUUID("session_id", req.GetSessionId())
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid session ID")
+		return nil, err
+	}
+
+	before, err := s.sp.GetSessionByID(sessionID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Error().Err(err).Msgf("get session for audit: %s", req.GetSessionId())
 	}
 
 	err = s.sp.DeleteSession(sessionID)
 	if err != nil {
-		log.Error().Err(err).Msgf("delete session by id: %s", req.GetSessionId())
+		return nil, errorMapper.Error(fmt.Errorf("delete session by id: %s: %w", req.GetSessionId(), err))
+	}
 
-		return nil, status.Error(codes.Internal, "internal error")
+	if before != nil {
+		s.auditor.Record(ctx, before.UserID, audit.OperationDeleteSession, newSessionAudit(before), nil)
 	}
 
 	return &emptypb.Empty{}, nil
 }
 
 func (s *Server) DeleteUser(ctx context.Context, req *proto.DeleteUserRequest) (*emptypb.Empty, error) {
-	userID, err := uuid.Parse(req.GetUserId())
+	userID, err := grpcsrv.ParseUUID("user_id", req.GetUserId())
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+		return nil, err
+	}
+
+	before, err := s.sp.GetByID(userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Error().Err(err).Msgf("get user for audit: %s", req.GetUserId())
 	}
 
 	err = s.sp.DeleteUser(userID)
 	if err != nil {
-		log.Error().Err(err).Msgf("delete user by id: %s", req.GetUserId())
-
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, errorMapper.Error(fmt.Errorf("delete user by id: %s: %w", req.GetUserId(), err))
 	}
 
+	s.auditor.Record(ctx, userID, audit.OperationDeleteUser, before, nil)
+
 	return &emptypb.Empty{}, nil
 }
 
 func (s *Server) AddView(_ context.Context, req *proto.UserViewRequest) (*emptypb.Empty, error) {
-	if req.GetUserId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	userID, err := grpcsrv.ParseUUID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
 	}
 
-	err := s.sp.AddView(uuid.MustParse(req.GetUserId()), convertRecentlyType(req.GetType()), req.GetTypeId())
+	err = s.sp.AddView(userID, convertRecentlyType(req.GetType()), req.GetTypeId())
 	if err != nil {
-		log.Error().Err(err).Msgf("add user view: %s", req.GetUserId())
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, errorMapper.Error(fmt.Errorf("add user view: %s: %w", req.GetUserId(), err))
 	}
 
 	return &emptypb.Empty{}, nil
 }
 
 func (s *Server) LastViewed(_ context.Context, req *proto.UserLastViewedRequest) (*proto.UserLastViewedResponse, error) {
-	if req.GetUserId() == "" {
-		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	userID, err := grpcsrv.ParseUUID("user_id", req.GetUserId())
+	if err != nil {
+		return nil, err
 	}
 
-	list, err := s.sp.LastViewed(uuid.MustParse(req.GetUserId()), int64(req.GetLimit()))
+	list, err := s.sp.LastViewed(userID, int64(req.GetLimit()))
 	if err != nil {
-		log.Error().Err(err).Msgf("get last viewed by user: %s", req.GetUserId())
-
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, errorMapper.Error(fmt.Errorf("get last viewed by user: %s: %w", req.GetUserId(), err))
 	}
 
 	return &proto.UserLastViewedResponse{
@@ -227,36 +292,32 @@ func (s *Server) LastViewed(_ context.Context, req *proto.UserLastViewedRequest)
 }
 
 func (s *Server) TrackActivity(_ context.Context, req *proto.TrackActivityRequest) (*emptypb.Empty, error) {
-	userID, err := uuid.Parse(req.GetUserId())
+	userID, err := grpcsrv.ParseUUID("user_id", req.GetUserId())
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "user id has wrong value")
+		return nil, err
 	}
 
-	sessionID, err := uuid.Parse(req.GetSessionId())
+	sessionID, err := grpcsrv.ParseUUID("session_id", req.GetSessionId())
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "session id has wrong value")
+		return nil, err
 	}
 
 	if err := s.sp.TrackActivity(userID, sessionID); err != nil {
-		log.Err(err).Msg("save track activity")
-
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, errorMapper.Error(fmt.Errorf("save track activity: %w", err))
 	}
 
 	return &emptypb.Empty{}, nil
 }
 
 func (s *Server) GetUserCanVoteProposals(ctx context.Context, req *proto.GetUserCanVoteProposalsRequest) (*proto.GetUserCanVoteProposalsResponse, error) {
-	userID, err := uuid.Parse(req.GetUserId())
+	userID, err := grpcsrv.ParseUUID("user_id", req.GetUserId())
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+		return nil, err
 	}
 
 	list, err := s.sp.GetUserCanVoteProposals(userID)
 	if err != nil {
-		log.Error().Err(err).Msgf("get user can vote proposals: %s", req.GetUserId())
-
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, errorMapper.Error(fmt.Errorf("get user can vote proposals: %s: %w", req.GetUserId(), err))
 	}
 
 	return &proto.GetUserCanVoteProposalsResponse{
@@ -277,34 +338,28 @@ func (s *Server) convertProfileInfoToAPI(profileInfo ProfileInfo) *proto.UserPro
 }
 
 func (s *Server) AllowSendingPush(_ context.Context, req *proto.AllowSendingPushRequest) (*proto.AllowSendingPushResponse, error) {
-	userID, err := uuid.Parse(req.GetUserId())
+	userID, err := grpcsrv.ParseUUID("user_id", req.GetUserId())
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "user id has wrong format")
+		return nil, err
 	}
 
 	allow, err := s.sp.AllowSendingPush(userID)
 	if err != nil {
-		log.Error().Err(err).Msg("allowSendingPush calculating")
-
-		return nil, status.Error(codes.Internal, "internal err")
+		return nil, errorMapper.Error(fmt.Errorf("allow sending push: %w", err))
 	}
 
 	return &proto.AllowSendingPushResponse{Allow: allow}, nil
 }
 
 func (s *Server) GetAvailableDaoByWallet(_ context.Context, req *proto.GetAvailableDaoByWalletRequest) (*proto.GetAvailableDaoByWalletResponse, error) {
-	userID, err := uuid.Parse(req.GetUserId())
+	userID, err := grpcsrv.ParseUUID("user_id", req.GetUserId())
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "user id has wrong format")
+		return nil, err
 	}
 
 	ids, err := s.sp.GetAvailableDaoByUser(userID)
 	if err != nil {
-		if errors.Is(err, ErrUserHasNoAddress) {
-			return nil, status.Error(codes.FailedPrecondition, err.Error())
-		}
-
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, errorMapper.Error(err)
 	}
 
 	return &proto.GetAvailableDaoByWalletResponse{
@@ -0,0 +1,676 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	proto "github.com/goverland-labs/goverland-inbox-api-protocol/protobuf/inboxapi"
+	"github.com/stretchr/testify/require"
+	"go.openly.dev/pointy"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-inbox-storage/internal/audit"
+	"github.com/goverland-labs/goverland-inbox-storage/pkg/grpcsrv"
+)
+
+var errBackend = errors.New("backend is down")
+
+var (
+	testCreatedAt = time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	testUser      = User{ID: uuid.New(), CreatedAt: testCreatedAt, Role: RegularRole, Address: pointy.String("0x1"), ENS: pointy.String("one.eth")}
+	testSession   = Session{ID: uuid.New(), UserID: testUser.ID, CreatedAt: testCreatedAt, DeviceUUID: "device", DeviceName: "phone"}
+)
+
+type fakeUsers struct {
+	created  *CreateSessionRequest
+	deleted  []uuid.UUID
+	viewed   string
+	activity uuid.UUID
+	err      error
+}
+
+func (f *fakeUsers) CreateSession(req CreateSessionRequest) (*Session, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	f.created = &req
+
+	return &testSession, nil
+}
+
+func (f *fakeUsers) GetSessionByID(id uuid.UUID) (*Session, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	if id != testSession.ID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return &testSession, nil
+}
+
+func (f *fakeUsers) DeleteSession(id uuid.UUID) error {
+	if _, err := f.GetSessionByID(id); err != nil {
+		return err
+	}
+
+	f.deleted = append(f.deleted, id)
+
+	return nil
+}
+
+func (f *fakeUsers) UseAuthNonce(_ string, nonce string, _ time.Time) (bool, error) {
+	return nonce == "valid", f.err
+}
+
+func (f *fakeUsers) GetByID(id uuid.UUID) (*User, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	if id != testUser.ID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return &testUser, nil
+}
+
+func (f *fakeUsers) GetByAddress(address string) (*User, error) {
+	if address != *testUser.Address {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return f.GetByID(testUser.ID)
+}
+
+func (f *fakeUsers) GetProfileInfo(userID uuid.UUID) (ProfileInfo, error) {
+	u, err := f.GetByID(userID)
+	if err != nil {
+		return ProfileInfo{}, err
+	}
+
+	return ProfileInfo{User: u, LastSessions: []Session{testSession}}, nil
+}
+
+func (f *fakeUsers) DeleteUser(id uuid.UUID) error {
+	if _, err := f.GetByID(id); err != nil {
+		return err
+	}
+
+	f.deleted = append(f.deleted, id)
+
+	return nil
+}
+
+func (f *fakeUsers) AddView(_ uuid.UUID, _ RecentlyType, id string) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	f.viewed = id
+
+	return nil
+}
+
+func (f *fakeUsers) LastViewed(uuid.UUID, int64) ([]RecentlyViewed, error) {
+	return []RecentlyViewed{{Model: gorm.Model{CreatedAt: testCreatedAt}, Type: RecentlyTypeDao, TypeID: "dao"}}, f.err
+}
+
+func (f *fakeUsers) TrackActivity(_ uuid.UUID, sessionID uuid.UUID) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	f.activity = sessionID
+
+	return nil
+}
+
+func (f *fakeUsers) GetUserCanVoteProposals(uuid.UUID) ([]string, error) {
+	return []string{"proposal"}, f.err
+}
+
+func (f *fakeUsers) AllowSendingPush(uuid.UUID) (bool, error) {
+	return true, f.err
+}
+
+func (f *fakeUsers) GetAvailableDaoByUser(userID uuid.UUID) ([]string, error) {
+	if _, err := f.GetByID(userID); err != nil {
+		return nil, err
+	}
+
+	return []string{"dao"}, nil
+}
+
+type fakeAuditor struct {
+	ops []audit.Operation
+}
+
+func (f *fakeAuditor) Record(_ context.Context, _ uuid.UUID, op audit.Operation, _, _ any) {
+	f.ops = append(f.ops, op)
+}
+
+func TestUnitServerCreateSession(t *testing.T) {
+	for name, tc := range map[string]struct {
+		req      *proto.CreateSessionRequest
+		err      error
+		expected *CreateSessionRequest
+		code     codes.Code
+		field    string
+	}{
+		"guest": {
+			req: &proto.CreateSessionRequest{
+				DeviceUuid: "device",
+				Account:    &proto.CreateSessionRequest_Guest{Guest: &proto.Guest{}},
+			},
+			expected: &CreateSessionRequest{DeviceUUID: "device", Locale: "de", Role: GuestRole},
+		},
+		"regular": {
+			req: &proto.CreateSessionRequest{
+				DeviceUuid: "device",
+				Account:    &proto.CreateSessionRequest_Regular{Regular: &proto.Regular{Address: "0x1"}},
+			},
+			expected: &CreateSessionRequest{Address: pointy.String("0x1"), DeviceUUID: "device", Locale: "de", Role: RegularRole},
+		},
+		"missing account": {
+			req:   &proto.CreateSessionRequest{DeviceUuid: "device"},
+			code:  codes.InvalidArgument,
+			field: "account",
+		},
+		"backend error": {
+			req: &proto.CreateSessionRequest{
+				Account: &proto.CreateSessionRequest_Guest{Guest: &proto.Guest{}},
+			},
+			err:  errBackend,
+			code: codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sp := &fakeUsers{err: tc.err}
+			auditor := &fakeAuditor{}
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-locale", "de"))
+
+			res, err := NewServer(sp, auditor).CreateSession(ctx, tc.req)
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			require.Equal(t, tc.expected, sp.created)
+			if tc.code != codes.OK {
+				require.Empty(t, auditor.ops)
+
+				return
+			}
+
+			require.Equal(t, []audit.Operation{audit.OperationCreateSession}, auditor.ops)
+			require.Equal(t, testSession.ID.String(), res.GetCreatedSession().GetId())
+			require.Equal(t, testUser.ID.String(), res.GetCreatedSession().GetUserId())
+			require.Equal(t, "device", res.GetCreatedSession().GetDeviceUuid())
+			require.Equal(t, testUser.ID.String(), res.GetUserProfile().GetUser().GetId())
+			require.Len(t, res.GetUserProfile().GetLastSessions(), 1)
+		})
+	}
+}
+
+func TestUnitServerUseAuthNonce(t *testing.T) {
+	for name, tc := range map[string]struct {
+		address  string
+		nonce    string
+		err      error
+		expected bool
+		ops      []audit.Operation
+		code     codes.Code
+		field    string
+	}{
+		"valid nonce": {
+			address:  "0x1",
+			nonce:    "valid",
+			expected: true,
+			ops:      []audit.Operation{audit.OperationUseAuthNonce},
+		},
+		"used nonce of unknown address": {
+			address: "0x2",
+			nonce:   "used",
+			ops:     []audit.Operation{audit.OperationUseAuthNonce},
+		},
+		"empty nonce": {
+			address: "0x1",
+			code:    codes.InvalidArgument,
+			field:   "nonce",
+		},
+		"empty address": {
+			nonce: "valid",
+			code:  codes.InvalidArgument,
+			field: "address",
+		},
+		"backend error": {
+			address: "0x1",
+			nonce:   "valid",
+			err:     errBackend,
+			code:    codes.Unauthenticated,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			auditor := &fakeAuditor{}
+
+			res, err := NewServer(&fakeUsers{err: tc.err}, auditor).UseAuthNonce(context.Background(), &proto.UseAuthNonceRequest{
+				Address: tc.address,
+				Nonce:   tc.nonce,
+			})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			require.Equal(t, tc.expected, res.GetValid())
+			require.Equal(t, tc.ops, auditor.ops)
+		})
+	}
+}
+
+func TestUnitServerGetSession(t *testing.T) {
+	for name, tc := range map[string]struct {
+		sessionID string
+		err       error
+		code      codes.Code
+		reason    string
+		field     string
+	}{
+		"session": {
+			sessionID: testSession.ID.String(),
+		},
+		"invalid session id": {
+			sessionID: "wrong",
+			code:      codes.InvalidArgument,
+			field:     "session_id",
+		},
+		"unknown session": {
+			sessionID: uuid.NewString(),
+			code:      codes.NotFound,
+			reason:    "NOT_FOUND",
+		},
+		"backend error": {
+			sessionID: testSession.ID.String(),
+			err:       errBackend,
+			code:      codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			res, err := NewServer(&fakeUsers{err: tc.err}, &fakeAuditor{}).GetSession(context.Background(), &proto.GetSessionRequest{
+				SessionId: tc.sessionID,
+			})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.reason, grpcsrv.ErrorReason(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.code != codes.OK {
+				return
+			}
+
+			require.Equal(t, testSession.ID.String(), res.GetSession().GetId())
+			require.Equal(t, testCreatedAt, res.GetSession().GetCreatedAt().AsTime())
+			require.Equal(t, "phone", res.GetSession().GetDeviceName())
+			require.Nil(t, res.GetSession().GetLastActivityAt())
+			require.Equal(t, testUser.ID.String(), res.GetUser().GetId())
+			require.Equal(t, "0x1", res.GetUser().GetAddress())
+			require.Equal(t, "one.eth", res.GetUser().GetEns())
+			require.Equal(t, proto.UserRole_USER_ROLE_REGULAR, res.GetUser().GetRole())
+		})
+	}
+}
+
+func TestUnitServerDeleteSession(t *testing.T) {
+	for name, tc := range map[string]struct {
+		sessionID string
+		err       error
+		code      codes.Code
+		reason    string
+		field     string
+	}{
+		"deleted": {
+			sessionID: testSession.ID.String(),
+		},
+		"invalid session id": {
+			sessionID: "wrong",
+			code:      codes.InvalidArgument,
+			field:     "session_id",
+		},
+		"unknown session": {
+			sessionID: uuid.NewString(),
+			code:      codes.NotFound,
+			reason:    "NOT_FOUND",
+		},
+		"backend error": {
+			sessionID: testSession.ID.String(),
+			err:       errBackend,
+			code:      codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sp := &fakeUsers{err: tc.err}
+			auditor := &fakeAuditor{}
+
+			_, err := NewServer(sp, auditor).DeleteSession(context.Background(), &proto.DeleteSessionRequest{
+				SessionId: tc.sessionID,
+			})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.reason, grpcsrv.ErrorReason(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.code == codes.OK {
+				require.Equal(t, []uuid.UUID{testSession.ID}, sp.deleted)
+				require.Equal(t, []audit.Operation{audit.OperationDeleteSession}, auditor.ops)
+			} else {
+				require.Empty(t, sp.deleted)
+				require.Empty(t, auditor.ops)
+			}
+		})
+	}
+}
+
+func TestUnitServerGetUser(t *testing.T) {
+	for name, tc := range map[string]struct {
+		address string
+		err     error
+		code    codes.Code
+		reason  string
+	}{
+		"user": {
+			address: "0x1",
+		},
+		"unknown address": {
+			address: "0x2",
+			code:    codes.NotFound,
+			reason:  "NOT_FOUND",
+		},
+		"backend error": {
+			address: "0x1",
+			err:     errBackend,
+			code:    codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			res, err := NewServer(&fakeUsers{err: tc.err}, &fakeAuditor{}).GetUser(context.Background(), &proto.GetUserRequest{
+				Address: tc.address,
+			})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.reason, grpcsrv.ErrorReason(err))
+			if tc.code == codes.OK {
+				require.Equal(t, testUser.ID.String(), res.GetId())
+				require.Equal(t, testCreatedAt, res.GetCreatedAt().AsTime())
+				require.Equal(t, "0x1", res.GetAddress())
+			}
+		})
+	}
+}
+
+// userCases are common cases of methods which require a valid user id
+var userCases = map[string]struct {
+	userID string
+	err    error
+	code   codes.Code
+	field  string
+}{
+	"known user": {
+		userID: testUser.ID.String(),
+	},
+	"invalid user id": {
+		userID: "wrong",
+		code:   codes.InvalidArgument,
+		field:  "user_id",
+	},
+	"backend error": {
+		userID: testUser.ID.String(),
+		err:    errBackend,
+		code:   codes.Internal,
+	},
+}
+
+func TestUnitServerGetUserProfile(t *testing.T) {
+	for name, tc := range map[string]struct {
+		userID string
+		err    error
+		code   codes.Code
+		reason string
+		field  string
+	}{
+		"profile": {
+			userID: testUser.ID.String(),
+		},
+		"invalid user id": {
+			userID: "wrong",
+			code:   codes.InvalidArgument,
+			field:  "user_id",
+		},
+		"unknown user": {
+			userID: uuid.NewString(),
+			code:   codes.NotFound,
+			reason: "NOT_FOUND",
+		},
+		"backend error": {
+			userID: testUser.ID.String(),
+			err:    errBackend,
+			code:   codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			res, err := NewServer(&fakeUsers{err: tc.err}, &fakeAuditor{}).GetUserProfile(context.Background(), &proto.GetUserProfileRequest{
+				UserId: tc.userID,
+			})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.reason, grpcsrv.ErrorReason(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.code == codes.OK {
+				require.Equal(t, testUser.ID.String(), res.GetUser().GetId())
+				require.Len(t, res.GetLastSessions(), 1)
+				require.Equal(t, testSession.ID.String(), res.GetLastSessions()[0].GetId())
+			}
+		})
+	}
+}
+
+func TestUnitServerDeleteUser(t *testing.T) {
+	for name, tc := range map[string]struct {
+		userID string
+		err    error
+		code   codes.Code
+		reason string
+		field  string
+	}{
+		"deleted": {
+			userID: testUser.ID.String(),
+		},
+		"invalid user id": {
+			userID: "wrong",
+			code:   codes.InvalidArgument,
+			field:  "user_id",
+		},
+		"unknown user": {
+			userID: uuid.NewString(),
+			code:   codes.NotFound,
+			reason: "NOT_FOUND",
+		},
+		"backend error": {
+			userID: testUser.ID.String(),
+			err:    errBackend,
+			code:   codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sp := &fakeUsers{err: tc.err}
+			auditor := &fakeAuditor{}
+
+			_, err := NewServer(sp, auditor).DeleteUser(context.Background(), &proto.DeleteUserRequest{UserId: tc.userID})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.reason, grpcsrv.ErrorReason(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.code == codes.OK {
+				require.Equal(t, []uuid.UUID{testUser.ID}, sp.deleted)
+				require.Equal(t, []audit.Operation{audit.OperationDeleteUser}, auditor.ops)
+			} else {
+				require.Empty(t, sp.deleted)
+			}
+		})
+	}
+}
+
+func TestUnitServerViews(t *testing.T) {
+	for name, tc := range userCases {
+		t.Run(name, func(t *testing.T) {
+			sp := &fakeUsers{err: tc.err}
+			server := NewServer(sp, &fakeAuditor{})
+
+			_, err := server.AddView(context.Background(), &proto.UserViewRequest{
+				UserId: tc.userID,
+				Type:   proto.RecentlyViewedType_RECENTLY_VIEWED_TYPE_DAO,
+				TypeId: "dao",
+			})
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+
+			res, err := server.LastViewed(context.Background(), &proto.UserLastViewedRequest{UserId: tc.userID})
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.code != codes.OK {
+				require.Empty(t, sp.viewed)
+
+				return
+			}
+
+			require.Equal(t, "dao", sp.viewed)
+			require.Len(t, res.GetList(), 1)
+			require.Equal(t, proto.RecentlyViewedType_RECENTLY_VIEWED_TYPE_DAO, res.GetList()[0].GetType())
+			require.Equal(t, "dao", res.GetList()[0].GetTypeId())
+			require.Equal(t, testCreatedAt, res.GetList()[0].GetCreatedAt().AsTime())
+		})
+	}
+}
+
+func TestUnitServerTrackActivity(t *testing.T) {
+	for name, tc := range map[string]struct {
+		userID    string
+		sessionID string
+		err       error
+		code      codes.Code
+		field     string
+	}{
+		"tracked": {
+			userID:    testUser.ID.String(),
+			sessionID: testSession.ID.String(),
+		},
+		"invalid user id": {
+			userID:    "wrong",
+			sessionID: testSession.ID.String(),
+			code:      codes.InvalidArgument,
+			field:     "user_id",
+		},
+		"invalid session id": {
+			userID:    testUser.ID.String(),
+			sessionID: "wrong",
+			code:      codes.InvalidArgument,
+			field:     "session_id",
+		},
+		"backend error": {
+			userID:    testUser.ID.String(),
+			sessionID: testSession.ID.String(),
+			err:       errBackend,
+			code:      codes.Internal,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sp := &fakeUsers{err: tc.err}
+
+			_, err := NewServer(sp, &fakeAuditor{}).TrackActivity(context.Background(), &proto.TrackActivityRequest{
+				UserId:    tc.userID,
+				SessionId: tc.sessionID,
+			})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.code == codes.OK {
+				require.Equal(t, testSession.ID, sp.activity)
+			}
+		})
+	}
+}
+
+func TestUnitServerGetUserCanVoteProposals(t *testing.T) {
+	for name, tc := range userCases {
+		t.Run(name, func(t *testing.T) {
+			res, err := NewServer(&fakeUsers{err: tc.err}, &fakeAuditor{}).GetUserCanVoteProposals(context.Background(), &proto.GetUserCanVoteProposalsRequest{
+				UserId: tc.userID,
+			})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.code == codes.OK {
+				require.Equal(t, []string{"proposal"}, res.GetProposalIds())
+			}
+		})
+	}
+}
+
+func TestUnitServerAllowSendingPush(t *testing.T) {
+	for name, tc := range userCases {
+		t.Run(name, func(t *testing.T) {
+			res, err := NewServer(&fakeUsers{err: tc.err}, &fakeAuditor{}).AllowSendingPush(context.Background(), &proto.AllowSendingPushRequest{
+				UserId: tc.userID,
+			})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			require.Equal(t, tc.code == codes.OK, res.GetAllow())
+		})
+	}
+}
+
+func TestUnitServerGetAvailableDaoByWallet(t *testing.T) {
+	for name, tc := range map[string]struct {
+		userID string
+		err    error
+		code   codes.Code
+		reason string
+		field  string
+	}{
+		"daos": {
+			userID: testUser.ID.String(),
+		},
+		"invalid user id": {
+			userID: "wrong",
+			code:   codes.InvalidArgument,
+			field:  "user_id",
+		},
+		"unknown user": {
+			userID: uuid.NewString(),
+			code:   codes.NotFound,
+			reason: "NOT_FOUND",
+		},
+		"user without address": {
+			userID: testUser.ID.String(),
+			err:    ErrUserHasNoAddress,
+			code:   codes.FailedPrecondition,
+			reason: "USER_HAS_NO_ADDRESS",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			res, err := NewServer(&fakeUsers{err: tc.err}, &fakeAuditor{}).GetAvailableDaoByWallet(context.Background(), &proto.GetAvailableDaoByWalletRequest{
+				UserId: tc.userID,
+			})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.reason, grpcsrv.ErrorReason(err))
+			require.Equal(t, tc.field, grpcsrv.ViolatedField(err))
+			if tc.code == codes.OK {
+				require.Equal(t, []string{"dao"}, res.GetDaoUuids())
+			}
+		})
+	}
+}
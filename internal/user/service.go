@@ -19,6 +19,7 @@ import (
 
 var (
 	ErrUserHasNoAddress = errors.New("user has no address")
+	ErrAmbiguousENS     = errors.New("ens name belongs to several users")
 )
 
 const (
@@ -86,6 +87,10 @@ func (s *Service) GetByAddress(address string) (*User, error) {
 	return s.repo.GetByAddress(address)
 }
 
+func (s *Service) GetRegularByAddressOrENS(value string) (*User, error) {
+	return s.repo.GetRegularByAddressOrENS(value)
+}
+
 func (s *Service) GetProfileInfo(userID uuid.UUID) (ProfileInfo, error) {
 	const countLastSessions = 10
 
@@ -129,6 +134,15 @@ func (s *Service) GetSessionByID(id uuid.UUID) (*Session, error) {
 	return s.sessionRepo.GetByID(id)
 }
 
+func (s *Service) GetLastSessionByDevice(userID uuid.UUID, deviceUUID string) (*Session, error) {
+	return s.sessionRepo.GetLastByDevice(userID, deviceUUID)
+}
+
+// GetLastLocale returns the locale of the latest active session with the known locale
+func (s *Service) GetLastLocale(userID uuid.UUID) (string, error) {
+	return s.sessionRepo.GetLastLocale(userID)
+}
+
 func (s *Service) DeleteSession(id uuid.UUID) error {
 	err := s.sessionRepo.Delete(id)
 	if err != nil {
@@ -204,6 +218,7 @@ func (s *Service) CreateSession(request CreateSessionRequest) (*Session, error)
 		DeviceName:     request.DeviceName,
 		AppVersion:     request.AppVersion,
 		AppPlatform:    request.AppPlatform,
+		Locale:         request.Locale,
 		LastActivityAt: time.Now(),
 	}
 
@@ -34,6 +34,20 @@ func (r *SessionRepo) GetLastSessions(id uuid.UUID, count int) ([]Session, error
 	return sessions, nil
 }
 
+func (r *SessionRepo) GetLastByDevice(userID uuid.UUID, deviceUUID string) (*Session, error) {
+	var session Session
+	err := r.db.
+		Where("user_id = ? and device_uuid = ?", userID, deviceUUID).
+		Order("created_at desc").
+		Take(&session).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
 func (r *SessionRepo) GetByID(id uuid.UUID) (*Session, error) {
 	session := Session{ID: id}
 	request := r.db.Take(&session)
@@ -52,6 +66,20 @@ func (r *SessionRepo) DeleteAllByUserID(userID uuid.UUID) error {
 	return r.db.Where("user_id = ?", userID).Delete(&Session{}).Error
 }
 
+func (r *SessionRepo) GetLastLocale(userID uuid.UUID) (string, error) {
+	var locale string
+	err := r.db.
+		Model(&Session{}).
+		Select("locale").
+		Where("user_id = ? and locale <> ''", userID).
+		Order("last_activity_at desc").
+		Limit(1).
+		Scan(&locale).
+		Error
+
+	return locale, err
+}
+
 func (r *SessionRepo) UpdateLastActivityAt(id uuid.UUID, lastActivityAt time.Time) error {
 	return r.db.
 		Model(&Session{}).
@@ -85,6 +85,14 @@ func NewService(api *zerion.Client, rm RecommendationMapper) (*Service, error) {
 	return service, nil
 }
 
+// Ready returns true if mapping config is loaded, otherwise positions are always empty
+func (s *Service) Ready() bool {
+	s.mappingMu.RLock()
+	defer s.mappingMu.RUnlock()
+
+	return len(s.mapping) > 0
+}
+
 // GetWalletPositions returns list of internal dao id based on mapping config
 func (s *Service) GetWalletPositions(address string) ([]uuid.UUID, error) {
 	s.mappingMu.RLock()
@@ -2,8 +2,19 @@ package grpcsrv
 
 import (
 	"context"
+
+	"google.golang.org/grpc/metadata"
 )
 
+// ActorMetadataKey is set by callers to identify who performs the request. Callers are not authenticated yet,
+// so the value is only claimed by the caller.
+const ActorMetadataKey = "x-actor"
+
+// ClaimedActorPrefix marks stored actors which are provided by the caller without authentication
+const ClaimedActorPrefix = "claimed:"
+
+type actorKey struct{}
+
 type Auth struct {
 }
 
@@ -11,7 +22,24 @@ func NewAuthInterceptor() *Auth {
 	return &Auth{}
 }
 
-// todo: implement me
+// todo: implement authentication, for now it only reads the actor claimed by the caller
 func (a *Auth) AuthAndIdentifyTickerFunc(ctx context.Context) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx, nil
+	}
+
+	if values := md.Get(ActorMetadataKey); len(values) > 0 && values[0] != "" {
+		ctx = context.WithValue(ctx, actorKey{}, values[0])
+	}
+
 	return ctx, nil
 }
+
+// ClaimedActorFromContext returns the actor provided by the caller. It's not verified, so it must not be
+// used for authorization and must be stored as claimed one.
+func ClaimedActorFromContext(ctx context.Context) (string, bool) {
+	actor, ok := ctx.Value(actorKey{}).(string)
+
+	return actor, ok
+}
@@ -0,0 +1,128 @@
+package grpcsrv
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"gorm.io/gorm"
+)
+
+const errorDomain = "inbox-storage"
+
+// ErrorRule maps the domain error to the gRPC code, Reason is provided to clients in ErrorInfo details
+type ErrorRule struct {
+	Err    error
+	Code   codes.Code
+	Reason string
+	// Details returns extra error details for clients, optional
+	Details func(err error) []protoadapt.MessageV1
+}
+
+var defaultErrorRules = []ErrorRule{
+	{Err: gorm.ErrRecordNotFound, Code: codes.NotFound, Reason: "NOT_FOUND"},
+	{Err: context.Canceled, Code: codes.Canceled, Reason: "CANCELED"},
+	{Err: context.DeadlineExceeded, Code: codes.DeadlineExceeded, Reason: "DEADLINE_EXCEEDED"},
+}
+
+// ErrorMapper converts errors returned by services to gRPC statuses
+type ErrorMapper struct {
+	rules []ErrorRule
+}
+
+// NewErrorMapper creates mapper with provided rules checked in order before the default ones
+func NewErrorMapper(rules ...ErrorRule) *ErrorMapper {
+	return &ErrorMapper{
+		rules: append(rules, defaultErrorRules...),
+	}
+}
+
+// Error returns the status error by the first matched rule. Statuses are returned as is, other errors
+// are logged and returned as Internal without details to not expose them.
+func (m *ErrorMapper) Error(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	for _, rule := range m.rules {
+		if !errors.Is(err, rule.Err) {
+			continue
+		}
+
+		details := []protoadapt.MessageV1{&errdetails.ErrorInfo{
+			Reason: rule.Reason,
+			Domain: errorDomain,
+		}}
+		if rule.Details != nil {
+			details = append(details, rule.Details(err)...)
+		}
+
+		return withDetails(status.New(rule.Code, err.Error()), details...)
+	}
+
+	log.Error().Err(err).Msg("internal error")
+
+	return status.Error(codes.Internal, "internal error")
+}
+
+// InvalidArgument returns the status error with the field violation details
+func InvalidArgument(field, description string) error {
+	return withDetails(status.New(codes.InvalidArgument, "invalid "+field), &errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{
+				Field:       field,
+				Description: description,
+			},
+		},
+	})
+}
+
+// ParseUUID parses the request field or returns InvalidArgument status error
+func ParseUUID(field, value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, InvalidArgument(field, "must be a valid uuid")
+	}
+
+	return id, nil
+}
+
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) error {
+	detailed, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+// ErrorReason returns the reason from the ErrorInfo details of the status error, empty if there are no details
+func ErrorReason(err error) string {
+	for _, details := range status.Convert(err).Details() {
+		if info, ok := details.(*errdetails.ErrorInfo); ok {
+			return info.GetReason()
+		}
+	}
+
+	return ""
+}
+
+// ViolatedField returns the first field from the BadRequest details of the status error, empty if there are no details
+func ViolatedField(err error) string {
+	for _, details := range status.Convert(err).Details() {
+		if br, ok := details.(*errdetails.BadRequest); ok && len(br.GetFieldViolations()) > 0 {
+			return br.GetFieldViolations()[0].GetField()
+		}
+	}
+
+	return ""
+}
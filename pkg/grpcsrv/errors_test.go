@@ -0,0 +1,79 @@
+package grpcsrv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"gorm.io/gorm"
+)
+
+func TestUnitErrorMapper(t *testing.T) {
+	errDomain := errors.New("domain error")
+	mapper := NewErrorMapper(ErrorRule{Err: errDomain, Code: codes.FailedPrecondition, Reason: "DOMAIN"})
+
+	for _, tc := range []struct {
+		name   string
+		err    error
+		code   codes.Code
+		reason string
+	}{
+		{name: "nil", err: nil, code: codes.OK},
+		{name: "domain rule", err: fmt.Errorf("wrapped: %w", errDomain), code: codes.FailedPrecondition, reason: "DOMAIN"},
+		{name: "not found", err: fmt.Errorf("get: %w", gorm.ErrRecordNotFound), code: codes.NotFound, reason: "NOT_FOUND"},
+		{name: "deadline", err: context.DeadlineExceeded, code: codes.DeadlineExceeded, reason: "DEADLINE_EXCEEDED"},
+		{name: "status as is", err: status.Error(codes.Unauthenticated, "nope"), code: codes.Unauthenticated},
+		{name: "unknown", err: errors.New("connection refused"), code: codes.Internal},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			st := status.Convert(mapper.Error(tc.err))
+			require.Equal(t, tc.code, st.Code())
+
+			if tc.code == codes.Internal {
+				require.Equal(t, "internal error", st.Message())
+			}
+
+			require.Equal(t, tc.reason, ErrorReason(mapper.Error(tc.err)))
+		})
+	}
+}
+
+func TestUnitParseUUID(t *testing.T) {
+	_, err := ParseUUID("user_id", "wrong")
+
+	st := status.Convert(err)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 1)
+
+	require.Equal(t, "user_id", ViolatedField(err))
+	require.Empty(t, ErrorReason(err))
+
+	_, err = ParseUUID("user_id", "2f0a5a5c-7cf3-4b8c-9b52-6f1e6e0b6c1e")
+	require.NoError(t, err)
+}
+
+func TestUnitErrorMapperDetails(t *testing.T) {
+	errLimit := errors.New("limit exceeded")
+	mapper := NewErrorMapper(ErrorRule{
+		Err:    errLimit,
+		Code:   codes.ResourceExhausted,
+		Reason: "LIMIT",
+		Details: func(error) []protoadapt.MessageV1 {
+			return []protoadapt.MessageV1{&errdetails.QuotaFailure{
+				Violations: []*errdetails.QuotaFailure_Violation{{Description: "monthly limit"}},
+			}}
+		},
+	})
+
+	st := status.Convert(mapper.Error(fmt.Errorf("summary: %w", errLimit)))
+	require.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Len(t, st.Details(), 2)
+	require.Equal(t, "LIMIT", st.Details()[0].(*errdetails.ErrorInfo).GetReason())
+	require.Equal(t, "monthly limit", st.Details()[1].(*errdetails.QuotaFailure).GetViolations()[0].GetDescription())
+}
@@ -0,0 +1,43 @@
+package grpcsrv
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// LocaleMetadataKey is set by callers to request localised content
+	LocaleMetadataKey = "x-locale"
+
+	acceptLanguageMetadataKey = "accept-language"
+)
+
+// LocaleFromContext returns the locale requested by the caller: the explicit locale metadata
+// or the most preferred language from accept-language. Empty string is returned if nothing is set.
+func LocaleFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	if values := md.Get(LocaleMetadataKey); len(values) > 0 && strings.TrimSpace(values[0]) != "" {
+		return strings.TrimSpace(values[0])
+	}
+
+	values := md.Get(acceptLanguageMetadataKey)
+	if len(values) == 0 {
+		return ""
+	}
+
+	// languages are ordered by preference, quality values are skipped
+	first, _, _ := strings.Cut(values[0], ",")
+	tag, _, _ := strings.Cut(first, ";")
+	tag = strings.TrimSpace(tag)
+	if tag == "*" {
+		return ""
+	}
+
+	return tag
+}
@@ -0,0 +1,41 @@
+package grpcsrv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestUnitLocaleFromContext(t *testing.T) {
+	for name, tc := range map[string]struct {
+		md       metadata.MD
+		expected string
+	}{
+		"no metadata": {
+			expected: "",
+		},
+		"explicit locale": {
+			md:       metadata.Pairs(LocaleMetadataKey, "pt-BR", "accept-language", "de"),
+			expected: "pt-BR",
+		},
+		"accept language": {
+			md:       metadata.Pairs("accept-language", "de-CH;q=0.9, en;q=0.8"),
+			expected: "de-CH",
+		},
+		"any language": {
+			md:       metadata.Pairs("accept-language", "*"),
+			expected: "",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tc.md)
+			}
+
+			require.Equal(t, tc.expected, LocaleFromContext(ctx))
+		})
+	}
+}
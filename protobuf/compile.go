@@ -0,0 +1,5 @@
+// Package protobuf contains the storage protocol: methods which are used by goverland services and admin tools
+// and are not a part of the inbox api protocol.
+//
+//go:generate sh ../bin/compile_proto.sh . . inboxstorage/*.proto
+package protobuf
@@ -0,0 +1,4 @@
+alter table user_subscriptions
+    add push_settings jsonb default null;
+
+comment on column user_subscriptions.push_settings is 'per dao overrides of the user push settings';
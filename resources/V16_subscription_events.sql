@@ -0,0 +1,31 @@
+create table user_subscription_events
+(
+    id              bigserial
+        primary key,
+    created_at      timestamp with time zone default now() not null,
+    subscription_id uuid                                   not null,
+    user_id         uuid                                   not null,
+    dao_id          uuid                                   not null,
+    type            text                                   not null
+);
+
+comment on column user_subscription_events.type is 'event type: subscribed, unsubscribed';
+
+create index user_subscription_events_dao_id_created_at_idx
+    on user_subscription_events (dao_id, created_at);
+
+create index user_subscription_events_created_at_idx
+    on user_subscription_events (created_at);
+
+insert into user_subscription_events (created_at, subscription_id, user_id, dao_id, type)
+select created_at, id, user_id, dao_id, 'subscribed'
+from user_subscriptions
+where user_id is not null
+  and dao_id is not null;
+
+insert into user_subscription_events (created_at, subscription_id, user_id, dao_id, type)
+select deleted_at, id, user_id, dao_id, 'unsubscribed'
+from user_subscriptions
+where deleted_at is not null
+  and user_id is not null
+  and dao_id is not null;
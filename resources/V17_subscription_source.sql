@@ -0,0 +1,12 @@
+alter table user_subscriptions
+    add source text default 'app' not null;
+
+comment on column user_subscriptions.source is 'subscription source: app, onboarding, wallet_recommendation, etc';
+
+alter table user_subscription_events
+    add source text default 'app' not null;
+
+comment on column user_subscription_events.source is 'event source: app, onboarding, wallet_recommendation, undo, etc';
+
+create index user_subscription_events_user_id_idx
+    on user_subscription_events (user_id);
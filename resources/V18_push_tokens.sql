@@ -0,0 +1,12 @@
+create table push_tokens
+(
+    user_id     text                                   not null,
+    device_uuid text                                   not null,
+    created_at  timestamp with time zone default now() not null,
+    updated_at  timestamp with time zone default now() not null,
+    token       text                                   not null,
+    constraint push_tokens_pk
+        primary key (user_id, device_uuid)
+);
+
+comment on column push_tokens.token is 'encrypted push token';
@@ -0,0 +1,17 @@
+create table push_token_metadata
+(
+    user_id             text                                   not null,
+    device_uuid         text                                   not null,
+    provider            text                                   not null,
+    platform            text                                   not null,
+    app_version         text                                   not null,
+    created_at          timestamp with time zone default now() not null,
+    refreshed_at        timestamp with time zone default now() not null,
+    invalidated_at      timestamp with time zone,
+    invalidation_reason text                     default ''    not null,
+    constraint push_token_metadata_pk
+        primary key (user_id, device_uuid)
+);
+
+create index push_token_metadata_refreshed_at_idx on push_token_metadata (refreshed_at);
+create index push_token_metadata_invalidated_at_idx on push_token_metadata (invalidated_at) where invalidated_at is not null;
@@ -0,0 +1,9 @@
+create table push_tokens_legacy_migration
+(
+    user_id     text                     not null
+        constraint push_tokens_legacy_migration_pk
+            primary key,
+    device_uuid text default ''          not null,
+    status      text                     not null,
+    migrated_at timestamp with time zone not null
+);
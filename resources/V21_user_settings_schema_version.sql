@@ -0,0 +1,2 @@
+alter table user_settings
+    add column schema_version integer default 1 not null;
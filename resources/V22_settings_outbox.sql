@@ -0,0 +1,32 @@
+-- keep only the latest settings by user and type to make them unique
+delete
+from user_settings s
+    using user_settings d
+where s.user_id = d.user_id
+  and s.type = d.type
+  and (s.updated_at, s.ctid) < (d.updated_at, d.ctid);
+
+create unique index user_settings_user_id_type_uidx
+    on user_settings (user_id, type);
+
+alter table user_settings
+    add column revision bigint default 0 not null;
+
+create table settings_outbox
+(
+    id           bigserial
+        constraint settings_outbox_pk
+            primary key,
+    created_at   timestamp with time zone default now() not null,
+    subject      text                                   not null,
+    payload      jsonb                                  not null,
+    published_at timestamp with time zone
+);
+
+create index settings_outbox_pending_idx
+    on settings_outbox (id)
+    where published_at is null;
+
+create index settings_outbox_published_at_idx
+    on settings_outbox (published_at)
+    where published_at is not null;
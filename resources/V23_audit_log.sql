@@ -0,0 +1,20 @@
+create table audit_log
+(
+    id         bigserial
+        constraint audit_log_pk
+            primary key,
+    created_at timestamp with time zone default now() not null,
+    actor      text                                   not null,
+    user_id    uuid                                   not null,
+    operation  text                                   not null,
+    before     jsonb,
+    after      jsonb,
+    metadata   jsonb                    default '{}'  not null
+);
+
+create index audit_log_user_id_created_at_idx
+    on audit_log (user_id, created_at);
+
+-- audit log is append-only
+create rule audit_log_no_update as on update to audit_log do instead nothing;
+create rule audit_log_no_delete as on delete to audit_log do instead nothing;
@@ -0,0 +1,16 @@
+alter table achievements
+    add updated_at timestamp default now();
+
+alter table achievements
+    add backfilled_at timestamp default null;
+
+-- achievements created by migrations are already linked to users
+update achievements
+set backfilled_at = now();
+
+-- keep text sort order comparable as numbers
+update achievements a
+set sort_order = lpad(o.position::text, 6, '0')
+from (select id, row_number() over (order by sort_order) as position
+      from achievements) o
+where a.id = o.id;
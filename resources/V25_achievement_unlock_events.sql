@@ -0,0 +1,11 @@
+alter table user_achievements
+    add unlock_published_at timestamp default null;
+
+-- do not notify about achievements unlocked before
+update user_achievements
+set unlock_published_at = now()
+where achieved_at is not null;
+
+create index idx_user_achievements_unpublished_unlocks
+    on user_achievements (achieved_at)
+    where achieved_at is not null and unlock_published_at is null;
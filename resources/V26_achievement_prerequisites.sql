@@ -0,0 +1,16 @@
+alter table achievements
+    add prerequisites jsonb default '[]' not null;
+
+alter table achievements
+    add series text default '' not null;
+
+alter table achievements
+    add tier int default 0 not null;
+
+update achievements
+set prerequisites = jsonb_build_array(trim(both '"' from blocked_by))
+where blocked_by is not null
+  and trim(both '"' from blocked_by) <> '';
+
+alter table achievements
+    drop column blocked_by;
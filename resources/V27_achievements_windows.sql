@@ -0,0 +1,8 @@
+alter table achievements
+    add starts_at timestamp with time zone;
+
+alter table achievements
+    add ends_at timestamp with time zone;
+
+alter table achievements
+    add expiry_policy text default 'keep' not null;
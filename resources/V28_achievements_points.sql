@@ -0,0 +1,8 @@
+alter table achievements
+    add points int default 1 not null;
+
+comment on column achievements.points is 'points summed for ranking users in leaderboards';
+
+create index user_achievements_achieved_at_idx
+    on user_achievements (achieved_at)
+    where achieved_at is not null;
@@ -0,0 +1,17 @@
+create table achievement_manual_changes
+(
+    id             bigserial
+        constraint achievement_manual_changes_pk
+            primary key,
+    created_at     timestamp with time zone default now() not null,
+    user_id        uuid                                   not null,
+    achievement_id text                                   not null,
+    action         text                                   not null,
+    actor          text                                   not null,
+    reason         text                                   not null
+);
+
+comment on column achievement_manual_changes.action is 'grant or revoke';
+
+create index achievement_manual_changes_user_id_idx
+    on achievement_manual_changes (user_id, created_at);
@@ -0,0 +1,19 @@
+create table achievement_progress_events
+(
+    id             bigserial
+        constraint achievement_progress_events_pk
+            primary key,
+    created_at     timestamp with time zone default now() not null,
+    user_id        uuid                                   not null,
+    achievement_id text                                   not null,
+    old_progress   int                                    not null,
+    new_progress   int                                    not null,
+    goal           int                                    not null,
+    achieved       bool                     default false not null,
+    trigger        text                                   not null
+);
+
+comment on column achievement_progress_events.trigger is 'event caused the change: recalculate:vote, etc';
+
+create index achievement_progress_events_user_id_achievement_id_idx
+    on achievement_progress_events (user_id, achievement_id, created_at);
@@ -0,0 +1,19 @@
+create table achievement_translations
+(
+    achievement_id      text                                   not null,
+    locale              text                                   not null,
+    created_at          timestamp with time zone default now() not null,
+    updated_at          timestamp with time zone default now() not null,
+    title               text                                   not null,
+    subtitle            text                     default ''    not null,
+    description         text                     default ''    not null,
+    achievement_message text                     default ''    not null,
+    images              jsonb                    default '[]'  not null,
+    constraint achievement_translations_pk
+        primary key (achievement_id, locale)
+);
+
+comment on column achievement_translations.locale is 'lowercase language tag: de, pt-br, etc';
+
+alter table user_sessions
+    add locale text default '' not null;
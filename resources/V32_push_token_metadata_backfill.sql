@@ -0,0 +1,4 @@
+insert into push_token_metadata (user_id, device_uuid, provider, platform, app_version, created_at, refreshed_at)
+select t.user_id, t.device_uuid, 'unknown', '', '', t.created_at, now()
+from push_tokens t
+on conflict (user_id, device_uuid) do nothing;
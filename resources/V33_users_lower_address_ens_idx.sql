@@ -0,0 +1,6 @@
+-- users are looked up by the address or ens name in any case
+create index users_lower_address_idx
+    on users (lower(address));
+
+create index users_lower_ens_idx
+    on users (lower(ens));
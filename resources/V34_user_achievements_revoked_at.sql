@@ -0,0 +1,4 @@
+alter table user_achievements
+    add revoked_at timestamp default null;
+
+comment on column user_achievements.revoked_at is 'manually revoked achievements are not calculated until granted again';
@@ -0,0 +1,25 @@
+-- unlock events are stored to the settings outbox in the transaction of the unlock, move not published ones there
+insert into settings_outbox (created_at, subject, payload)
+select now(),
+       'inbox.achievement.unlocked',
+       jsonb_build_object(
+               'id', ua.user_id || ':' || ua.achievement_id || ':' || extract(epoch from ua.achieved_at::timestamptz)::bigint,
+               'user_id', ua.user_id,
+               'achievement_id', ua.achievement_id,
+               'title', a.title,
+               'subtitle', a.subtitle,
+               'achievement_message', coalesce(a.achievement_message, ''),
+               'images', a.images,
+               'exclusive', a.exclusive,
+               'achieved_at', ua.achieved_at::timestamptz
+       )
+from user_achievements ua
+         inner join achievements a on a.id = ua.achievement_id
+where ua.achieved_at is not null
+  and ua.unlock_published_at is null
+order by ua.achieved_at;
+
+drop index idx_user_achievements_unpublished_unlocks;
+
+alter table user_achievements
+    drop column unlock_published_at;